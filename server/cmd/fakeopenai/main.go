@@ -0,0 +1,40 @@
+// Command fakeopenai serves a scripted, OpenAI-compatible API for demos of
+// the AI features without a real provider. Point the app's AI settings at
+// http://localhost:8090/v1 with any API key.
+//
+//	go run ./cmd/fakeopenai [-addr :8090] [-script script.json]
+//
+// Without -script it answers every recommendation with the same cocktail.
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/nguyenjessev/liquor-locker/internal/fakeopenai"
+)
+
+//go:embed demo.json
+var demoScript []byte
+
+func main() {
+	addr := flag.String("addr", ":8090", "address to listen on")
+	path := flag.String("script", "", "JSON script to serve instead of the demo")
+	flag.Parse()
+
+	var script fakeopenai.Script
+	if *path != "" {
+		var err error
+		if script, err = fakeopenai.LoadScript(*path); err != nil {
+			log.Fatal(err)
+		}
+	} else if err := json.Unmarshal(demoScript, &script); err != nil {
+		log.Fatal("invalid demo script: ", err)
+	}
+
+	log.Printf("Serving a fake OpenAI API on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, fakeopenai.New(script)))
+}
@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/ai/configure": {
             "post": {
-                "description": "Configure the OpenAI service with base URL and API key",
+                "description": "Configure the OpenAI service with base URL and API key. This sets the profile named \"default\" and makes it the default. The settings are saved, with the key encrypted, if the server has an ENCRYPTION_KEY",
                 "consumes": [
                     "application/json"
                 ],
@@ -81,7 +81,7 @@ const docTemplate = `{
         },
         "/ai/models": {
             "get": {
-                "description": "Returns a list of available AI models",
+                "description": "Returns a list of available AI models from the default profile, or from the named one",
                 "produces": [
                     "application/json"
                 ],
@@ -89,6 +89,14 @@ const docTemplate = `{
                     "ai"
                 ],
                 "summary": "List available AI models",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile name",
+                        "name": "profile",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -99,6 +107,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "405": {
                         "description": "Method Not Allowed",
                         "schema": {
@@ -131,7 +148,7 @@ const docTemplate = `{
         },
         "/ai/service": {
             "get": {
-                "description": "Check if the AI service is initialized",
+                "description": "Check if the AI service is initialized, and see the default profile's base URL and the last four characters of its API key",
                 "produces": [
                     "application/json"
                 ],
@@ -143,10 +160,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "boolean"
-                            }
+                            "$ref": "#/definitions/models.AIServiceStatus"
                         }
                     },
                     "405": {
@@ -161,26 +175,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/bottles": {
+        "/api/admin/backups": {
             "get": {
-                "description": "Returns a list of all bottles",
+                "description": "GET lists the backups in the backup directory, newest first. POST takes a backup now and prunes old ones by the retention policy",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "bottles"
+                    "admin"
                 ],
-                "summary": "Get all bottles",
+                "summary": "List or take database backups",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.BottleResponse"
+                                "$ref": "#/definitions/models.Backup"
                             }
                         }
                     },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Backup"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -193,7 +213,66 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Adds a new bottle to the collection",
+                "description": "GET lists the backups in the backup directory, newest first. POST takes a backup now and prunes old ones by the retention policy",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List or take database backups",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Backup"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Backup"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ai/profiles": {
+            "get": {
+                "description": "Lists the AI provider profiles in the order they are tried, the default first. API keys are redacted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ai"
+                ],
+                "summary": "List AI profiles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AIProfileResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Saves an AI provider profile with its API key encrypted. Set provider to \"ollama\" to use Ollama's native API at its root URL, such as http://localhost:11434; otherwise the API must be OpenAI-compatible. An Ollama profile needs no API key. The first profile becomes the default. Needs the server's ENCRYPTION_KEY",
                 "consumes": [
                     "application/json"
                 ],
@@ -201,17 +280,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bottles"
+                    "ai"
                 ],
-                "summary": "Create a new bottle",
+                "summary": "Create an AI profile",
                 "parameters": [
                     {
-                        "description": "Bottle to add",
-                        "name": "bottle",
+                        "description": "Profile to add",
+                        "name": "profile",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateBottleRequest"
+                            "$ref": "#/definitions/models.AIProfileRequest"
                         }
                     }
                 ],
@@ -219,7 +298,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.BottleResponse"
+                            "$ref": "#/definitions/models.AIProfileResponse"
                         }
                     },
                     "400": {
@@ -231,6 +310,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -239,24 +327,33 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/bottles/{id}": {
+        "/api/ai/profiles/{id}": {
             "get": {
-                "description": "Returns a single bottle by its ID",
+                "description": "Returns a single AI provider profile with its API key redacted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "bottles"
+                    "ai"
                 ],
-                "summary": "Get a bottle by ID",
+                "summary": "Get an AI profile by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Bottle ID",
+                        "description": "Profile ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -266,7 +363,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.BottleResponse"
+                            "$ref": "#/definitions/models.AIProfileResponse"
                         }
                     },
                     "400": {
@@ -290,7 +387,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates a bottle's information by its ID",
+                "description": "Replaces an AI provider profile's settings. Leave api_key empty to keep the current key. Setting is_default makes it the default; a profile stops being the default only when another one takes over",
                 "consumes": [
                     "application/json"
                 ],
@@ -298,24 +395,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bottles"
+                    "ai"
                 ],
-                "summary": "Update a bottle by ID",
+                "summary": "Update an AI profile",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Bottle ID",
+                        "description": "Profile ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Bottle update info",
-                        "name": "bottle",
+                        "description": "Profile settings",
+                        "name": "profile",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateBottleRequest"
+                            "$ref": "#/definitions/models.AIProfileRequest"
                         }
                     }
                 ],
@@ -323,7 +420,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.BottleResponse"
+                            "$ref": "#/definitions/models.AIProfileResponse"
                         }
                     },
                     "400": {
@@ -343,30 +440,9 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Deletes a bottle from the collection by its ID",
-                "tags": [
-                    "bottles"
-                ],
-                "summary": "Delete a bottle by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Bottle ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -374,8 +450,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -384,39 +469,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/cocktails/recommendation": {
-            "post": {
-                "description": "Get a cocktail recommendation from the AI",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Deletes an AI provider profile. If it was the default, the oldest remaining profile becomes the default",
                 "tags": [
                     "ai"
                 ],
-                "summary": "Recommend a cocktail",
+                "summary": "Delete an AI profile",
                 "parameters": [
                     {
-                        "description": "Model selection",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "integer",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -427,8 +498,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -457,23 +528,124 @@ const docTemplate = `{
                 }
             }
         },
-        "/fresh": {
+        "/api/bottles": {
             "get": {
-                "description": "Get a list of all fresh items",
+                "description": "Returns a list of bottles, newest first unless sorted, optionally filtered by their details and paged. Text filters ignore case. When there are more pages, the X-Next-Cursor and Link headers point to the next one.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "fresh"
+                    "bottles"
+                ],
+                "summary": "Get all bottles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category, e.g. whiskey",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subcategory, e.g. bourbon",
+                        "name": "subcategory",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Brand",
+                        "name": "brand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country of origin",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Region",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum ABV in percent",
+                        "name": "min_abv",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum ABV in percent",
+                        "name": "max_abv",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only opened (true) or sealed (false) items",
+                        "name": "opened",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name contains, ignoring case",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purchased after this date (YYYY-MM-DD or RFC 3339)",
+                        "name": "purchased_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name, price or open_date; prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page's X-Next-Cursor header",
+                        "name": "cursor",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all fresh items",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.FreshResponse"
+                                "$ref": "#/definitions/models.BottleResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -489,7 +661,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Add a new fresh item to the collection",
+                "description": "Adds a new bottle to the collection",
                 "consumes": [
                     "application/json"
                 ],
@@ -497,17 +669,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fresh"
+                    "bottles"
                 ],
-                "summary": "Create a new fresh item",
+                "summary": "Create a new bottle",
                 "parameters": [
                     {
-                        "description": "Fresh item to create",
-                        "name": "fresh",
+                        "description": "Bottle to add",
+                        "name": "bottle",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateFreshRequest"
+                            "$ref": "#/definitions/models.CreateBottleRequest"
                         }
                     }
                 ],
@@ -515,7 +687,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.FreshResponse"
+                            "$ref": "#/definitions/models.BottleResponse"
                         }
                     },
                     "400": {
@@ -539,20 +711,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/fresh/{id}": {
+        "/api/bottles/{id}": {
             "get": {
-                "description": "Get details of a specific fresh item",
+                "description": "Returns a single bottle by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "fresh"
+                    "bottles"
                 ],
-                "summary": "Get a fresh item by ID",
+                "summary": "Get a bottle by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Fresh ID",
+                        "description": "Bottle ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -562,7 +734,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.FreshResponse"
+                            "$ref": "#/definitions/models.BottleResponse"
                         }
                     },
                     "400": {
@@ -582,20 +754,11 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             },
             "put": {
-                "description": "Update an existing fresh item by ID",
+                "description": "Updates a bottle's information by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -603,24 +766,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fresh"
+                    "bottles"
                 ],
-                "summary": "Update a fresh item",
+                "summary": "Update a bottle by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Fresh ID",
+                        "description": "Bottle ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Fresh item to update",
-                        "name": "fresh",
+                        "description": "Bottle update info",
+                        "name": "bottle",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateFreshRequest"
+                            "$ref": "#/definitions/models.UpdateBottleRequest"
                         }
                     }
                 ],
@@ -628,7 +791,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.FreshResponse"
+                            "$ref": "#/definitions/models.BottleResponse"
                         }
                     },
                     "400": {
@@ -648,9 +811,39 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a bottle from the collection by its ID",
+                "tags": [
+                    "bottles"
+                ],
+                "summary": "Delete a bottle by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Bottle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -659,28 +852,45 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a fresh item by ID",
+            }
+        },
+        "/api/bottles/{id}/pour": {
+            "post": {
+                "description": "Decrements the bottle's remaining volume, records the pour, and marks the bottle finished when it reaches zero. A pour larger than what is left only takes what is left; a finished bottle can't be poured from",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "fresh"
+                    "bottles"
                 ],
-                "summary": "Delete a fresh item",
+                "summary": "Pour from a bottle",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Fresh ID",
+                        "description": "Bottle ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Amount poured in ml",
+                        "name": "pour",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PourRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BottleResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -700,6 +910,56 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/bottles/{id}/pours": {
+            "get": {
+                "description": "Returns every recorded pour from the bottle, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bottles"
+                ],
+                "summary": "Get a bottle's pour history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Bottle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Pour"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -712,30 +972,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/mixers": {
+        "/api/cocktails": {
             "get": {
-                "description": "Get a list of all mixers",
+                "description": "Returns every saved cocktail recipe with ingredients and steps",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "mixers"
+                    "cocktails"
                 ],
-                "summary": "Get all mixers",
+                "summary": "Get all cocktail recipes",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Mixer"
+                                "$ref": "#/definitions/models.SavedCocktailResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
             },
             "post": {
-                "description": "Add a new mixer to the collection",
+                "description": "Saves a cocktail recipe with its ingredients and ordered steps",
                 "consumes": [
                     "application/json"
                 ],
@@ -743,17 +1012,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "mixers"
+                    "cocktails"
                 ],
-                "summary": "Create a new mixer",
+                "summary": "Create a new cocktail recipe",
                 "parameters": [
                     {
-                        "description": "Mixer to create",
-                        "name": "mixer",
+                        "description": "Cocktail to save",
+                        "name": "cocktail",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Mixer"
+                            "$ref": "#/definitions/models.CreateCocktailRequest"
                         }
                     }
                 ],
@@ -761,7 +1030,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Mixer"
+                            "$ref": "#/definitions/models.SavedCocktailResponse"
                         }
                     },
                     "400": {
@@ -772,38 +1041,70 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/mixers/{id}": {
+        "/api/cocktails/history": {
             "get": {
-                "description": "Get details of a specific mixer",
+                "description": "Returns every recorded cocktail event with the inventory it used, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "mixers"
+                    "cocktails"
                 ],
-                "summary": "Get a mixer by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Mixer ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                "summary": "List made cocktails",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CocktailEvent"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
+                }
+            }
+        },
+        "/api/cocktails/makeable": {
+            "get": {
+                "description": "Compares saved recipes against bottles, mixers, fresh ingredients, bitters, syrups and garnishes that are in stock, returning recipes that can be made now and recipes missing exactly one ingredient",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cocktails"
                 ],
+                "summary": "List cocktails makeable from the inventory",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Mixer"
+                            "$ref": "#/definitions/models.MakeableResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -812,9 +1113,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing mixer by ID",
+            }
+        },
+        "/api/cocktails/recommendation/save": {
+            "post": {
+                "description": "Persists one cocktail returned by the recommendation endpoint, recording the model and prompt it came from",
                 "consumes": [
                     "application/json"
                 ],
@@ -822,32 +1125,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "mixers"
+                    "cocktails"
                 ],
-                "summary": "Update a mixer",
+                "summary": "Save an AI recommendation as a recipe",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Mixer ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Mixer to update",
-                        "name": "mixer",
+                        "description": "Recommended cocktail to save",
+                        "name": "recommendation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Mixer"
+                            "$ref": "#/definitions/models.SaveRecommendationRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Mixer"
+                            "$ref": "#/definitions/models.SavedCocktailResponse"
                         }
                     },
                     "400": {
@@ -859,8 +1155,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -869,28 +1165,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a mixer by ID",
+            }
+        },
+        "/api/cocktails/{id}": {
+            "get": {
+                "description": "Returns a single saved cocktail recipe by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "mixers"
+                    "cocktails"
                 ],
-                "summary": "Delete a mixer",
+                "summary": "Get a cocktail recipe by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Mixer ID",
+                        "description": "Cocktail ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SavedCocktailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     },
                     "404": {
                         "description": "Not Found",
@@ -902,27 +1212,3932 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
+            },
+            "put": {
+                "description": "Replaces a saved cocktail recipe, including its ingredients and steps",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cocktails"
+                ],
+                "summary": "Update a cocktail recipe by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cocktail ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cocktail update info",
+                        "name": "cocktail",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateCocktailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SavedCocktailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a saved cocktail recipe along with its ingredients and steps",
+                "tags": [
+                    "cocktails"
+                ],
+                "summary": "Delete a cocktail recipe by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cocktail ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cocktails/{id}/batch": {
+            "get": {
+                "description": "Scales the recipe to a number of servings, optionally converting volumes to metric or imperial units and adding water for pre-batched dilution",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cocktails"
+                ],
+                "summary": "Batch a cocktail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cocktail ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of servings",
+                        "name": "servings",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Fraction of the liquid volume to add as water, e.g. 0.2",
+                        "name": "dilution",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "metric or imperial; defaults to the recipe's units",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cocktails/{id}/history": {
+            "get": {
+                "description": "Returns the recorded events for one cocktail with the inventory each used, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cocktails"
+                ],
+                "summary": "List times a cocktail was made",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cocktail ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CocktailEvent"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cocktails/{id}/make": {
+            "post": {
+                "description": "Records that the cocktail was made and deducts each ingredient's quantity, scaled by servings, from matching bottles, mixers, fresh items and syrups with a tracked volume, bitters with tracked dashes and garnishes with a tracked count. Only volumes are listed in deductions. Ingredients, or the part of one, that could not be deducted are listed in skipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cocktails"
+                ],
+                "summary": "Make a cocktail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cocktail ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Number of servings, default 1",
+                        "name": "make",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.MakeCocktailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.CocktailEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/expiring": {
+            "get": {
+                "description": "Returns opened mixers, prepared fresh items and syrups that are expiring or have expired, soonest first. Passing days lists everything that expires within that many days instead",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expiring"
+                ],
+                "summary": "List items about to go bad",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Expiry window in days",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ExpiringItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/export": {
+            "get": {
+                "description": "Downloads every table as a versioned JSON archive that POST /api/restore accepts, on this or another server at the same schema version",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "archive"
+                ],
+                "summary": "Export the database",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Archive"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/import": {
+            "post": {
+                "description": "Creates many items of one kind from a CSV file with a header row or a JSON array of the kind's create requests. CSV headers match fields by name; map renames a header, as in map=Cost:price, or skips it with map=Notes:-. Every row is checked first and the report lists each bad row; only when all rows are good, and it isn't a dry run, are they saved, in one transaction",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Import inventory in bulk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bottle, mixer, fresh, bitters, syrup or garnish",
+                        "name": "kind",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv or json; defaults to csv for a text/csv body and json otherwise",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "CSV header renames as header:field",
+                        "name": "map",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check the rows without saving them",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry run, with any bad rows",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImportReport"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Some rows are bad; nothing was saved",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImportReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ingredients": {
+            "get": {
+                "description": "Returns every catalog ingredient with its parent and aliases",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Get the ingredient catalog",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CatalogIngredientResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a canonical ingredient, optionally under a parent and with aliases",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Create a catalog ingredient",
+                "parameters": [
+                    {
+                        "description": "Ingredient to add",
+                        "name": "ingredient",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateCatalogIngredientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.CatalogIngredientResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ingredients/links": {
+            "put": {
+                "description": "Sets or clears the catalog ingredient of a bottle, mixer or fresh item",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Link an inventory item to a catalog ingredient",
+                "parameters": [
+                    {
+                        "description": "Link to set; kind is bottle, mixer or fresh",
+                        "name": "link",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LinkIngredientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ingredients/resolve": {
+            "get": {
+                "description": "Matches a name such as \"Buffalo Trace Bourbon\" against catalog names and aliases",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Resolve a free-text name to a catalog ingredient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name to resolve",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResolveIngredientResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ingredients/{id}": {
+            "get": {
+                "description": "Returns a single catalog ingredient with its aliases",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Get a catalog ingredient by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ingredient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CatalogIngredientResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a catalog ingredient and its aliases, re-parenting its children and unlinking inventory",
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Delete a catalog ingredient by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ingredient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ingredients/{id}/aliases": {
+            "post": {
+                "description": "Registers another name that should resolve to the ingredient",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Add an alias to a catalog ingredient",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ingredient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alias to add",
+                        "name": "alias",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddAliasRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.CatalogIngredientResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mixers/{id}": {
+            "delete": {
+                "description": "Delete a mixer from the collection by its ID",
+                "tags": [
+                    "mixers"
+                ],
+                "summary": "Delete a mixer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mixer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mixers/{id}/pour": {
+            "post": {
+                "description": "Decrements the mixer's remaining volume, records the pour, and marks the mixer finished when it reaches zero. A pour larger than what is left only takes what is left; a finished mixer can't be poured from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mixers"
+                ],
+                "summary": "Pour from a mixer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mixer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount poured in ml",
+                        "name": "pour",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PourRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MixerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mixers/{id}/pours": {
+            "get": {
+                "description": "Returns every recorded pour from the mixer, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mixers"
+                ],
+                "summary": "Get a mixer's pour history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mixer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Pour"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/restore": {
+            "post": {
+                "description": "Writes an archive from GET /api/export back in one transaction. The archive must be at the database's schema version. replace empties every table first and keeps the archived IDs; merge keeps existing rows, adds the archived ones under new IDs with their references rewritten, and skips those already in the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "archive"
+                ],
+                "summary": "Restore the database from an archive",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "replace or merge",
+                        "name": "mode",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Archive from GET /api/export",
+                        "name": "archive",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Archive"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RestoreReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/search": {
+            "get": {
+                "description": "Finds inventory items and saved cocktail recipes by name. Bottles also match on their category, brand and origin, bitters on their brand, and recipes on their description and ingredients. Every word must match the start of a word; hits come best first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search the whole bar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of hits, 1 to 100 (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SearchHit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/shopping-lists": {
+            "get": {
+                "description": "Returns every saved shopping list with its items, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping-lists"
+                ],
+                "summary": "Get all shopping lists",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ShoppingList"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Builds a list of the ingredients the chosen recipes need but the inventory lacks, merging ingredients whose names match and adding up their quantities. Recipes are chosen by ID, by missing_one for every saved recipe missing exactly one ingredient, or both",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping-lists"
+                ],
+                "summary": "Create a shopping list",
+                "parameters": [
+                    {
+                        "description": "Recipes to shop for",
+                        "name": "list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateShoppingListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShoppingList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/shopping-lists/{id}": {
+            "get": {
+                "description": "Returns a shopping list as JSON, a plain-text checklist or CSV",
+                "produces": [
+                    "application/json",
+                    "text/plain",
+                    "text/csv"
+                ],
+                "tags": [
+                    "shopping-lists"
+                ],
+                "summary": "Get a shopping list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shopping list ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default), text or csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShoppingList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a shopping list and its items",
+                "tags": [
+                    "shopping-lists"
+                ],
+                "summary": "Delete a shopping list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shopping list ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/shopping-lists/{id}/items/{itemId}": {
+            "put": {
+                "description": "Marks a shopping list item as bought, or clears the mark",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping-lists"
+                ],
+                "summary": "Check off a shopping list item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shopping list ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Checked state",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CheckShoppingItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShoppingListItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/shopping-lists/{id}/items/{itemId}/convert": {
+            "post": {
+                "description": "Creates a bottle, mixer, fresh item, bitters, syrup or garnish named after the shopping list item and checks the item off. Each item can be converted once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping-lists"
+                ],
+                "summary": "Add a bought item to the inventory",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shopping list ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Inventory kind and purchase details",
+                        "name": "convert",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ConvertShoppingItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShoppingListItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/bitters": {
+            "get": {
+                "description": "Returns a list of bitters, newest first unless sorted, optionally filtered and paged. When there are more pages, the X-Next-Cursor and Link headers point to the next one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bitters"
+                ],
+                "summary": "Get all bitters",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only opened (true) or sealed (false) items",
+                        "name": "opened",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name contains, ignoring case",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purchased after this date (YYYY-MM-DD or RFC 3339)",
+                        "name": "purchased_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name, price or open_date; prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page's X-Next-Cursor header",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BittersResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds bitters to the collection",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bitters"
+                ],
+                "summary": "Create bitters",
+                "parameters": [
+                    {
+                        "description": "Bitters to create",
+                        "name": "bitters",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateBittersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.BittersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/bitters/{id}": {
+            "get": {
+                "description": "Returns the bitters with the given ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bitters"
+                ],
+                "summary": "Get bitters by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Bitters ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BittersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the bitters' fields. A brand or dash count left out is kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bitters"
+                ],
+                "summary": "Update bitters",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Bitters ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated bitters",
+                        "name": "bitters",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateBittersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BittersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes the bitters with the given ID",
+                "tags": [
+                    "bitters"
+                ],
+                "summary": "Delete bitters",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Bitters ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/cocktails/recommendation": {
+            "post": {
+                "description": "Get a cocktail recommendation from the AI. Set mode to \"use_expiring\" to build it around fresh ingredients and opened mixers nearing expiry. The named profile, or the default one, is asked first with the given model or its default model; if it fails or times out, the other profiles are tried in turn with their default models. The X-AI-Profile header names the profile that answered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ai"
+                ],
+                "summary": "Recommend a cocktail",
+                "parameters": [
+                    {
+                        "description": "Model, mode and profile selection",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/fresh": {
+            "get": {
+                "description": "Get a list of fresh items, newest first unless sorted, optionally filtered and paged. When there are more pages, the X-Next-Cursor and Link headers point to the next one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fresh"
+                ],
+                "summary": "Get all fresh items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name contains, ignoring case",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purchased after this date (YYYY-MM-DD or RFC 3339)",
+                        "name": "purchased_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name, price or open_date (the prepared date); prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page's X-Next-Cursor header",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.FreshResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a new fresh item to the collection",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fresh"
+                ],
+                "summary": "Create a new fresh item",
+                "parameters": [
+                    {
+                        "description": "Fresh item to create",
+                        "name": "fresh",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateFreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.FreshResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/fresh/{id}": {
+            "get": {
+                "description": "Get details of a specific fresh item",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fresh"
+                ],
+                "summary": "Get a fresh item by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fresh ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FreshResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing fresh item by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fresh"
+                ],
+                "summary": "Update a fresh item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fresh ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fresh item to update",
+                        "name": "fresh",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateFreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FreshResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a fresh item by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fresh"
+                ],
+                "summary": "Delete a fresh item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fresh ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/garnishes": {
+            "get": {
+                "description": "Returns a list of garnishes, newest first unless sorted, optionally filtered and paged. When there are more pages, the X-Next-Cursor and Link headers point to the next one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "garnishes"
+                ],
+                "summary": "Get all garnishes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name contains, ignoring case",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purchased after this date (YYYY-MM-DD or RFC 3339)",
+                        "name": "purchased_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name or price; prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page's X-Next-Cursor header",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GarnishResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a garnish to the collection",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "garnishes"
+                ],
+                "summary": "Create a garnish",
+                "parameters": [
+                    {
+                        "description": "Garnish to create",
+                        "name": "garnish",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateGarnishRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.GarnishResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/garnishes/{id}": {
+            "get": {
+                "description": "Returns the garnish with the given ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "garnishes"
+                ],
+                "summary": "Get a garnish by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Garnish ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GarnishResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the garnish's fields. A quantity left out is kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "garnishes"
+                ],
+                "summary": "Update a garnish",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Garnish ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated garnish",
+                        "name": "garnish",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateGarnishRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GarnishResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes the garnish with the given ID",
+                "tags": [
+                    "garnishes"
+                ],
+                "summary": "Delete a garnish",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Garnish ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/mixers": {
+            "get": {
+                "description": "Returns a list of mixers, newest first unless sorted, optionally filtered and paged. When there are more pages, the X-Next-Cursor and Link headers point to the next one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mixers"
+                ],
+                "summary": "Get all mixers",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only opened (true) or sealed (false) items",
+                        "name": "opened",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name contains, ignoring case",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purchased after this date (YYYY-MM-DD or RFC 3339)",
+                        "name": "purchased_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name, price or open_date; prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page's X-Next-Cursor header",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MixerResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a new mixer to the collection",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mixer"
+                ],
+                "summary": "Create a new mixer",
+                "parameters": [
+                    {
+                        "description": "Mixer item to create",
+                        "name": "mixer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateMixerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.MixerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/mixers/{id}": {
+            "get": {
+                "description": "Get details of a specific mixer",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mixers"
+                ],
+                "summary": "Get a mixer by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mixer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Mixer"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing mixer by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mixers"
+                ],
+                "summary": "Update a mixer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mixer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Mixer to update",
+                        "name": "mixer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Mixer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Mixer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/syrups": {
+            "get": {
+                "description": "Returns a list of syrups, newest first unless sorted, optionally filtered and paged. When there are more pages, the X-Next-Cursor and Link headers point to the next one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "syrups"
+                ],
+                "summary": "Get all syrups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name contains, ignoring case",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purchased after this date (YYYY-MM-DD or RFC 3339)",
+                        "name": "purchased_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name, price or open_date (the made date); prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page's X-Next-Cursor header",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SyrupResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a syrup to the collection",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "syrups"
+                ],
+                "summary": "Create a syrup",
+                "parameters": [
+                    {
+                        "description": "Syrup to create",
+                        "name": "syrup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateSyrupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SyrupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/syrups/{id}": {
+            "get": {
+                "description": "Returns the syrup with the given ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "syrups"
+                ],
+                "summary": "Get a syrup by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Syrup ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SyrupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the syrup's fields. A ratio, size or remaining volume left out is kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "syrups"
+                ],
+                "summary": "Update a syrup",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Syrup ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated syrup",
+                        "name": "syrup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateSyrupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SyrupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes the syrup with the given ID",
+                "tags": [
+                    "syrups"
+                ],
+                "summary": "Delete a syrup",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Syrup ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/syrups/{id}/pour": {
+            "post": {
+                "description": "Decrements the syrup's remaining volume, records the pour, and marks the syrup finished when it reaches zero. A pour larger than what is left only takes what is left; a finished syrup can't be poured from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "syrups"
+                ],
+                "summary": "Pour from a syrup",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Syrup ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount poured in ml",
+                        "name": "pour",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PourRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SyrupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/syrups/{id}/pours": {
+            "get": {
+                "description": "Returns every recorded pour from the syrup, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "syrups"
+                ],
+                "summary": "Get a syrup's pour history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Syrup ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Pour"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
     },
     "definitions": {
         "handlers.ConfigureRequest": {
             "type": "object",
             "properties": {
-                "api_key": {
+                "api_key": {
+                    "type": "string"
+                },
+                "base_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AIProfileRequest": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "type": "string"
+                },
+                "base_url": {
+                    "type": "string"
+                },
+                "default_model": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "Provider is \"openai\" (the default) or \"ollama\".",
+                    "type": "string"
+                },
+                "timeout_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AIProfileResponse": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "description": "APIKey shows only the last four characters of the key, as in \"****abcd\".",
+                    "type": "string"
+                },
+                "base_url": {
+                    "type": "string"
+                },
+                "default_model": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "ready": {
+                    "description": "Ready is false if the saved key can't be decrypted with the server's\nENCRYPTION_KEY; the profile is skipped until its key is entered again.",
+                    "type": "boolean"
+                },
+                "timeout_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AIServiceStatus": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "description": "APIKey shows only the last four characters of the key, as in \"****abcd\".",
+                    "type": "string"
+                },
+                "base_url": {
+                    "type": "string"
+                },
+                "initialized": {
+                    "type": "boolean"
+                },
+                "persisted": {
+                    "description": "Persisted is true if the settings are saved and survive a restart. It\nis false when the server has no ENCRYPTION_KEY to protect them with.",
+                    "type": "boolean"
+                },
+                "profile": {
+                    "type": "string"
+                },
+                "profiles": {
+                    "description": "Profiles counts every profile, including fallbacks.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AddAliasRequest": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Archive": {
+            "type": "object",
+            "properties": {
+                "exported_at": {
+                    "type": "string"
+                },
+                "format": {
+                    "description": "Format is always \"liquor-locker-archive\", to reject unrelated files.",
+                    "type": "string"
+                },
+                "schema_version": {
+                    "description": "SchemaVersion is the database migration the tables were exported at.\nAn archive can only be restored into a database at the same migration.",
+                    "type": "integer"
+                },
+                "tables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "object",
+                            "additionalProperties": {}
+                        }
+                    }
+                },
+                "version": {
+                    "description": "Version is the version of the archive layout itself.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Backup": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BatchIngredient": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "original": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "string"
+                },
+                "scaled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "cocktail_id": {
+                    "type": "integer"
+                },
+                "dilution": {
+                    "type": "number"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BatchIngredient"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "servings": {
+                    "type": "integer"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Step"
+                    }
+                },
+                "total_volume": {
+                    "type": "string"
+                },
+                "units": {
+                    "type": "string"
+                },
+                "water": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BittersResponse": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string"
+                },
+                "dashes_remaining": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "open_date": {
+                    "type": "string"
+                },
+                "opened": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BottleResponse": {
+            "type": "object",
+            "properties": {
+                "abv": {
+                    "type": "number"
+                },
+                "age_statement": {
+                    "type": "string"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "finished": {
+                    "type": "boolean"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ingredient_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "open_date": {
+                    "type": "string"
+                },
+                "opened": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "proof": {
+                    "description": "Proof is twice the ABV, in US proof.",
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "remaining_ml": {
+                    "type": "number"
+                },
+                "size_ml": {
+                    "type": "number"
+                },
+                "subcategory": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CatalogIngredientResponse": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CheckShoppingItemRequest": {
+            "type": "object",
+            "properties": {
+                "checked": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.CocktailEvent": {
+            "type": "object",
+            "properties": {
+                "cocktail_id": {
+                    "type": "integer"
+                },
+                "cocktail_name": {
+                    "type": "string"
+                },
+                "deductions": {
+                    "description": "Deductions are the pours taken from inventory for this event.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Pour"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "made_at": {
+                    "type": "string"
+                },
+                "servings": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "Skipped lists ingredients that could not be deducted. It is only\nreported when the event is created.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SkippedIngredient"
+                    }
+                }
+            }
+        },
+        "models.CocktailResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IngredientResponse"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StepResponse"
+                    }
+                }
+            }
+        },
+        "models.ConvertShoppingItemRequest": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateBittersRequest": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string"
+                },
+                "dashes_remaining": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "open_date": {
+                    "type": "string"
+                },
+                "opened": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateBottleRequest": {
+            "type": "object",
+            "properties": {
+                "abv": {
+                    "type": "number"
+                },
+                "age_statement": {
+                    "type": "string"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "open_date": {
+                    "type": "string"
+                },
+                "opened": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "remaining_ml": {
+                    "type": "number"
+                },
+                "size_ml": {
+                    "type": "number"
+                },
+                "subcategory": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateCatalogIngredientRequest": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CreateCocktailRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IngredientRequest"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StepRequest"
+                    }
+                }
+            }
+        },
+        "models.CreateFreshRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "prepared_date": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "remaining_ml": {
+                    "type": "number"
+                },
+                "shelf_life_days": {
+                    "type": "integer"
+                },
+                "size_ml": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.CreateGarnishRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CreateMixerRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "open_date": {
+                    "type": "string"
+                },
+                "opened": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "remaining_ml": {
+                    "type": "number"
+                },
+                "shelf_life_days": {
+                    "type": "integer"
+                },
+                "size_ml": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.CreateShoppingListRequest": {
+            "type": "object",
+            "properties": {
+                "cocktail_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "missing_one": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateSyrupRequest": {
+            "type": "object",
+            "properties": {
+                "made_date": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "ratio": {
+                    "type": "string"
+                },
+                "remaining_ml": {
+                    "type": "number"
+                },
+                "size_ml": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ExpiringItem": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "shelf_life_days": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FreshResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "finished": {
+                    "type": "boolean"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ingredient_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prepared_date": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "remaining_ml": {
+                    "type": "number"
+                },
+                "shelf_life_days": {
+                    "type": "integer"
+                },
+                "size_ml": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GarnishResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ImportError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ImportReport": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportError"
+                    }
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Ingredient": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.IngredientRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.IngredientResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
                     "type": "string"
+                }
+            }
+        },
+        "models.LinkIngredientRequest": {
+            "type": "object",
+            "properties": {
+                "ingredient_id": {
+                    "type": "integer"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MakeCocktailRequest": {
+            "type": "object",
+            "properties": {
+                "servings": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MakeableResponse": {
+            "type": "object",
+            "properties": {
+                "makeable": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SavedCocktailResponse"
+                    }
+                },
+                "missing_one": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MissingOneResponse"
+                    }
+                }
+            }
+        },
+        "models.MissingOneResponse": {
+            "type": "object",
+            "properties": {
+                "cocktail": {
+                    "$ref": "#/definitions/models.SavedCocktailResponse"
+                },
+                "missing": {
+                    "$ref": "#/definitions/models.Ingredient"
+                }
+            }
+        },
+        "models.Mixer": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "finished": {
+                    "type": "boolean"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ingredient_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "open_date": {
+                    "type": "string"
+                },
+                "opened": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "remaining_ml": {
+                    "type": "number"
+                },
+                "shelf_life_days": {
+                    "description": "ShelfLifeDays overrides the default shelf life for the item's kind.",
+                    "type": "integer"
+                },
+                "size_ml": {
+                    "type": "number"
                 },
-                "base_url": {
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "models.BottleResponse": {
+        "models.MixerResponse": {
             "type": "object",
             "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "finished": {
+                    "type": "boolean"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "ingredient_id": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -932,66 +5147,318 @@ const docTemplate = `{
                 "opened": {
                     "type": "boolean"
                 },
+                "price": {
+                    "type": "number"
+                },
                 "purchase_date": {
                     "type": "string"
+                },
+                "remaining_ml": {
+                    "type": "number"
+                },
+                "shelf_life_days": {
+                    "type": "integer"
+                },
+                "size_ml": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "models.CreateBottleRequest": {
+        "models.Pour": {
+            "type": "object",
+            "properties": {
+                "amount_ml": {
+                    "type": "number"
+                },
+                "event_id": {
+                    "description": "EventID links the pour to the cocktail event that caused it, if any.",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "item_type": {
+                    "type": "string"
+                },
+                "poured_at": {
+                    "type": "string"
+                },
+                "remaining_ml": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.PourRequest": {
+            "type": "object",
+            "properties": {
+                "amount_ml": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ResolveIngredientResponse": {
+            "type": "object",
+            "properties": {
+                "ingredient": {
+                    "$ref": "#/definitions/models.CatalogIngredientResponse"
+                },
+                "lineage": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CatalogIngredientResponse"
+                    }
+                },
+                "query": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RestoreCount": {
+            "type": "object",
+            "properties": {
+                "restored": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RestoreReport": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "type": "string"
+                },
+                "schema_version": {
+                    "type": "integer"
+                },
+                "tables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.RestoreCount"
+                    }
+                }
+            }
+        },
+        "models.SaveRecommendationRequest": {
+            "type": "object",
+            "properties": {
+                "cocktail": {
+                    "$ref": "#/definitions/models.CocktailResponse"
+                },
+                "mode": {
+                    "description": "Mode is the recommendation mode used, which picks the default prompt\nwhen Prompt is empty.",
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "prompt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SavedCocktailResponse": {
             "type": "object",
             "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Ingredient"
+                    }
+                },
                 "name": {
                     "type": "string"
                 },
-                "open_date": {
+                "source_model": {
                     "type": "string"
                 },
-                "opened": {
+                "source_prompt": {
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Step"
+                    }
+                }
+            }
+        },
+        "models.SearchHit": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ShoppingList": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ShoppingListItem"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ShoppingListItem": {
+            "type": "object",
+            "properties": {
+                "checked": {
                     "type": "boolean"
                 },
-                "purchase_date": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "converted_id": {
+                    "type": "integer"
+                },
+                "converted_kind": {
+                    "description": "ConvertedKind and ConvertedID point at the inventory row the item was\nturned into, once it has been.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "needed_for": {
+                    "type": "string"
+                },
+                "quantity": {
                     "type": "string"
                 }
             }
         },
-        "models.CreateFreshRequest": {
+        "models.SkippedIngredient": {
             "type": "object",
             "properties": {
                 "name": {
                     "type": "string"
                 },
-                "prepared_date": {
+                "quantity": {
                     "type": "string"
                 },
-                "purchase_date": {
+                "reason": {
                     "type": "string"
                 }
             }
         },
-        "models.FreshResponse": {
+        "models.Step": {
             "type": "object",
             "properties": {
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "order": {
+                    "type": "integer"
+                },
+                "text": {
                     "type": "string"
+                }
+            }
+        },
+        "models.StepRequest": {
+            "type": "object",
+            "properties": {
+                "order": {
+                    "type": "integer"
                 },
-                "prepared_date": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.StepResponse": {
+            "type": "object",
+            "properties": {
+                "order": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SyrupResponse": {
+            "type": "object",
+            "properties": {
+                "finished": {
+                    "type": "boolean"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "made_date": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string"
                 },
+                "price": {
+                    "type": "number"
+                },
                 "purchase_date": {
                     "type": "string"
+                },
+                "ratio": {
+                    "type": "string"
+                },
+                "remaining_ml": {
+                    "type": "number"
+                },
+                "size_ml": {
+                    "type": "number"
                 }
             }
         },
-        "models.Mixer": {
+        "models.UpdateBittersRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "brand": {
                     "type": "string"
                 },
-                "id": {
+                "dashes_remaining": {
                     "type": "integer"
                 },
                 "name": {
@@ -1003,10 +5470,10 @@ const docTemplate = `{
                 "opened": {
                     "type": "boolean"
                 },
-                "purchase_date": {
-                    "type": "string"
+                "price": {
+                    "type": "number"
                 },
-                "updated_at": {
+                "purchase_date": {
                     "type": "string"
                 }
             }
@@ -1014,6 +5481,21 @@ const docTemplate = `{
         "models.UpdateBottleRequest": {
             "type": "object",
             "properties": {
+                "abv": {
+                    "type": "number"
+                },
+                "age_statement": {
+                    "type": "string"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -1023,8 +5505,46 @@ const docTemplate = `{
                 "opened": {
                     "type": "boolean"
                 },
+                "price": {
+                    "type": "number"
+                },
                 "purchase_date": {
                     "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "remaining_ml": {
+                    "type": "number"
+                },
+                "size_ml": {
+                    "type": "number"
+                },
+                "subcategory": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UpdateCocktailRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IngredientRequest"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StepRequest"
+                    }
                 }
             }
         },
@@ -1037,8 +5557,63 @@ const docTemplate = `{
                 "prepared_date": {
                     "type": "string"
                 },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "remaining_ml": {
+                    "type": "number"
+                },
+                "shelf_life_days": {
+                    "type": "integer"
+                },
+                "size_ml": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.UpdateGarnishRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.UpdateSyrupRequest": {
+            "type": "object",
+            "properties": {
+                "made_date": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
                 "purchase_date": {
                     "type": "string"
+                },
+                "ratio": {
+                    "type": "string"
+                },
+                "remaining_ml": {
+                    "type": "number"
+                },
+                "size_ml": {
+                    "type": "number"
                 }
             }
         }
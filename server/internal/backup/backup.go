@@ -0,0 +1,252 @@
+// Package backup writes timestamped copies of the live database into a
+// backup directory, on a schedule or on demand, and prunes old copies by a
+// retention policy.
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+const (
+	prefix = "app-"
+	suffix = ".db"
+	// stamp is the UTC time in a backup's name. It sorts in time order.
+	stamp = "20060102-150405.000"
+)
+
+// Database writes a consistent copy of itself to a new file.
+// repository.Repository implements it.
+type Database interface {
+	BackupTo(ctx context.Context, path string) error
+}
+
+// Config controls where backups go, how often they are taken and how many
+// are kept.
+type Config struct {
+	Dir string
+	// Interval is the time between scheduled backups; 0 turns them off.
+	Interval time.Duration
+	// Keep is how many of the newest backups to keep; 0 keeps them all.
+	Keep int
+	// MaxAge removes backups older than this; 0 keeps them however old.
+	MaxAge time.Duration
+}
+
+// DefaultConfig takes a backup a day and keeps a week of them.
+var DefaultConfig = Config{
+	Dir:      "./internal/database/backups",
+	Interval: 24 * time.Hour,
+	Keep:     7,
+}
+
+// ConfigFromEnv reads BACKUP_DIR, BACKUP_INTERVAL, BACKUP_KEEP and
+// BACKUP_MAX_AGE over DefaultConfig. Durations use Go syntax, such as "6h".
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig
+	if dir := os.Getenv("BACKUP_DIR"); dir != "" {
+		cfg.Dir = dir
+	}
+
+	for _, d := range []struct {
+		name  string
+		value *time.Duration
+	}{
+		{"BACKUP_INTERVAL", &cfg.Interval},
+		{"BACKUP_MAX_AGE", &cfg.MaxAge},
+	} {
+		raw := os.Getenv(d.name)
+		if raw == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(raw)
+		if err != nil || duration < 0 {
+			return cfg, fmt.Errorf("%s must be a duration such as 24h, got %q", d.name, raw)
+		}
+		*d.value = duration
+	}
+
+	if raw := os.Getenv("BACKUP_KEEP"); raw != "" {
+		keep, err := strconv.Atoi(raw)
+		if err != nil || keep < 0 {
+			return cfg, fmt.Errorf("BACKUP_KEEP must be a whole number, got %q", raw)
+		}
+		cfg.Keep = keep
+	}
+
+	return cfg, nil
+}
+
+// Manager takes and prunes backups. It is safe for concurrent use; backups
+// are taken one at a time.
+type Manager struct {
+	db  Database
+	cfg Config
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+func NewManager(db Database, cfg Config) *Manager {
+	return &Manager{db: db, cfg: cfg, now: time.Now}
+}
+
+// Config returns the configuration the manager runs with.
+func (m *Manager) Config() Config {
+	return m.cfg
+}
+
+// Run takes a backup now, then prunes old ones. A failed prune is logged but
+// doesn't fail the backup.
+func (m *Manager) Run(ctx context.Context) (*models.Backup, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := os.MkdirAll(m.cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %v", err)
+	}
+
+	created := m.now().UTC().Truncate(time.Millisecond)
+	name := prefix + created.Format(stamp) + suffix
+	path := filepath.Join(m.cfg.Dir, name)
+
+	// Write under a temporary name so a backup cut short never looks
+	// complete.
+	tmp := path + ".tmp"
+	os.Remove(tmp)
+	if err := m.db.BackupTo(ctx, tmp); err != nil {
+		os.Remove(tmp)
+		return nil, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return nil, fmt.Errorf("failed to save backup: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat backup: %v", err)
+	}
+
+	if err := m.prune(); err != nil {
+		log.Printf("ERROR: Pruning backups failed - error=%v", err)
+	}
+
+	return &models.Backup{Name: name, CreatedAt: created, SizeBytes: info.Size()}, nil
+}
+
+// List returns the backups in the backup directory, newest first. Other
+// files there are ignored.
+func (m *Manager) List() ([]models.Backup, error) {
+	entries, err := os.ReadDir(m.cfg.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []models.Backup{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup directory: %v", err)
+	}
+
+	backups := []models.Backup{}
+	for _, entry := range entries {
+		created, ok := parseName(entry.Name())
+		if !ok || entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat backup: %v", err)
+		}
+		backups = append(backups, models.Backup{Name: entry.Name(), CreatedAt: created, SizeBytes: info.Size()})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+	return backups, nil
+}
+
+func parseName(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+		return time.Time{}, false
+	}
+	created, err := time.Parse(stamp, strings.TrimSuffix(strings.TrimPrefix(name, prefix), suffix))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return created, true
+}
+
+// prune removes backups past the newest Keep or older than MaxAge. The
+// newest backup is always kept.
+func (m *Manager) prune() error {
+	backups, err := m.List()
+	if err != nil {
+		return err
+	}
+
+	now := m.now()
+	for i, b := range backups {
+		if i == 0 {
+			continue
+		}
+		tooMany := m.cfg.Keep > 0 && i >= m.cfg.Keep
+		tooOld := m.cfg.MaxAge > 0 && now.Sub(b.CreatedAt) > m.cfg.MaxAge
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.Remove(filepath.Join(m.cfg.Dir, b.Name)); err != nil {
+			return fmt.Errorf("failed to remove backup %s: %v", b.Name, err)
+		}
+	}
+	return nil
+}
+
+// Schedule takes a backup every Interval until ctx is done. The first one is
+// due an Interval after the newest backup in the directory, or straight away
+// if that is already past, so restarts don't put backups off. It does nothing
+// if scheduled backups are off.
+func (m *Manager) Schedule(ctx context.Context) {
+	if m.cfg.Interval <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(m.firstDelay())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			if b, err := m.Run(ctx); err != nil {
+				log.Printf("ERROR: Scheduled backup failed - error=%v", err)
+			} else {
+				log.Printf("Backed up database to %s", b.Name)
+			}
+			timer.Reset(m.cfg.Interval)
+		}
+	}
+}
+
+// firstDelay returns how long Schedule waits for its first backup.
+func (m *Manager) firstDelay() time.Duration {
+	backups, err := m.List()
+	if err != nil {
+		log.Printf("ERROR: Listing backups failed - error=%v", err)
+		return 0
+	}
+	if len(backups) == 0 {
+		return 0
+	}
+	return max(m.cfg.Interval-m.now().Sub(backups[0].CreatedAt), 0)
+}
@@ -0,0 +1,204 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeDB writes a small file in place of a database copy.
+type fakeDB struct {
+	err error
+}
+
+func (f fakeDB) BackupTo(ctx context.Context, path string) error {
+	if f.err != nil {
+		return f.err
+	}
+	return os.WriteFile(path, []byte("backup"), 0o644)
+}
+
+// newTestManager returns a manager whose clock starts at start and moves on
+// an hour every time it is read.
+func newTestManager(t *testing.T, cfg Config, start time.Time) *Manager {
+	t.Helper()
+
+	cfg.Dir = filepath.Join(t.TempDir(), "backups")
+	m := NewManager(fakeDB{}, cfg)
+	now := start
+	m.now = func() time.Time {
+		now = now.Add(time.Hour)
+		return now
+	}
+	return m
+}
+
+func TestRun_KeepsNewest(t *testing.T) {
+	m := newTestManager(t, Config{Keep: 3}, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := context.Background()
+
+	var names []string
+	for range 5 {
+		b, err := m.Run(ctx)
+		if err != nil {
+			t.Fatalf("Run() error = %v, want nil", err)
+		}
+		if b.SizeBytes != int64(len("backup")) {
+			t.Errorf("Run() size = %d, want %d", b.SizeBytes, len("backup"))
+		}
+		names = append(names, b.Name)
+	}
+
+	backups, err := m.List()
+	if err != nil {
+		t.Fatalf("List() error = %v, want nil", err)
+	}
+	if len(backups) != 3 {
+		t.Fatalf("List() returned %d backups, want 3", len(backups))
+	}
+	for i, b := range backups {
+		if want := names[len(names)-1-i]; b.Name != want {
+			t.Errorf("List()[%d] = %s, want %s", i, b.Name, want)
+		}
+	}
+}
+
+func TestRun_MaxAge(t *testing.T) {
+	m := newTestManager(t, Config{MaxAge: 90 * time.Minute}, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := context.Background()
+
+	for range 4 {
+		if _, err := m.Run(ctx); err != nil {
+			t.Fatalf("Run() error = %v, want nil", err)
+		}
+	}
+
+	// The clock moves on an hour with every read, so by the last prune every
+	// backup but the newest is over 90 minutes old.
+	backups, err := m.List()
+	if err != nil {
+		t.Fatalf("List() error = %v, want nil", err)
+	}
+	if len(backups) != 1 {
+		t.Errorf("List() returned %d backups, want only the newest", len(backups))
+	}
+}
+
+func TestSchedule_FirstDelay(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := newTestManager(t, Config{Interval: 24 * time.Hour}, start)
+	if got := m.firstDelay(); got != 0 {
+		t.Errorf("firstDelay() without backups = %v, want 0", got)
+	}
+
+	b, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v, want nil", err)
+	}
+
+	for _, tt := range []struct {
+		age  time.Duration
+		want time.Duration
+	}{
+		{time.Hour, 23 * time.Hour},
+		{24 * time.Hour, 0},
+		{30 * 24 * time.Hour, 0},
+	} {
+		m.now = func() time.Time { return b.CreatedAt.Add(tt.age) }
+		if got := m.firstDelay(); got != tt.want {
+			t.Errorf("firstDelay() with a backup %v old = %v, want %v", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestSchedule_BacksUpOverdue(t *testing.T) {
+	m := newTestManager(t, Config{Interval: time.Hour}, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.Schedule(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		backups, err := m.List()
+		if err != nil {
+			t.Fatalf("List() error = %v, want nil", err)
+		}
+		if len(backups) > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Schedule() took no backup, want one straight away with none on disk")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+}
+
+func TestRun_Failure(t *testing.T) {
+	dir := t.TempDir()
+	m := NewManager(fakeDB{err: errors.New("disk full")}, Config{Dir: dir})
+
+	if _, err := m.Run(context.Background()); err == nil {
+		t.Fatal("Run() error = nil, want the database's error")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("backup directory has %d files after a failed backup, want none", len(entries))
+	}
+}
+
+func TestList_IgnoresOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"app-20260101-000000.000.db", "app-20260101-010000.000.db.tmp", "notes.txt", "app-latest.db"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	backups, err := NewManager(fakeDB{}, Config{Dir: dir}).List()
+	if err != nil {
+		t.Fatalf("List() error = %v, want nil", err)
+	}
+	if len(backups) != 1 || !backups[0].CreatedAt.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("List() = %+v, want only the finished backup", backups)
+	}
+}
+
+func TestList_MissingDir(t *testing.T) {
+	backups, err := NewManager(fakeDB{}, Config{Dir: filepath.Join(t.TempDir(), "none")}).List()
+	if err != nil || len(backups) != 0 {
+		t.Errorf("List() = %v, %v, want no backups and no error", backups, err)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("BACKUP_DIR", "/backups")
+	t.Setenv("BACKUP_INTERVAL", "6h")
+	t.Setenv("BACKUP_KEEP", "10")
+	t.Setenv("BACKUP_MAX_AGE", "")
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v, want nil", err)
+	}
+	want := Config{Dir: "/backups", Interval: 6 * time.Hour, Keep: 10}
+	if cfg != want {
+		t.Errorf("ConfigFromEnv() = %+v, want %+v", cfg, want)
+	}
+
+	t.Setenv("BACKUP_INTERVAL", "daily")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Error("ConfigFromEnv() with a bad interval error = nil, want an error")
+	}
+}
@@ -0,0 +1,139 @@
+// Package batch scales saved recipes up for parties and pre-batching.
+package batch
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/nguyenjessev/liquor-locker/internal/measure"
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+// Units selects the unit system batch quantities are converted to.
+type Units string
+
+const (
+	// Original keeps each ingredient in the unit the recipe uses.
+	Original Units = ""
+	Metric   Units = "metric"
+	Imperial Units = "imperial"
+)
+
+var (
+	ErrInvalidServings = errors.New("servings must be greater than zero")
+	ErrInvalidDilution = errors.New("dilution must be between 0 and 1")
+	ErrInvalidUnits    = errors.New("units must be metric or imperial")
+)
+
+// ParseUnits reads a units query value. An empty value keeps the recipe's
+// own units.
+func ParseUnits(s string) (Units, error) {
+	switch units := Units(strings.ToLower(strings.TrimSpace(s))); units {
+	case Original, Metric, Imperial:
+		return units, nil
+	}
+	return Original, ErrInvalidUnits
+}
+
+// volumeUnit is the unit volumes are converted to, or measure.Count to leave
+// them alone.
+func (u Units) volumeUnit() measure.Unit {
+	switch u {
+	case Metric:
+		return measure.Milliliter
+	case Imperial:
+		return measure.Ounce
+	}
+	return measure.Count
+}
+
+// Options controls how a recipe is batched.
+type Options struct {
+	Servings int
+	// Dilution is the fraction of the batch's liquid volume to add as water,
+	// e.g. 0.2 for the 20% a stirred drink picks up from ice.
+	Dilution float64
+	Units    Units
+}
+
+// Scale multiplies every ingredient of cocktail by the number of servings,
+// converts volumes to the chosen unit system and adds dilution water.
+// Quantities that cannot be parsed, such as "to taste", are passed through
+// unscaled.
+func Scale(cocktail *models.Cocktail, opts Options) (*models.BatchResponse, error) {
+	if opts.Servings <= 0 {
+		return nil, ErrInvalidServings
+	}
+	if opts.Dilution < 0 || opts.Dilution >= 1 {
+		return nil, ErrInvalidDilution
+	}
+
+	response := &models.BatchResponse{
+		CocktailID:  cocktail.ID,
+		Name:        cocktail.Name,
+		Servings:    opts.Servings,
+		Dilution:    opts.Dilution,
+		Units:       string(opts.Units),
+		Ingredients: make([]models.BatchIngredient, 0, len(cocktail.Ingredients)),
+		Steps:       cocktail.Steps,
+	}
+	if response.Steps == nil {
+		response.Steps = []models.Step{}
+	}
+
+	var totalML float64
+	for _, ingredient := range cocktail.Ingredients {
+		batched := models.BatchIngredient{
+			Name:     ingredient.Name,
+			Quantity: ingredient.Quantity,
+			Original: ingredient.Quantity,
+		}
+
+		quantity, err := measure.Parse(ingredient.Quantity)
+		if err != nil || isIce(ingredient.Name) {
+			response.Ingredients = append(response.Ingredients, batched)
+			continue
+		}
+
+		quantity = measure.Scale(quantity, float64(opts.Servings))
+		if ml, ok := quantity.ML(); ok {
+			totalML += ml
+			if unit := opts.Units.volumeUnit(); unit != measure.Count {
+				quantity, _ = measure.Convert(quantity, unit)
+			}
+		}
+
+		batched.Quantity = quantity.String()
+		batched.Scaled = true
+		response.Ingredients = append(response.Ingredients, batched)
+	}
+
+	if waterML := totalML * opts.Dilution; waterML > 0 {
+		response.Water = formatVolume(waterML, opts.Units)
+		totalML += waterML
+	}
+	response.TotalVolume = formatVolume(totalML, opts.Units)
+
+	return response, nil
+}
+
+// isIce reports whether the ingredient is ice, which is added at
+// service rather than batched.
+func isIce(name string) bool {
+	for _, word := range strings.Fields(strings.ToLower(name)) {
+		if word == "ice" {
+			return true
+		}
+	}
+	return false
+}
+
+// formatVolume writes a total in the chosen units, using millilitres when the
+// recipe's own units are kept.
+func formatVolume(ml float64, units Units) string {
+	q := measure.Quantity{Amount: ml, Unit: measure.Milliliter}
+	if units == Imperial {
+		q, _ = measure.Convert(q, measure.Ounce)
+	}
+	return q.String()
+}
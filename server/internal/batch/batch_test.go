@@ -0,0 +1,120 @@
+package batch
+
+import (
+	"testing"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+var manhattan = &models.Cocktail{
+	ID:   1,
+	Name: "Manhattan",
+	Ingredients: []models.Ingredient{
+		{Name: "Rye Whiskey", Quantity: "2 oz"},
+		{Name: "Sweet Vermouth", Quantity: "1 oz"},
+		{Name: "Angostura Bitters", Quantity: "2 dashes"},
+		{Name: "Cherry", Quantity: "1"},
+		{Name: "Ice", Quantity: "1 cup"},
+		{Name: "Orange Twist", Quantity: "to taste"},
+	},
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  Options
+		want  []string
+		water string
+		total string
+	}{
+		{
+			name:  "original units",
+			opts:  Options{Servings: 10},
+			want:  []string{"20 oz", "10 oz", "20 dashes", "10", "1 cup", "to taste"},
+			total: "905.6 ml",
+		},
+		{
+			name:  "metric with dilution",
+			opts:  Options{Servings: 10, Dilution: 0.2, Units: Metric},
+			want:  []string{"591.5 ml", "295.7 ml", "18.4 ml", "10", "1 cup", "to taste"},
+			water: "181.1 ml",
+			total: "1086.7 ml",
+		},
+		{
+			name:  "imperial",
+			opts:  Options{Servings: 4, Units: Imperial},
+			want:  []string{"8 oz", "4 oz", "1/4 oz", "4", "1 cup", "to taste"},
+			total: "12 1/4 oz",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := Scale(manhattan, tt.opts)
+		if err != nil {
+			t.Fatalf("%s: Scale() error = %v, want nil", tt.name, err)
+		}
+
+		for i, ingredient := range got.Ingredients {
+			if ingredient.Quantity != tt.want[i] {
+				t.Errorf("%s: %s quantity = %q, want %q", tt.name, ingredient.Name, ingredient.Quantity, tt.want[i])
+			}
+		}
+		if got.Water != tt.water {
+			t.Errorf("%s: water = %q, want %q", tt.name, got.Water, tt.water)
+		}
+		if got.TotalVolume != tt.total {
+			t.Errorf("%s: total = %q, want %q", tt.name, got.TotalVolume, tt.total)
+		}
+	}
+}
+
+func TestScale_Unscaled(t *testing.T) {
+	got, err := Scale(manhattan, Options{Servings: 3})
+	if err != nil {
+		t.Fatalf("Scale() error = %v, want nil", err)
+	}
+
+	for _, ingredient := range got.Ingredients {
+		wantScaled := ingredient.Name != "Ice" && ingredient.Name != "Orange Twist"
+		if ingredient.Scaled != wantScaled {
+			t.Errorf("%s scaled = %v, want %v", ingredient.Name, ingredient.Scaled, wantScaled)
+		}
+	}
+}
+
+func TestScale_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		opts Options
+		want error
+	}{
+		{Options{Servings: 0}, ErrInvalidServings},
+		{Options{Servings: 4, Dilution: -0.1}, ErrInvalidDilution},
+		{Options{Servings: 4, Dilution: 1}, ErrInvalidDilution},
+	}
+
+	for _, tt := range tests {
+		if _, err := Scale(manhattan, tt.opts); err != tt.want {
+			t.Errorf("Scale(%+v) error = %v, want %v", tt.opts, err, tt.want)
+		}
+	}
+}
+
+func TestParseUnits(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Units
+		err   error
+	}{
+		{"", Original, nil},
+		{"metric", Metric, nil},
+		{"Imperial", Imperial, nil},
+		{"cups", Original, ErrInvalidUnits},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseUnits(tt.input)
+		if got != tt.want || err != tt.err {
+			t.Errorf("ParseUnits(%q) = %q, %v, want %q, %v", tt.input, got, err, tt.want, tt.err)
+		}
+	}
+}
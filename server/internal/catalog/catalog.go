@@ -0,0 +1,152 @@
+// Package catalog resolves free-text ingredient names to canonical catalog
+// entries and answers hierarchy questions such as "is bourbon a whiskey".
+package catalog
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/nguyenjessev/liquor-locker/internal/matcher"
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+// Catalog is an in-memory index over the canonical ingredient catalog.
+type Catalog struct {
+	entries map[int64]*models.CatalogIngredient
+	// names maps a normalized name or alias to its ingredient ID.
+	names map[string]int64
+}
+
+// New indexes the given catalog entries by name and alias.
+func New(entries []*models.CatalogIngredient) *Catalog {
+	c := &Catalog{
+		entries: make(map[int64]*models.CatalogIngredient, len(entries)),
+		names:   make(map[string]int64),
+	}
+
+	for _, entry := range entries {
+		c.entries[entry.ID] = entry
+	}
+	// Canonical names win over aliases that happen to normalize the same.
+	for _, entry := range entries {
+		for _, alias := range entry.Aliases {
+			if key := matcher.Normalize(alias); key != "" {
+				c.names[key] = entry.ID
+			}
+		}
+	}
+	for _, entry := range entries {
+		if key := matcher.Normalize(entry.Name); key != "" {
+			c.names[key] = entry.ID
+		}
+	}
+
+	return c
+}
+
+// Get returns the catalog entry with the given ID.
+func (c *Catalog) Get(id int64) (*models.CatalogIngredient, bool) {
+	entry, ok := c.entries[id]
+	return entry, ok
+}
+
+// Resolve maps a free-text name to a catalog entry. An exact match on a name
+// or alias is preferred; otherwise the longest name or alias that appears as
+// a run of whole words inside the text wins, so "Buffalo Trace Bourbon"
+// resolves to Bourbon and "Hendrick's Gin" resolves to Gin.
+func (c *Catalog) Resolve(name string) (*models.CatalogIngredient, bool) {
+	id, ok := c.ResolveID(name)
+	if !ok {
+		return nil, false
+	}
+	return c.entries[id], true
+}
+
+// ResolveID is like Resolve but returns only the entry's ID.
+func (c *Catalog) ResolveID(name string) (int64, bool) {
+	normalized := matcher.Normalize(name)
+	if normalized == "" {
+		return 0, false
+	}
+
+	if id, ok := c.names[normalized]; ok {
+		return id, true
+	}
+
+	words := strings.Fields(normalized)
+	// Try progressively shorter runs of words so the most specific phrase
+	// wins.
+	for size := len(words) - 1; size > 0; size-- {
+		for start := 0; start+size <= len(words); start++ {
+			phrase := strings.Join(words[start:start+size], " ")
+			if id, ok := c.names[phrase]; ok && c.fits(id, slices.Concat(words[:start], words[start+size:])) {
+				return id, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// fits reports whether the words of a name left over from the phrase that
+// resolved it to id only narrow the entry down rather than making it another
+// product: "Aromatic Bitters" fits Angostura Bitters, but "Sloe" doesn't fit
+// Gin.
+func (c *Catalog) fits(id int64, rest []string) bool {
+	for _, word := range rest {
+		if !matcher.IsProductWord(word) {
+			continue
+		}
+		found := false
+		for _, entry := range c.Lineage(id) {
+			if slices.Contains(strings.Fields(matcher.Normalize(entry.Name)), word) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// ResolveExactID resolves a name only if it is a catalog name or alias,
+// ignoring stopwords such as "fresh". Unlike ResolveID it never falls back to
+// part of the name, so "Orange Juice" doesn't resolve to Juice.
+func (c *Catalog) ResolveExactID(name string) (int64, bool) {
+	for _, key := range []string{matcher.Normalize(name), matcher.Key(name)} {
+		if id, ok := c.names[key]; ok && key != "" {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// Lineage returns the entry with the given ID followed by its ancestors, from
+// most to least specific.
+func (c *Catalog) Lineage(id int64) []*models.CatalogIngredient {
+	var lineage []*models.CatalogIngredient
+	seen := make(map[int64]bool)
+	for entry, ok := c.entries[id]; ok && !seen[entry.ID]; {
+		seen[entry.ID] = true
+		lineage = append(lineage, entry)
+		if entry.ParentID == nil {
+			break
+		}
+		entry, ok = c.entries[*entry.ParentID]
+	}
+	return lineage
+}
+
+// Satisfies reports whether having the ingredient have is enough for a recipe
+// that asks for want, i.e. want is have itself or one of its ancestors.
+// Bourbon satisfies Whiskey, but Whiskey does not satisfy Bourbon.
+func (c *Catalog) Satisfies(have, want int64) bool {
+	for _, entry := range c.Lineage(have) {
+		if entry.ID == want {
+			return true
+		}
+	}
+	return false
+}
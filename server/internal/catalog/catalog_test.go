@@ -0,0 +1,138 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/nguyenjessev/liquor-locker/internal/matcher"
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+func ptr(id int64) *int64 {
+	return &id
+}
+
+func testCatalog() *Catalog {
+	return New([]*models.CatalogIngredient{
+		{ID: 1, Name: "Spirit"},
+		{ID: 2, Name: "Whiskey", ParentID: ptr(1), Aliases: []string{"whisky"}},
+		{ID: 3, Name: "Bourbon", ParentID: ptr(2), Aliases: []string{"Buffalo Trace", "bourbon whiskey"}},
+		{ID: 4, Name: "Gin", ParentID: ptr(1)},
+		{ID: 5, Name: "Sweet Vermouth", Aliases: []string{"Carpano Antica"}},
+		{ID: 6, Name: "Bitters"},
+		{ID: 7, Name: "Angostura Bitters", ParentID: ptr(6), Aliases: []string{"angostura"}},
+	})
+}
+
+func TestResolve(t *testing.T) {
+	c := testCatalog()
+
+	tests := []struct {
+		name   string
+		wantID int64
+		wantOK bool
+	}{
+		{"Bourbon", 3, true},
+		{"buffalo trace", 3, true},
+		{"Buffalo Trace Bourbon", 3, true},
+		{"Bourbon Whiskey", 3, true},
+		{"Scotch Whisky", 2, true},
+		{"Hendrick's Gin", 4, true},
+		{"Carpano Antica Formula", 5, true},
+		{"Angostura Aromatic Bitters", 7, true},
+		{"Sloe Gin", 0, false},
+		{"Gin Liqueur", 0, false},
+		{"Campari", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		entry, ok := c.Resolve(tt.name)
+		if ok != tt.wantOK {
+			t.Errorf("Resolve(%q) ok = %v, want %v", tt.name, ok, tt.wantOK)
+			continue
+		}
+		if ok && entry.ID != tt.wantID {
+			t.Errorf("Resolve(%q) = %s (%d), want ID %d", tt.name, entry.Name, entry.ID, tt.wantID)
+		}
+	}
+}
+
+func TestLineage(t *testing.T) {
+	c := testCatalog()
+
+	lineage := c.Lineage(3)
+	want := []string{"Bourbon", "Whiskey", "Spirit"}
+	if len(lineage) != len(want) {
+		t.Fatalf("Lineage(3) = %d entries, want %d", len(lineage), len(want))
+	}
+	for i, entry := range lineage {
+		if entry.Name != want[i] {
+			t.Errorf("Lineage(3)[%d] = %s, want %s", i, entry.Name, want[i])
+		}
+	}
+
+	if len(c.Lineage(99)) != 0 {
+		t.Error("Lineage(99) returned entries for an unknown ID")
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	c := testCatalog()
+
+	if !c.Satisfies(3, 2) {
+		t.Error("Satisfies(bourbon, whiskey) = false, want true")
+	}
+	if !c.Satisfies(3, 3) {
+		t.Error("Satisfies(bourbon, bourbon) = false, want true")
+	}
+	if c.Satisfies(2, 3) {
+		t.Error("Satisfies(whiskey, bourbon) = true, want false")
+	}
+	if c.Satisfies(4, 2) {
+		t.Error("Satisfies(gin, whiskey) = true, want false")
+	}
+}
+
+func TestResolveExactID(t *testing.T) {
+	c := New([]*models.CatalogIngredient{
+		{ID: 1, Name: "Liqueur"},
+		{ID: 2, Name: "Campari", ParentID: ptr(1)},
+		{ID: 3, Name: "Juice"},
+		{ID: 4, Name: "Lime Juice", ParentID: ptr(3)},
+	})
+
+	tests := []struct {
+		name   string
+		wantID int64
+		wantOK bool
+	}{
+		{"Lime Juice", 4, true},
+		{"Fresh Lime Juice", 4, true},
+		{"campari", 2, true},
+		{"Orange Juice", 0, false},
+		{"Grapefruit Juice", 0, false},
+		{"Maraschino Liqueur", 0, false},
+	}
+	for _, tt := range tests {
+		id, ok := c.ResolveExactID(tt.name)
+		if ok != tt.wantOK || id != tt.wantID {
+			t.Errorf("ResolveExactID(%q) = %d, %v; want %d, %v", tt.name, id, ok, tt.wantID, tt.wantOK)
+		}
+	}
+
+	// Unseeded specific ingredients aren't satisfied by a sibling under the
+	// same generic parent.
+	m := matcher.New([]matcher.Item{{Name: "Lime Juice"}, {Name: "Campari"}}).WithResolver(c)
+	for _, tt := range []struct{ want, have string }{
+		{"Orange Juice", "Lime Juice"},
+		{"Grapefruit Juice", "Lime Juice"},
+		{"Maraschino Liqueur", "Campari"},
+	} {
+		if item, ok := m.Find(tt.want); ok {
+			t.Errorf("Find(%q) = %s, want no match from %s", tt.want, item.Name, tt.have)
+		}
+	}
+	if item, ok := m.Find("Liqueur"); !ok || item.Name != "Campari" {
+		t.Errorf("Find(\"Liqueur\") = %v, %v; want Campari", item, ok)
+	}
+}
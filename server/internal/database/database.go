@@ -0,0 +1,10 @@
+// Package database holds the SQL migrations, built into the binary so it can
+// run from any working directory.
+package database
+
+import "embed"
+
+// Migrations holds the golang-migrate migrations under migrations/.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
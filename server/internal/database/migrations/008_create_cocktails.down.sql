@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS cocktail_steps;
+DROP TABLE IF EXISTS cocktail_ingredients;
+DROP TABLE IF EXISTS cocktails;
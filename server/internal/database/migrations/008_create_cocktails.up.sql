@@ -0,0 +1,26 @@
+CREATE TABLE cocktails (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE cocktail_ingredients (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	cocktail_id INTEGER NOT NULL REFERENCES cocktails(id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	quantity TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_cocktail_ingredients_cocktail_id ON cocktail_ingredients(cocktail_id);
+
+CREATE TABLE cocktail_steps (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	cocktail_id INTEGER NOT NULL REFERENCES cocktails(id) ON DELETE CASCADE,
+	step_order INTEGER NOT NULL,
+	text TEXT NOT NULL
+);
+
+CREATE INDEX idx_cocktail_steps_cocktail_id ON cocktail_steps(cocktail_id);
@@ -0,0 +1,2 @@
+ALTER TABLE cocktails DROP COLUMN source_model;
+ALTER TABLE cocktails DROP COLUMN source_prompt;
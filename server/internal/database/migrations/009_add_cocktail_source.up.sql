@@ -0,0 +1,2 @@
+ALTER TABLE cocktails ADD COLUMN source_model TEXT NULL;
+ALTER TABLE cocktails ADD COLUMN source_prompt TEXT NULL;
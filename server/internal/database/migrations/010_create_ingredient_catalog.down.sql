@@ -0,0 +1,6 @@
+ALTER TABLE bottles DROP COLUMN ingredient_id;
+ALTER TABLE mixers DROP COLUMN ingredient_id;
+ALTER TABLE fresh DROP COLUMN ingredient_id;
+
+DROP TABLE IF EXISTS ingredient_aliases;
+DROP TABLE IF EXISTS catalog_ingredients;
@@ -0,0 +1,73 @@
+CREATE TABLE catalog_ingredients (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+	parent_id INTEGER NULL REFERENCES catalog_ingredients(id),
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE ingredient_aliases (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	ingredient_id INTEGER NOT NULL REFERENCES catalog_ingredients(id) ON DELETE CASCADE,
+	alias TEXT NOT NULL UNIQUE
+);
+
+CREATE INDEX idx_ingredient_aliases_ingredient_id ON ingredient_aliases(ingredient_id);
+
+ALTER TABLE bottles ADD COLUMN ingredient_id INTEGER NULL;
+ALTER TABLE mixers ADD COLUMN ingredient_id INTEGER NULL;
+ALTER TABLE fresh ADD COLUMN ingredient_id INTEGER NULL;
+
+-- Seed a starter hierarchy. Aliases are stored normalized (lowercase words).
+INSERT INTO catalog_ingredients (name) VALUES
+	('Spirit'), ('Liqueur'), ('Fortified Wine'), ('Bitters'), ('Juice'), ('Syrup'), ('Soda');
+
+INSERT INTO catalog_ingredients (name, parent_id) VALUES
+	('Whiskey', (SELECT id FROM catalog_ingredients WHERE name = 'Spirit')),
+	('Gin', (SELECT id FROM catalog_ingredients WHERE name = 'Spirit')),
+	('Rum', (SELECT id FROM catalog_ingredients WHERE name = 'Spirit')),
+	('Tequila', (SELECT id FROM catalog_ingredients WHERE name = 'Spirit')),
+	('Mezcal', (SELECT id FROM catalog_ingredients WHERE name = 'Spirit')),
+	('Vodka', (SELECT id FROM catalog_ingredients WHERE name = 'Spirit')),
+	('Brandy', (SELECT id FROM catalog_ingredients WHERE name = 'Spirit')),
+	('Campari', (SELECT id FROM catalog_ingredients WHERE name = 'Liqueur')),
+	('Aperol', (SELECT id FROM catalog_ingredients WHERE name = 'Liqueur')),
+	('Triple Sec', (SELECT id FROM catalog_ingredients WHERE name = 'Liqueur')),
+	('Vermouth', (SELECT id FROM catalog_ingredients WHERE name = 'Fortified Wine')),
+	('Angostura Bitters', (SELECT id FROM catalog_ingredients WHERE name = 'Bitters')),
+	('Orange Bitters', (SELECT id FROM catalog_ingredients WHERE name = 'Bitters')),
+	('Lime Juice', (SELECT id FROM catalog_ingredients WHERE name = 'Juice')),
+	('Lemon Juice', (SELECT id FROM catalog_ingredients WHERE name = 'Juice')),
+	('Simple Syrup', (SELECT id FROM catalog_ingredients WHERE name = 'Syrup')),
+	('Tonic Water', (SELECT id FROM catalog_ingredients WHERE name = 'Soda')),
+	('Club Soda', (SELECT id FROM catalog_ingredients WHERE name = 'Soda'));
+
+INSERT INTO catalog_ingredients (name, parent_id) VALUES
+	('Bourbon', (SELECT id FROM catalog_ingredients WHERE name = 'Whiskey')),
+	('Rye Whiskey', (SELECT id FROM catalog_ingredients WHERE name = 'Whiskey')),
+	('Scotch', (SELECT id FROM catalog_ingredients WHERE name = 'Whiskey')),
+	('White Rum', (SELECT id FROM catalog_ingredients WHERE name = 'Rum')),
+	('Dark Rum', (SELECT id FROM catalog_ingredients WHERE name = 'Rum')),
+	('Cognac', (SELECT id FROM catalog_ingredients WHERE name = 'Brandy')),
+	('Sweet Vermouth', (SELECT id FROM catalog_ingredients WHERE name = 'Vermouth')),
+	('Dry Vermouth', (SELECT id FROM catalog_ingredients WHERE name = 'Vermouth'));
+
+INSERT INTO ingredient_aliases (ingredient_id, alias) VALUES
+	((SELECT id FROM catalog_ingredients WHERE name = 'Whiskey'), 'whisky'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Bourbon'), 'bourbon whiskey'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Bourbon'), 'buffalo trace'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Bourbon'), 'makers mark'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Bourbon'), 'wild turkey'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Rye Whiskey'), 'rye'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Scotch'), 'scotch whisky'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'White Rum'), 'light rum'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'White Rum'), 'silver rum'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Triple Sec'), 'cointreau'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Triple Sec'), 'orange liqueur'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Sweet Vermouth'), 'rosso vermouth'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Sweet Vermouth'), 'carpano antica'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Angostura Bitters'), 'angostura'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Club Soda'), 'soda water'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Club Soda'), 'seltzer'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Club Soda'), 'seltzer water'),
+	((SELECT id FROM catalog_ingredients WHERE name = 'Tonic Water'), 'tonic');
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS pours;
+
+ALTER TABLE bottles DROP COLUMN size_ml;
+ALTER TABLE bottles DROP COLUMN remaining_ml;
+ALTER TABLE bottles DROP COLUMN finished;
+ALTER TABLE bottles DROP COLUMN finished_at;
+
+ALTER TABLE mixers DROP COLUMN size_ml;
+ALTER TABLE mixers DROP COLUMN remaining_ml;
+ALTER TABLE mixers DROP COLUMN finished;
+ALTER TABLE mixers DROP COLUMN finished_at;
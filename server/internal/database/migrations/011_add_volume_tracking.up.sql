@@ -0,0 +1,20 @@
+ALTER TABLE bottles ADD COLUMN size_ml REAL NULL;
+ALTER TABLE bottles ADD COLUMN remaining_ml REAL NULL;
+ALTER TABLE bottles ADD COLUMN finished BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE bottles ADD COLUMN finished_at DATETIME NULL;
+
+ALTER TABLE mixers ADD COLUMN size_ml REAL NULL;
+ALTER TABLE mixers ADD COLUMN remaining_ml REAL NULL;
+ALTER TABLE mixers ADD COLUMN finished BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE mixers ADD COLUMN finished_at DATETIME NULL;
+
+CREATE TABLE pours (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	item_type TEXT NOT NULL,
+	item_id INTEGER NOT NULL,
+	amount_ml REAL NOT NULL,
+	remaining_ml REAL NOT NULL,
+	poured_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pours_item ON pours(item_type, item_id);
@@ -0,0 +1,8 @@
+ALTER TABLE fresh DROP COLUMN size_ml;
+ALTER TABLE fresh DROP COLUMN remaining_ml;
+ALTER TABLE fresh DROP COLUMN finished;
+ALTER TABLE fresh DROP COLUMN finished_at;
+
+ALTER TABLE pours DROP COLUMN event_id;
+
+DROP TABLE IF EXISTS cocktail_events;
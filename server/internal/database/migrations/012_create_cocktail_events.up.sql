@@ -0,0 +1,16 @@
+CREATE TABLE cocktail_events (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	cocktail_id INTEGER NOT NULL,
+	cocktail_name TEXT NOT NULL,
+	servings INTEGER NOT NULL DEFAULT 1,
+	made_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_cocktail_events_cocktail_id ON cocktail_events(cocktail_id);
+
+ALTER TABLE pours ADD COLUMN event_id INTEGER NULL;
+
+ALTER TABLE fresh ADD COLUMN size_ml REAL NULL;
+ALTER TABLE fresh ADD COLUMN remaining_ml REAL NULL;
+ALTER TABLE fresh ADD COLUMN finished BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE fresh ADD COLUMN finished_at DATETIME NULL;
@@ -0,0 +1,21 @@
+CREATE TABLE shopping_lists (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE shopping_list_items (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	list_id INTEGER NOT NULL REFERENCES shopping_lists(id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	quantity TEXT NOT NULL DEFAULT '',
+	needed_for TEXT NOT NULL DEFAULT '',
+	checked BOOLEAN NOT NULL DEFAULT FALSE,
+	checked_at DATETIME NULL,
+	converted_kind TEXT NULL,
+	converted_id INTEGER NULL
+);
+
+CREATE INDEX idx_shopping_list_items_list_id ON shopping_list_items(list_id);
@@ -0,0 +1,2 @@
+ALTER TABLE fresh DROP COLUMN shelf_life_days;
+ALTER TABLE mixers DROP COLUMN shelf_life_days;
@@ -0,0 +1,2 @@
+ALTER TABLE fresh ADD COLUMN shelf_life_days INTEGER NULL;
+ALTER TABLE mixers ADD COLUMN shelf_life_days INTEGER NULL;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_bottles_category;
+
+ALTER TABLE bottles DROP COLUMN category;
+ALTER TABLE bottles DROP COLUMN subcategory;
+ALTER TABLE bottles DROP COLUMN brand;
+ALTER TABLE bottles DROP COLUMN abv;
+ALTER TABLE bottles DROP COLUMN country;
+ALTER TABLE bottles DROP COLUMN region;
+ALTER TABLE bottles DROP COLUMN age_statement;
@@ -0,0 +1,9 @@
+ALTER TABLE bottles ADD COLUMN category TEXT NULL;
+ALTER TABLE bottles ADD COLUMN subcategory TEXT NULL;
+ALTER TABLE bottles ADD COLUMN brand TEXT NULL;
+ALTER TABLE bottles ADD COLUMN abv REAL NULL;
+ALTER TABLE bottles ADD COLUMN country TEXT NULL;
+ALTER TABLE bottles ADD COLUMN region TEXT NULL;
+ALTER TABLE bottles ADD COLUMN age_statement TEXT NULL;
+
+CREATE INDEX idx_bottles_category ON bottles(category COLLATE NOCASE);
@@ -0,0 +1,16 @@
+DROP TRIGGER IF EXISTS search_cocktail_ingredients_delete;
+DROP TRIGGER IF EXISTS search_cocktail_ingredients_insert;
+DROP TRIGGER IF EXISTS search_cocktails_delete;
+DROP TRIGGER IF EXISTS search_cocktails_update;
+DROP TRIGGER IF EXISTS search_cocktails_insert;
+DROP TRIGGER IF EXISTS search_fresh_delete;
+DROP TRIGGER IF EXISTS search_fresh_update;
+DROP TRIGGER IF EXISTS search_fresh_insert;
+DROP TRIGGER IF EXISTS search_mixers_delete;
+DROP TRIGGER IF EXISTS search_mixers_update;
+DROP TRIGGER IF EXISTS search_mixers_insert;
+DROP TRIGGER IF EXISTS search_bottles_delete;
+DROP TRIGGER IF EXISTS search_bottles_update;
+DROP TRIGGER IF EXISTS search_bottles_insert;
+
+DROP TABLE IF EXISTS search_index;
@@ -0,0 +1,96 @@
+-- One full-text index over the whole bar. The bundled SQLite driver only
+-- builds FTS5 with the sqlite_fts5 build tag, so this uses FTS4, which is
+-- always available and supports the same MATCH queries.
+CREATE VIRTUAL TABLE search_index USING fts4(
+	kind,
+	item_id,
+	name,
+	details,
+	notindexed=kind,
+	notindexed=item_id,
+	tokenize=unicode61 "remove_diacritics=2"
+);
+
+INSERT INTO search_index (kind, item_id, name, details)
+SELECT 'bottle', id, name, concat_ws(' ', category, subcategory, brand, country, region) FROM bottles;
+
+INSERT INTO search_index (kind, item_id, name, details)
+SELECT 'mixer', id, name, '' FROM mixers;
+
+INSERT INTO search_index (kind, item_id, name, details)
+SELECT 'fresh', id, name, '' FROM fresh;
+
+INSERT INTO search_index (kind, item_id, name, details)
+SELECT 'cocktail', c.id, c.name, concat_ws(' ', c.description, (SELECT group_concat(name, ' ') FROM cocktail_ingredients WHERE cocktail_id = c.id))
+FROM cocktails c;
+
+CREATE TRIGGER search_bottles_insert AFTER INSERT ON bottles BEGIN
+	INSERT INTO search_index (kind, item_id, name, details)
+	VALUES ('bottle', NEW.id, NEW.name, concat_ws(' ', NEW.category, NEW.subcategory, NEW.brand, NEW.country, NEW.region));
+END;
+
+CREATE TRIGGER search_bottles_update AFTER UPDATE OF name, category, subcategory, brand, country, region ON bottles BEGIN
+	DELETE FROM search_index WHERE kind = 'bottle' AND item_id = OLD.id;
+	INSERT INTO search_index (kind, item_id, name, details)
+	VALUES ('bottle', NEW.id, NEW.name, concat_ws(' ', NEW.category, NEW.subcategory, NEW.brand, NEW.country, NEW.region));
+END;
+
+CREATE TRIGGER search_bottles_delete AFTER DELETE ON bottles BEGIN
+	DELETE FROM search_index WHERE kind = 'bottle' AND item_id = OLD.id;
+END;
+
+CREATE TRIGGER search_mixers_insert AFTER INSERT ON mixers BEGIN
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('mixer', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_mixers_update AFTER UPDATE OF name ON mixers BEGIN
+	DELETE FROM search_index WHERE kind = 'mixer' AND item_id = OLD.id;
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('mixer', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_mixers_delete AFTER DELETE ON mixers BEGIN
+	DELETE FROM search_index WHERE kind = 'mixer' AND item_id = OLD.id;
+END;
+
+CREATE TRIGGER search_fresh_insert AFTER INSERT ON fresh BEGIN
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('fresh', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_fresh_update AFTER UPDATE OF name ON fresh BEGIN
+	DELETE FROM search_index WHERE kind = 'fresh' AND item_id = OLD.id;
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('fresh', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_fresh_delete AFTER DELETE ON fresh BEGIN
+	DELETE FROM search_index WHERE kind = 'fresh' AND item_id = OLD.id;
+END;
+
+-- A recipe's row holds its description and ingredient names, so it is
+-- rebuilt whenever the recipe or its ingredients change.
+CREATE TRIGGER search_cocktails_insert AFTER INSERT ON cocktails BEGIN
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('cocktail', NEW.id, NEW.name, NEW.description);
+END;
+
+CREATE TRIGGER search_cocktails_update AFTER UPDATE OF name, description ON cocktails BEGIN
+	DELETE FROM search_index WHERE kind = 'cocktail' AND item_id = OLD.id;
+	INSERT INTO search_index (kind, item_id, name, details)
+	SELECT 'cocktail', NEW.id, NEW.name, concat_ws(' ', NEW.description, (SELECT group_concat(name, ' ') FROM cocktail_ingredients WHERE cocktail_id = NEW.id));
+END;
+
+CREATE TRIGGER search_cocktails_delete AFTER DELETE ON cocktails BEGIN
+	DELETE FROM search_index WHERE kind = 'cocktail' AND item_id = OLD.id;
+END;
+
+CREATE TRIGGER search_cocktail_ingredients_insert AFTER INSERT ON cocktail_ingredients BEGIN
+	DELETE FROM search_index WHERE kind = 'cocktail' AND item_id = NEW.cocktail_id;
+	INSERT INTO search_index (kind, item_id, name, details)
+	SELECT 'cocktail', c.id, c.name, concat_ws(' ', c.description, (SELECT group_concat(name, ' ') FROM cocktail_ingredients WHERE cocktail_id = c.id))
+	FROM cocktails c WHERE c.id = NEW.cocktail_id;
+END;
+
+CREATE TRIGGER search_cocktail_ingredients_delete AFTER DELETE ON cocktail_ingredients BEGIN
+	DELETE FROM search_index WHERE kind = 'cocktail' AND item_id = OLD.cocktail_id;
+	INSERT INTO search_index (kind, item_id, name, details)
+	SELECT 'cocktail', c.id, c.name, concat_ws(' ', c.description, (SELECT group_concat(name, ' ') FROM cocktail_ingredients WHERE cocktail_id = c.id))
+	FROM cocktails c WHERE c.id = OLD.cocktail_id;
+END;
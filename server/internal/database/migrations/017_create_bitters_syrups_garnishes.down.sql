@@ -0,0 +1,15 @@
+DROP TRIGGER IF EXISTS search_garnishes_delete;
+DROP TRIGGER IF EXISTS search_garnishes_update;
+DROP TRIGGER IF EXISTS search_garnishes_insert;
+DROP TRIGGER IF EXISTS search_syrups_delete;
+DROP TRIGGER IF EXISTS search_syrups_update;
+DROP TRIGGER IF EXISTS search_syrups_insert;
+DROP TRIGGER IF EXISTS search_bitters_delete;
+DROP TRIGGER IF EXISTS search_bitters_update;
+DROP TRIGGER IF EXISTS search_bitters_insert;
+
+DELETE FROM search_index WHERE kind IN ('bitters', 'syrup', 'garnish');
+
+DROP TABLE IF EXISTS garnishes;
+DROP TABLE IF EXISTS syrups;
+DROP TABLE IF EXISTS bitters;
@@ -0,0 +1,78 @@
+-- Bitters are measured in dashes and garnishes by count, so only syrups
+-- track volume and can be poured from.
+CREATE TABLE bitters (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	brand TEXT NULL,
+	opened BOOLEAN NOT NULL DEFAULT FALSE,
+	open_date DATETIME NULL,
+	purchase_date DATETIME NULL,
+	price REAL NULL,
+	dashes_remaining INTEGER NULL,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE syrups (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	ratio TEXT NULL,
+	made_date DATETIME NULL,
+	purchase_date DATETIME NULL,
+	price REAL NULL,
+	size_ml REAL NULL,
+	remaining_ml REAL NULL,
+	finished BOOLEAN NOT NULL DEFAULT FALSE,
+	finished_at DATETIME NULL,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE garnishes (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	quantity INTEGER NULL,
+	purchase_date DATETIME NULL,
+	price REAL NULL,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER search_bitters_insert AFTER INSERT ON bitters BEGIN
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('bitters', NEW.id, NEW.name, coalesce(NEW.brand, ''));
+END;
+
+CREATE TRIGGER search_bitters_update AFTER UPDATE OF name, brand ON bitters BEGIN
+	DELETE FROM search_index WHERE kind = 'bitters' AND item_id = OLD.id;
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('bitters', NEW.id, NEW.name, coalesce(NEW.brand, ''));
+END;
+
+CREATE TRIGGER search_bitters_delete AFTER DELETE ON bitters BEGIN
+	DELETE FROM search_index WHERE kind = 'bitters' AND item_id = OLD.id;
+END;
+
+CREATE TRIGGER search_syrups_insert AFTER INSERT ON syrups BEGIN
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('syrup', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_syrups_update AFTER UPDATE OF name ON syrups BEGIN
+	DELETE FROM search_index WHERE kind = 'syrup' AND item_id = OLD.id;
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('syrup', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_syrups_delete AFTER DELETE ON syrups BEGIN
+	DELETE FROM search_index WHERE kind = 'syrup' AND item_id = OLD.id;
+END;
+
+CREATE TRIGGER search_garnishes_insert AFTER INSERT ON garnishes BEGIN
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('garnish', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_garnishes_update AFTER UPDATE OF name ON garnishes BEGIN
+	DELETE FROM search_index WHERE kind = 'garnish' AND item_id = OLD.id;
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('garnish', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_garnishes_delete AFTER DELETE ON garnishes BEGIN
+	DELETE FROM search_index WHERE kind = 'garnish' AND item_id = OLD.id;
+END;
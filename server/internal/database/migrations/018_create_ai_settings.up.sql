@@ -0,0 +1,8 @@
+-- The AI provider settings, kept in a single row. The API key is encrypted
+-- with the server's ENCRYPTION_KEY and never stored in the clear.
+CREATE TABLE ai_settings (
+	id INTEGER PRIMARY KEY CHECK (id = 1),
+	base_url TEXT NOT NULL,
+	api_key_encrypted TEXT NOT NULL,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
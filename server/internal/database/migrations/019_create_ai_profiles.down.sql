@@ -0,0 +1,14 @@
+CREATE TABLE ai_settings (
+	id INTEGER PRIMARY KEY CHECK (id = 1),
+	base_url TEXT NOT NULL,
+	api_key_encrypted TEXT NOT NULL,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO ai_settings (id, base_url, api_key_encrypted, updated_at)
+SELECT 1, base_url, api_key_encrypted, updated_at
+FROM ai_profiles
+ORDER BY is_default DESC, id
+LIMIT 1;
+
+DROP TABLE IF EXISTS ai_profiles;
@@ -0,0 +1,22 @@
+-- Named AI provider profiles replace the single row of AI settings. The
+-- default profile is tried first and the rest, oldest first, are fallbacks.
+-- API keys stay encrypted with the server's ENCRYPTION_KEY.
+CREATE TABLE ai_profiles (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+	base_url TEXT NOT NULL,
+	api_key_encrypted TEXT NOT NULL,
+	default_model TEXT NULL,
+	timeout_seconds INTEGER NULL,
+	is_default BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_ai_profiles_default ON ai_profiles (is_default) WHERE is_default;
+
+INSERT INTO ai_profiles (name, base_url, api_key_encrypted, is_default, created_at, updated_at)
+SELECT 'default', base_url, api_key_encrypted, TRUE, updated_at, updated_at
+FROM ai_settings;
+
+DROP TABLE ai_settings;
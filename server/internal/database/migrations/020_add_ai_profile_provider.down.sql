@@ -0,0 +1 @@
+ALTER TABLE ai_profiles DROP COLUMN provider;
@@ -0,0 +1,3 @@
+-- The protocol a profile speaks: 'openai' for any OpenAI-compatible API, or
+-- 'ollama' for Ollama's native API.
+ALTER TABLE ai_profiles ADD COLUMN provider TEXT NOT NULL DEFAULT 'openai';
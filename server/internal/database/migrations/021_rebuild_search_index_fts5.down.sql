@@ -0,0 +1,169 @@
+-- Go back to the FTS4 index that 016 and 017 created.
+
+DROP TRIGGER IF EXISTS search_bottles_insert;
+DROP TRIGGER IF EXISTS search_bottles_update;
+DROP TRIGGER IF EXISTS search_bottles_delete;
+DROP TRIGGER IF EXISTS search_mixers_insert;
+DROP TRIGGER IF EXISTS search_mixers_update;
+DROP TRIGGER IF EXISTS search_mixers_delete;
+DROP TRIGGER IF EXISTS search_fresh_insert;
+DROP TRIGGER IF EXISTS search_fresh_update;
+DROP TRIGGER IF EXISTS search_fresh_delete;
+DROP TRIGGER IF EXISTS search_cocktails_insert;
+DROP TRIGGER IF EXISTS search_cocktails_update;
+DROP TRIGGER IF EXISTS search_cocktails_delete;
+DROP TRIGGER IF EXISTS search_cocktail_ingredients_insert;
+DROP TRIGGER IF EXISTS search_cocktail_ingredients_delete;
+DROP TRIGGER IF EXISTS search_bitters_insert;
+DROP TRIGGER IF EXISTS search_bitters_update;
+DROP TRIGGER IF EXISTS search_bitters_delete;
+DROP TRIGGER IF EXISTS search_syrups_insert;
+DROP TRIGGER IF EXISTS search_syrups_update;
+DROP TRIGGER IF EXISTS search_syrups_delete;
+DROP TRIGGER IF EXISTS search_garnishes_insert;
+DROP TRIGGER IF EXISTS search_garnishes_update;
+DROP TRIGGER IF EXISTS search_garnishes_delete;
+
+DROP TABLE IF EXISTS search_index;
+
+CREATE VIRTUAL TABLE search_index USING fts4(
+	kind,
+	item_id,
+	name,
+	details,
+	notindexed=kind,
+	notindexed=item_id,
+	tokenize=unicode61 "remove_diacritics=2"
+);
+
+INSERT INTO search_index (kind, item_id, name, details)
+SELECT 'bottle', id, name, concat_ws(' ', category, subcategory, brand, country, region) FROM bottles;
+
+INSERT INTO search_index (kind, item_id, name, details)
+SELECT 'mixer', id, name, '' FROM mixers;
+
+INSERT INTO search_index (kind, item_id, name, details)
+SELECT 'fresh', id, name, '' FROM fresh;
+
+INSERT INTO search_index (kind, item_id, name, details)
+SELECT 'cocktail', c.id, c.name, concat_ws(' ', c.description, (SELECT group_concat(name, ' ') FROM cocktail_ingredients WHERE cocktail_id = c.id))
+FROM cocktails c;
+
+CREATE TRIGGER search_bottles_insert AFTER INSERT ON bottles BEGIN
+	INSERT INTO search_index (kind, item_id, name, details)
+	VALUES ('bottle', NEW.id, NEW.name, concat_ws(' ', NEW.category, NEW.subcategory, NEW.brand, NEW.country, NEW.region));
+END;
+
+CREATE TRIGGER search_bottles_update AFTER UPDATE OF name, category, subcategory, brand, country, region ON bottles BEGIN
+	DELETE FROM search_index WHERE kind = 'bottle' AND item_id = OLD.id;
+	INSERT INTO search_index (kind, item_id, name, details)
+	VALUES ('bottle', NEW.id, NEW.name, concat_ws(' ', NEW.category, NEW.subcategory, NEW.brand, NEW.country, NEW.region));
+END;
+
+CREATE TRIGGER search_bottles_delete AFTER DELETE ON bottles BEGIN
+	DELETE FROM search_index WHERE kind = 'bottle' AND item_id = OLD.id;
+END;
+
+CREATE TRIGGER search_mixers_insert AFTER INSERT ON mixers BEGIN
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('mixer', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_mixers_update AFTER UPDATE OF name ON mixers BEGIN
+	DELETE FROM search_index WHERE kind = 'mixer' AND item_id = OLD.id;
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('mixer', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_mixers_delete AFTER DELETE ON mixers BEGIN
+	DELETE FROM search_index WHERE kind = 'mixer' AND item_id = OLD.id;
+END;
+
+CREATE TRIGGER search_fresh_insert AFTER INSERT ON fresh BEGIN
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('fresh', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_fresh_update AFTER UPDATE OF name ON fresh BEGIN
+	DELETE FROM search_index WHERE kind = 'fresh' AND item_id = OLD.id;
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('fresh', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_fresh_delete AFTER DELETE ON fresh BEGIN
+	DELETE FROM search_index WHERE kind = 'fresh' AND item_id = OLD.id;
+END;
+
+-- A recipe's row holds its description and ingredient names, so it is
+-- rebuilt whenever the recipe or its ingredients change.
+CREATE TRIGGER search_cocktails_insert AFTER INSERT ON cocktails BEGIN
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('cocktail', NEW.id, NEW.name, NEW.description);
+END;
+
+CREATE TRIGGER search_cocktails_update AFTER UPDATE OF name, description ON cocktails BEGIN
+	DELETE FROM search_index WHERE kind = 'cocktail' AND item_id = OLD.id;
+	INSERT INTO search_index (kind, item_id, name, details)
+	SELECT 'cocktail', NEW.id, NEW.name, concat_ws(' ', NEW.description, (SELECT group_concat(name, ' ') FROM cocktail_ingredients WHERE cocktail_id = NEW.id));
+END;
+
+CREATE TRIGGER search_cocktails_delete AFTER DELETE ON cocktails BEGIN
+	DELETE FROM search_index WHERE kind = 'cocktail' AND item_id = OLD.id;
+END;
+
+CREATE TRIGGER search_cocktail_ingredients_insert AFTER INSERT ON cocktail_ingredients BEGIN
+	DELETE FROM search_index WHERE kind = 'cocktail' AND item_id = NEW.cocktail_id;
+	INSERT INTO search_index (kind, item_id, name, details)
+	SELECT 'cocktail', c.id, c.name, concat_ws(' ', c.description, (SELECT group_concat(name, ' ') FROM cocktail_ingredients WHERE cocktail_id = c.id))
+	FROM cocktails c WHERE c.id = NEW.cocktail_id;
+END;
+
+CREATE TRIGGER search_cocktail_ingredients_delete AFTER DELETE ON cocktail_ingredients BEGIN
+	DELETE FROM search_index WHERE kind = 'cocktail' AND item_id = OLD.cocktail_id;
+	INSERT INTO search_index (kind, item_id, name, details)
+	SELECT 'cocktail', c.id, c.name, concat_ws(' ', c.description, (SELECT group_concat(name, ' ') FROM cocktail_ingredients WHERE cocktail_id = c.id))
+	FROM cocktails c WHERE c.id = OLD.cocktail_id;
+END;
+
+INSERT INTO search_index (kind, item_id, name, details)
+SELECT 'bitters', id, name, coalesce(brand, '') FROM bitters;
+
+INSERT INTO search_index (kind, item_id, name, details)
+SELECT 'syrup', id, name, '' FROM syrups;
+
+INSERT INTO search_index (kind, item_id, name, details)
+SELECT 'garnish', id, name, '' FROM garnishes;
+
+CREATE TRIGGER search_bitters_insert AFTER INSERT ON bitters BEGIN
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('bitters', NEW.id, NEW.name, coalesce(NEW.brand, ''));
+END;
+
+CREATE TRIGGER search_bitters_update AFTER UPDATE OF name, brand ON bitters BEGIN
+	DELETE FROM search_index WHERE kind = 'bitters' AND item_id = OLD.id;
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('bitters', NEW.id, NEW.name, coalesce(NEW.brand, ''));
+END;
+
+CREATE TRIGGER search_bitters_delete AFTER DELETE ON bitters BEGIN
+	DELETE FROM search_index WHERE kind = 'bitters' AND item_id = OLD.id;
+END;
+
+CREATE TRIGGER search_syrups_insert AFTER INSERT ON syrups BEGIN
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('syrup', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_syrups_update AFTER UPDATE OF name ON syrups BEGIN
+	DELETE FROM search_index WHERE kind = 'syrup' AND item_id = OLD.id;
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('syrup', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_syrups_delete AFTER DELETE ON syrups BEGIN
+	DELETE FROM search_index WHERE kind = 'syrup' AND item_id = OLD.id;
+END;
+
+CREATE TRIGGER search_garnishes_insert AFTER INSERT ON garnishes BEGIN
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('garnish', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_garnishes_update AFTER UPDATE OF name ON garnishes BEGIN
+	DELETE FROM search_index WHERE kind = 'garnish' AND item_id = OLD.id;
+	INSERT INTO search_index (kind, item_id, name, details) VALUES ('garnish', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_garnishes_delete AFTER DELETE ON garnishes BEGIN
+	DELETE FROM search_index WHERE kind = 'garnish' AND item_id = OLD.id;
+END;
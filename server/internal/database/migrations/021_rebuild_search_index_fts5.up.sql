@@ -0,0 +1,172 @@
+-- Rebuild the search index with FTS5, which ranks hits with bm25. FTS5 is only
+-- compiled into the SQLite driver with the sqlite_fts5 build tag, so the
+-- server must be built and tested with -tags sqlite_fts5.
+--
+-- Each row's rowid is its item's ID times eight plus a code for its kind
+-- (bottle 1, mixer 2, fresh 3, cocktail 4, bitters 5, syrup 6, garnish 7), so
+-- the triggers find the row to replace by rowid instead of scanning the
+-- unindexed kind and item_id columns.
+
+DROP TRIGGER IF EXISTS search_bottles_insert;
+DROP TRIGGER IF EXISTS search_bottles_update;
+DROP TRIGGER IF EXISTS search_bottles_delete;
+DROP TRIGGER IF EXISTS search_mixers_insert;
+DROP TRIGGER IF EXISTS search_mixers_update;
+DROP TRIGGER IF EXISTS search_mixers_delete;
+DROP TRIGGER IF EXISTS search_fresh_insert;
+DROP TRIGGER IF EXISTS search_fresh_update;
+DROP TRIGGER IF EXISTS search_fresh_delete;
+DROP TRIGGER IF EXISTS search_cocktails_insert;
+DROP TRIGGER IF EXISTS search_cocktails_update;
+DROP TRIGGER IF EXISTS search_cocktails_delete;
+DROP TRIGGER IF EXISTS search_cocktail_ingredients_insert;
+DROP TRIGGER IF EXISTS search_cocktail_ingredients_delete;
+DROP TRIGGER IF EXISTS search_bitters_insert;
+DROP TRIGGER IF EXISTS search_bitters_update;
+DROP TRIGGER IF EXISTS search_bitters_delete;
+DROP TRIGGER IF EXISTS search_syrups_insert;
+DROP TRIGGER IF EXISTS search_syrups_update;
+DROP TRIGGER IF EXISTS search_syrups_delete;
+DROP TRIGGER IF EXISTS search_garnishes_insert;
+DROP TRIGGER IF EXISTS search_garnishes_update;
+DROP TRIGGER IF EXISTS search_garnishes_delete;
+
+DROP TABLE IF EXISTS search_index;
+
+CREATE VIRTUAL TABLE search_index USING fts5(
+	kind UNINDEXED,
+	item_id UNINDEXED,
+	name,
+	details,
+	tokenize = 'unicode61 remove_diacritics 2'
+);
+
+INSERT INTO search_index (rowid, kind, item_id, name, details)
+SELECT id * 8 + 1, 'bottle', id, name, concat_ws(' ', category, subcategory, brand, country, region) FROM bottles;
+
+INSERT INTO search_index (rowid, kind, item_id, name, details)
+SELECT id * 8 + 2, 'mixer', id, name, '' FROM mixers;
+
+INSERT INTO search_index (rowid, kind, item_id, name, details)
+SELECT id * 8 + 3, 'fresh', id, name, '' FROM fresh;
+
+INSERT INTO search_index (rowid, kind, item_id, name, details)
+SELECT c.id * 8 + 4, 'cocktail', c.id, c.name, concat_ws(' ', c.description, (SELECT group_concat(name, ' ') FROM cocktail_ingredients WHERE cocktail_id = c.id))
+FROM cocktails c;
+
+INSERT INTO search_index (rowid, kind, item_id, name, details)
+SELECT id * 8 + 5, 'bitters', id, name, coalesce(brand, '') FROM bitters;
+
+INSERT INTO search_index (rowid, kind, item_id, name, details)
+SELECT id * 8 + 6, 'syrup', id, name, '' FROM syrups;
+
+INSERT INTO search_index (rowid, kind, item_id, name, details)
+SELECT id * 8 + 7, 'garnish', id, name, '' FROM garnishes;
+
+CREATE TRIGGER search_bottles_insert AFTER INSERT ON bottles BEGIN
+	INSERT INTO search_index (rowid, kind, item_id, name, details) VALUES (NEW.id * 8 + 1, 'bottle', NEW.id, NEW.name, concat_ws(' ', NEW.category, NEW.subcategory, NEW.brand, NEW.country, NEW.region));
+END;
+
+CREATE TRIGGER search_bottles_update AFTER UPDATE OF name, category, subcategory, brand, country, region ON bottles BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.id * 8 + 1;
+	INSERT INTO search_index (rowid, kind, item_id, name, details) VALUES (NEW.id * 8 + 1, 'bottle', NEW.id, NEW.name, concat_ws(' ', NEW.category, NEW.subcategory, NEW.brand, NEW.country, NEW.region));
+END;
+
+CREATE TRIGGER search_bottles_delete AFTER DELETE ON bottles BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.id * 8 + 1;
+END;
+
+CREATE TRIGGER search_mixers_insert AFTER INSERT ON mixers BEGIN
+	INSERT INTO search_index (rowid, kind, item_id, name, details) VALUES (NEW.id * 8 + 2, 'mixer', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_mixers_update AFTER UPDATE OF name ON mixers BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.id * 8 + 2;
+	INSERT INTO search_index (rowid, kind, item_id, name, details) VALUES (NEW.id * 8 + 2, 'mixer', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_mixers_delete AFTER DELETE ON mixers BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.id * 8 + 2;
+END;
+
+CREATE TRIGGER search_fresh_insert AFTER INSERT ON fresh BEGIN
+	INSERT INTO search_index (rowid, kind, item_id, name, details) VALUES (NEW.id * 8 + 3, 'fresh', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_fresh_update AFTER UPDATE OF name ON fresh BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.id * 8 + 3;
+	INSERT INTO search_index (rowid, kind, item_id, name, details) VALUES (NEW.id * 8 + 3, 'fresh', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_fresh_delete AFTER DELETE ON fresh BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.id * 8 + 3;
+END;
+
+-- A recipe's row holds its description and ingredient names, so it is
+-- rebuilt whenever the recipe or its ingredients change.
+CREATE TRIGGER search_cocktails_insert AFTER INSERT ON cocktails BEGIN
+	INSERT INTO search_index (rowid, kind, item_id, name, details) VALUES (NEW.id * 8 + 4, 'cocktail', NEW.id, NEW.name, NEW.description);
+END;
+
+CREATE TRIGGER search_cocktails_update AFTER UPDATE OF name, description ON cocktails BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.id * 8 + 4;
+	INSERT INTO search_index (rowid, kind, item_id, name, details)
+	SELECT NEW.id * 8 + 4, 'cocktail', NEW.id, NEW.name, concat_ws(' ', NEW.description, (SELECT group_concat(name, ' ') FROM cocktail_ingredients WHERE cocktail_id = NEW.id));
+END;
+
+CREATE TRIGGER search_cocktails_delete AFTER DELETE ON cocktails BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.id * 8 + 4;
+END;
+
+CREATE TRIGGER search_cocktail_ingredients_insert AFTER INSERT ON cocktail_ingredients BEGIN
+	DELETE FROM search_index WHERE rowid = NEW.cocktail_id * 8 + 4;
+	INSERT INTO search_index (rowid, kind, item_id, name, details)
+	SELECT c.id * 8 + 4, 'cocktail', c.id, c.name, concat_ws(' ', c.description, (SELECT group_concat(name, ' ') FROM cocktail_ingredients WHERE cocktail_id = c.id))
+	FROM cocktails c WHERE c.id = NEW.cocktail_id;
+END;
+
+CREATE TRIGGER search_cocktail_ingredients_delete AFTER DELETE ON cocktail_ingredients BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.cocktail_id * 8 + 4;
+	INSERT INTO search_index (rowid, kind, item_id, name, details)
+	SELECT c.id * 8 + 4, 'cocktail', c.id, c.name, concat_ws(' ', c.description, (SELECT group_concat(name, ' ') FROM cocktail_ingredients WHERE cocktail_id = c.id))
+	FROM cocktails c WHERE c.id = OLD.cocktail_id;
+END;
+
+CREATE TRIGGER search_bitters_insert AFTER INSERT ON bitters BEGIN
+	INSERT INTO search_index (rowid, kind, item_id, name, details) VALUES (NEW.id * 8 + 5, 'bitters', NEW.id, NEW.name, coalesce(NEW.brand, ''));
+END;
+
+CREATE TRIGGER search_bitters_update AFTER UPDATE OF name, brand ON bitters BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.id * 8 + 5;
+	INSERT INTO search_index (rowid, kind, item_id, name, details) VALUES (NEW.id * 8 + 5, 'bitters', NEW.id, NEW.name, coalesce(NEW.brand, ''));
+END;
+
+CREATE TRIGGER search_bitters_delete AFTER DELETE ON bitters BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.id * 8 + 5;
+END;
+
+CREATE TRIGGER search_syrups_insert AFTER INSERT ON syrups BEGIN
+	INSERT INTO search_index (rowid, kind, item_id, name, details) VALUES (NEW.id * 8 + 6, 'syrup', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_syrups_update AFTER UPDATE OF name ON syrups BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.id * 8 + 6;
+	INSERT INTO search_index (rowid, kind, item_id, name, details) VALUES (NEW.id * 8 + 6, 'syrup', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_syrups_delete AFTER DELETE ON syrups BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.id * 8 + 6;
+END;
+
+CREATE TRIGGER search_garnishes_insert AFTER INSERT ON garnishes BEGIN
+	INSERT INTO search_index (rowid, kind, item_id, name, details) VALUES (NEW.id * 8 + 7, 'garnish', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_garnishes_update AFTER UPDATE OF name ON garnishes BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.id * 8 + 7;
+	INSERT INTO search_index (rowid, kind, item_id, name, details) VALUES (NEW.id * 8 + 7, 'garnish', NEW.id, NEW.name, '');
+END;
+
+CREATE TRIGGER search_garnishes_delete AFTER DELETE ON garnishes BEGIN
+	DELETE FROM search_index WHERE rowid = OLD.id * 8 + 7;
+END;
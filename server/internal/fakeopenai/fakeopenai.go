@@ -0,0 +1,351 @@
+// Package fakeopenai is an in-process stand-in for an OpenAI-compatible API.
+// It answers the model list and chat completion endpoints from a script, so
+// the AI features can be tested and demoed with no network or API key.
+//
+// Each chat completion request gets the next turn of the script: a tool call,
+// a text or JSON answer, or an error. Every request is recorded so tests can
+// check what was sent.
+package fakeopenai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Script is what the fake says, in order.
+type Script struct {
+	// Models are the model IDs listed by GET /models. An empty list lists
+	// "fake-model".
+	Models []string `json:"models,omitempty"`
+	Turns  []Turn   `json:"turns"`
+	// Loop starts the script again after the last turn. Otherwise requests
+	// past the end get a 500 error.
+	Loop bool `json:"loop,omitempty"`
+}
+
+// Turn is the answer to one chat completion request. Set one of ToolCalls,
+// Content or Status.
+type Turn struct {
+	// ToolCalls asks the client to call its tools.
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// Content is the answer. A JSON string is sent as its text; any other
+	// JSON value, such as an object for a json_schema response, is sent as
+	// its JSON encoding.
+	Content json.RawMessage `json:"content,omitempty"`
+	// Status fails the request with this HTTP status and Error as the
+	// message. openai-go retries 408, 409, 429 and 5xx statuses, and each
+	// retry takes another turn.
+	Status int    `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// Delay holds the answer back, as in "2s", to test timeouts.
+	Delay Duration `json:"delay,omitempty"`
+}
+
+// ToolCall is a call to one of the client's tools.
+type ToolCall struct {
+	Name string `json:"name"`
+	// Arguments is the JSON object of arguments; empty means {}.
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// Duration is a time.Duration written in Go syntax in JSON, as in "1.5s".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"2s\": %v", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// LoadScript reads a Script from a JSON file.
+func LoadScript(path string) (Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Script{}, err
+	}
+
+	var script Script
+	if err := json.Unmarshal(data, &script); err != nil {
+		return Script{}, fmt.Errorf("invalid script %s: %v", path, err)
+	}
+	return script, nil
+}
+
+// Request is a chat completion request as the fake received it.
+type Request struct {
+	Model          string          `json:"model"`
+	Messages       []Message       `json:"messages"`
+	Tools          []Tool          `json:"tools,omitempty"`
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+}
+
+// ToolNames lists the names of the tools offered in the request.
+func (r Request) ToolNames() []string {
+	var names []string
+	for _, tool := range r.Tools {
+		names = append(names, tool.Function.Name)
+	}
+	return names
+}
+
+type Message struct {
+	Role       string          `json:"role"`
+	Content    json.RawMessage `json:"content,omitempty"`
+	ToolCallID string          `json:"tool_call_id,omitempty"`
+	ToolCalls  []struct {
+		ID       string `json:"id"`
+		Function struct {
+			Name      string `json:"name"`
+			Arguments string `json:"arguments"`
+		} `json:"function"`
+	} `json:"tool_calls,omitempty"`
+}
+
+// Text returns the message's content, whether it was sent as a string or as
+// text parts.
+func (m Message) Text() string {
+	var s string
+	if json.Unmarshal(m.Content, &s) == nil {
+		return s
+	}
+
+	var parts []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if json.Unmarshal(m.Content, &parts) != nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, part := range parts {
+		b.WriteString(part.Text)
+	}
+	return b.String()
+}
+
+type Tool struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description,omitempty"`
+		Parameters  json.RawMessage `json:"parameters,omitempty"`
+	} `json:"function"`
+}
+
+type ResponseFormat struct {
+	Type       string `json:"type"`
+	JSONSchema *struct {
+		Name   string          `json:"name"`
+		Schema json.RawMessage `json:"schema"`
+		Strict bool            `json:"strict"`
+	} `json:"json_schema,omitempty"`
+}
+
+// Server serves a Script.
+type Server struct {
+	// APIKey, if set, must be sent as a bearer token.
+	APIKey string
+
+	mu       sync.Mutex
+	script   Script
+	next     int
+	calls    int
+	requests []Request
+}
+
+// New makes a Server for script. Serve it with Start, or as an http.Handler.
+func New(script Script) *Server {
+	return &Server{script: script}
+}
+
+// Start serves s on a local port until the returned server is closed. Its URL
+// is the base URL to give the client.
+func (s *Server) Start() *httptest.Server {
+	return httptest.NewServer(s)
+}
+
+// Requests returns the chat completion requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.APIKey != "" && r.Header.Get("Authorization") != "Bearer "+s.APIKey {
+		writeError(w, http.StatusUnauthorized, "invalid API key")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/models"):
+		s.listModels(w)
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/chat/completions"):
+		s.chatCompletion(w, r)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) listModels(w http.ResponseWriter) {
+	ids := s.script.Models
+	if len(ids) == 0 {
+		ids = []string{"fake-model"}
+	}
+
+	type model struct {
+		ID      string `json:"id"`
+		Object  string `json:"object"`
+		Created int64  `json:"created"`
+		OwnedBy string `json:"owned_by"`
+	}
+	list := struct {
+		Object string  `json:"object"`
+		Data   []model `json:"data"`
+	}{Object: "list", Data: []model{}}
+	for _, id := range ids {
+		list.Data = append(list.Data, model{ID: id, Object: "model", OwnedBy: "fakeopenai"})
+	}
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+var errScriptDone = errors.New("the script has no more turns")
+
+// turn records req and takes the next turn of the script.
+func (s *Server) turn(req Request) (Turn, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, req)
+	if s.next >= len(s.script.Turns) {
+		if !s.script.Loop || len(s.script.Turns) == 0 {
+			return Turn{}, 0, errScriptDone
+		}
+		s.next = 0
+	}
+
+	turn := s.script.Turns[s.next]
+	s.next++
+	s.calls++
+	return turn, s.calls, nil
+}
+
+func (s *Server) chatCompletion(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unable to read request")
+		return
+	}
+	var req Request
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	turn, n, err := s.turn(req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if turn.Delay > 0 {
+		select {
+		case <-time.After(time.Duration(turn.Delay)):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if turn.Status != 0 {
+		writeError(w, turn.Status, turn.Error)
+		return
+	}
+
+	type function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	}
+	type toolCall struct {
+		ID       string   `json:"id"`
+		Type     string   `json:"type"`
+		Function function `json:"function"`
+	}
+	message := struct {
+		Role      string     `json:"role"`
+		Content   *string    `json:"content"`
+		ToolCalls []toolCall `json:"tool_calls,omitempty"`
+	}{Role: "assistant"}
+	finish := "stop"
+
+	if len(turn.ToolCalls) > 0 {
+		finish = "tool_calls"
+		for i, call := range turn.ToolCalls {
+			arguments := "{}"
+			if len(call.Arguments) > 0 {
+				arguments = string(call.Arguments)
+			}
+			message.ToolCalls = append(message.ToolCalls, toolCall{
+				ID:       fmt.Sprintf("call_%d_%d", n, i+1),
+				Type:     "function",
+				Function: function{Name: call.Name, Arguments: arguments},
+			})
+		}
+	} else {
+		content := contentText(turn.Content)
+		message.Content = &content
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":      fmt.Sprintf("chatcmpl-fake-%d", n),
+		"object":  "chat.completion",
+		"created": time.Now().Unix(),
+		"model":   req.Model,
+		"choices": []any{map[string]any{
+			"index":         0,
+			"message":       message,
+			"finish_reason": finish,
+			"logprobs":      nil,
+		}},
+		"usage": map[string]int{"prompt_tokens": 0, "completion_tokens": 0, "total_tokens": 0},
+	})
+}
+
+func contentText(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{
+		"error": map[string]string{"message": message, "type": "fakeopenai_error"},
+	})
+}
@@ -0,0 +1,85 @@
+package fakeopenai
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func post(t *testing.T, url string) (int, map[string]any) {
+	t.Helper()
+
+	resp, err := http.Post(url+"/v1/chat/completions", "application/json", strings.NewReader(`{"model": "m", "messages": [{"role": "user", "content": "hi"}]}`))
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return resp.StatusCode, body
+}
+
+func content(body map[string]any) any {
+	choice := body["choices"].([]any)[0].(map[string]any)
+	return choice["message"].(map[string]any)["content"]
+}
+
+func TestScriptOrder(t *testing.T) {
+	for _, loop := range []bool{false, true} {
+		fake := New(Script{Loop: loop, Turns: []Turn{
+			{Content: json.RawMessage(`"one"`)},
+			{Content: json.RawMessage(`{"n": 2}`)},
+		}})
+		server := fake.Start()
+
+		if _, body := post(t, server.URL); content(body) != "one" {
+			t.Errorf("loop=%v: first answer = %v, want one", loop, content(body))
+		}
+		if _, body := post(t, server.URL); content(body) != `{"n": 2}` {
+			t.Errorf("loop=%v: second answer = %v, want the JSON object as text", loop, content(body))
+		}
+
+		status, body := post(t, server.URL)
+		switch {
+		case loop && (status != http.StatusOK || content(body) != "one"):
+			t.Errorf("loop=true: third answer = %d %v, want the script again", status, body)
+		case !loop && status != http.StatusInternalServerError:
+			t.Errorf("loop=false: third answer status = %d, want 500", status)
+		}
+
+		if n := len(fake.Requests()); n != 3 {
+			t.Errorf("loop=%v: recorded %d requests, want 3", loop, n)
+		}
+		server.Close()
+	}
+}
+
+func TestLoadScript(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "script.json")
+	data := `{"models": ["m"], "turns": [{"tool_calls": [{"name": "list_bottles"}], "delay": "1.5s"}, {"status": 429, "error": "slow down"}]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+
+	script, err := LoadScript(path)
+	if err != nil {
+		t.Fatalf("LoadScript() error = %v", err)
+	}
+	if len(script.Turns) != 2 || script.Turns[0].ToolCalls[0].Name != "list_bottles" || time.Duration(script.Turns[0].Delay) != 1500*time.Millisecond || script.Turns[1].Status != 429 {
+		t.Errorf("LoadScript() = %+v", script)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"turns": [{"delay": 5}]}`), 0o644); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+	if _, err := LoadScript(path); err == nil {
+		t.Error("LoadScript() with a numeric delay succeeded, want an error")
+	}
+}
@@ -0,0 +1,223 @@
+// Package freshness works out when prepared fresh ingredients, opened mixers
+// and homemade syrups go bad, from their dates and a shelf life that defaults
+// by kind of ingredient.
+package freshness
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nguyenjessev/liquor-locker/internal/matcher"
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+const (
+	StatusFresh    = "fresh"
+	StatusExpiring = "expiring"
+	StatusExpired  = "expired"
+)
+
+const day = 24 * time.Hour
+
+// minWarning is the shortest time before expiry that an item is flagged as
+// expiring, so one-day items like lime juice don't go straight to expired.
+const minWarning = 12 * time.Hour
+
+// rule gives a shelf life in days to items whose name contains every word of
+// name. Rules are checked in order, so more specific names come first.
+type rule struct {
+	name string
+	days int
+}
+
+// freshRules apply to fresh items from the day they are prepared, and to
+// syrups from the day they are made.
+var freshRules = []rule{
+	{"lime juice", 1},
+	{"lemon juice", 1},
+	{"grapefruit juice", 2},
+	{"orange juice", 2},
+	{"pineapple juice", 3},
+	{"juice", 2},
+	{"rich syrup", 42},
+	{"honey syrup", 28},
+	{"simple syrup", 28},
+	{"ginger syrup", 14},
+	{"orgeat", 14},
+	{"grenadine", 28},
+	{"syrup", 21},
+	{"egg white", 2},
+	{"egg", 21},
+	{"cream", 7},
+	{"mint", 7},
+	{"basil", 5},
+	{"lime", 14},
+	{"lemon", 14},
+	{"orange", 14},
+}
+
+// DefaultFreshDays is the shelf life of a fresh item no rule matches.
+const DefaultFreshDays = 7
+
+// mixerRules apply to mixers from the day they are opened.
+var mixerRules = []rule{
+	{"tonic", 3},
+	{"soda", 3},
+	{"ginger beer", 3},
+	{"ginger ale", 3},
+	{"cola", 3},
+	{"sparkling", 3},
+	{"juice", 7},
+	{"cream", 7},
+	{"syrup", 28},
+	{"grenadine", 28},
+}
+
+// DefaultMixerDays is the opened shelf life of a mixer no rule matches.
+const DefaultMixerDays = 14
+
+// DefaultSyrupDays is the shelf life of a syrup no rule matches.
+const DefaultSyrupDays = 21
+
+// DefaultShelfLife returns the shelf life in days for an item of the given
+// kind ("fresh", "mixer" or "syrup") by its name.
+func DefaultShelfLife(kind, name string) int {
+	rules, fallback := freshRules, DefaultFreshDays
+	switch kind {
+	case "mixer":
+		rules, fallback = mixerRules, DefaultMixerDays
+	case "syrup":
+		fallback = DefaultSyrupDays
+	}
+
+	words := make(map[string]bool)
+	for _, word := range strings.Fields(matcher.Normalize(name)) {
+		words[word] = true
+	}
+
+	for _, r := range rules {
+		if containsAll(words, strings.Fields(matcher.Normalize(r.name))) {
+			return r.days
+		}
+	}
+	return fallback
+}
+
+func containsAll(words map[string]bool, want []string) bool {
+	for _, word := range want {
+		if !words[word] {
+			return false
+		}
+	}
+	return true
+}
+
+// Evaluate works out when an item started at start expires and its status at
+// now. Items without a start date are always fresh. An item is expiring in the
+// last quarter of its shelf life, and at least in its last twelve hours.
+func Evaluate(start *time.Time, shelfLifeDays int, now time.Time) models.Expiry {
+	expiry := models.Expiry{ShelfLifeDays: shelfLifeDays, Status: StatusFresh}
+	if start == nil || shelfLifeDays <= 0 {
+		return expiry
+	}
+
+	shelfLife := time.Duration(shelfLifeDays) * day
+	expiresAt := start.Add(shelfLife)
+	expiry.ExpiresAt = &expiresAt
+
+	warning := max(shelfLife/4, minWarning)
+	switch left := expiresAt.Sub(now); {
+	case left <= 0:
+		expiry.Status = StatusExpired
+	case left <= warning:
+		expiry.Status = StatusExpiring
+	}
+
+	return expiry
+}
+
+// Fresh evaluates a fresh item, counting from when it was prepared, or bought
+// if it has no prepared date.
+func Fresh(item *models.Fresh, now time.Time) models.Expiry {
+	start := item.PreparedDate
+	if start == nil {
+		start = item.PurchaseDate
+	}
+	return Evaluate(start, shelfLife(item.ShelfLifeDays, "fresh", item.Name), now)
+}
+
+// Mixer evaluates a mixer, counting from when it was opened. Sealed mixers
+// don't expire.
+func Mixer(mixer *models.Mixer, now time.Time) models.Expiry {
+	var start *time.Time
+	if mixer.Opened {
+		start = mixer.OpenDate
+	}
+	return Evaluate(start, shelfLife(mixer.ShelfLifeDays, "mixer", mixer.Name), now)
+}
+
+// Syrup evaluates a syrup, counting from when it was made, or bought if it
+// has no made date.
+func Syrup(syrup *models.Syrup, now time.Time) models.Expiry {
+	start := syrup.MadeDate
+	if start == nil {
+		start = syrup.PurchaseDate
+	}
+	return Evaluate(start, DefaultShelfLife("syrup", syrup.Name), now)
+}
+
+func shelfLife(days *int, kind, name string) int {
+	if days != nil {
+		return *days
+	}
+	return DefaultShelfLife(kind, name)
+}
+
+// Expiring lists the unfinished mixers, fresh items and syrups that have
+// expired or will within the given window, soonest first. A zero window lists
+// only items whose status is expiring or expired.
+func Expiring(mixers []*models.Mixer, fresh []*models.Fresh, syrups []*models.Syrup, now time.Time, within time.Duration) []models.ExpiringItem {
+	items := []models.ExpiringItem{}
+	add := func(kind string, id int64, name string, expiry models.Expiry) {
+		if expiry.ExpiresAt == nil {
+			return
+		}
+		if within > 0 {
+			if expiry.ExpiresAt.Sub(now) > within {
+				return
+			}
+		} else if expiry.Status == StatusFresh {
+			return
+		}
+		items = append(items, models.ExpiringItem{
+			Kind:          kind,
+			ID:            id,
+			Name:          name,
+			ShelfLifeDays: expiry.ShelfLifeDays,
+			ExpiresAt:     *expiry.ExpiresAt,
+			Status:        expiry.Status,
+		})
+	}
+
+	for _, mixer := range mixers {
+		if !mixer.Finished {
+			add("mixer", mixer.ID, mixer.Name, Mixer(mixer, now))
+		}
+	}
+	for _, item := range fresh {
+		if !item.Finished {
+			add("fresh", item.ID, item.Name, Fresh(item, now))
+		}
+	}
+	for _, syrup := range syrups {
+		if !syrup.Finished {
+			add("syrup", syrup.ID, syrup.Name, Syrup(syrup, now))
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ExpiresAt.Before(items[j].ExpiresAt)
+	})
+	return items
+}
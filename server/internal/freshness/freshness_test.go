@@ -0,0 +1,116 @@
+package freshness
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+var now = time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+func ago(d time.Duration) *time.Time {
+	t := now.Add(-d)
+	return &t
+}
+
+func TestDefaultShelfLife(t *testing.T) {
+	tests := []struct {
+		kind string
+		name string
+		want int
+	}{
+		{"fresh", "Lime Juice", 1},
+		{"fresh", "Fresh Squeezed Lemon Juice", 1},
+		{"fresh", "Simple Syrup", 28},
+		{"fresh", "Rich Demerara Syrup", 42},
+		{"fresh", "Egg Whites", 2},
+		{"fresh", "Limes", 14},
+		{"fresh", "Cucumber", DefaultFreshDays},
+		{"mixer", "Fever-Tree Tonic Water", 3},
+		{"mixer", "Club Soda", 3},
+		{"mixer", "Ginger Beer", 3},
+		{"mixer", "Cranberry Juice", 7},
+		{"mixer", "Bitter Lemon", DefaultMixerDays},
+		{"syrup", "Honey Syrup", 28},
+		{"syrup", "Orgeat", 14},
+		{"syrup", "Demerara", DefaultSyrupDays},
+	}
+
+	for _, tt := range tests {
+		if got := DefaultShelfLife(tt.kind, tt.name); got != tt.want {
+			t.Errorf("DefaultShelfLife(%q, %q) = %d, want %d", tt.kind, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name   string
+		start  *time.Time
+		days   int
+		status string
+	}{
+		{"no start date", nil, 3, StatusFresh},
+		{"just opened", ago(time.Hour), 3, StatusFresh},
+		{"last quarter", ago(60 * time.Hour), 3, StatusExpiring},
+		{"past expiry", ago(80 * time.Hour), 3, StatusExpired},
+		{"one-day item just made", ago(time.Hour), 1, StatusFresh},
+		{"one-day item in last twelve hours", ago(13 * time.Hour), 1, StatusExpiring},
+	}
+
+	for _, tt := range tests {
+		got := Evaluate(tt.start, tt.days, now)
+		if got.Status != tt.status {
+			t.Errorf("%s: Evaluate() status = %q, want %q", tt.name, got.Status, tt.status)
+		}
+		if (tt.start == nil) != (got.ExpiresAt == nil) {
+			t.Errorf("%s: Evaluate() expires_at = %v, want set only with a start date", tt.name, got.ExpiresAt)
+		}
+	}
+}
+
+func TestMixer_SealedNeverExpires(t *testing.T) {
+	mixer := &models.Mixer{Name: "Tonic Water", Opened: false, OpenDate: ago(30 * 24 * time.Hour)}
+	if got := Mixer(mixer, now); got.Status != StatusFresh || got.ExpiresAt != nil {
+		t.Errorf("Mixer() = %+v, want a sealed mixer to stay fresh", got)
+	}
+}
+
+func TestFresh_ShelfLifeOverride(t *testing.T) {
+	days := 10
+	item := &models.Fresh{Name: "Lime Juice", PreparedDate: ago(2 * 24 * time.Hour), ShelfLifeDays: &days}
+	if got := Fresh(item, now); got.Status != StatusFresh || got.ShelfLifeDays != 10 {
+		t.Errorf("Fresh() = %+v, want the override to keep it fresh", got)
+	}
+}
+
+func TestExpiring(t *testing.T) {
+	mixers := []*models.Mixer{
+		{ID: 1, Name: "Tonic Water", Opened: true, OpenDate: ago(4 * 24 * time.Hour)},
+		{ID: 2, Name: "Ginger Beer", Opened: true, OpenDate: ago(time.Hour)},
+		{ID: 3, Name: "Club Soda", Opened: true, OpenDate: ago(5 * 24 * time.Hour), Finished: true},
+	}
+	fresh := []*models.Fresh{
+		{ID: 1, Name: "Lime Juice", PreparedDate: ago(18 * time.Hour)},
+		{ID: 2, Name: "Simple Syrup", PreparedDate: ago(24 * time.Hour)},
+	}
+	syrups := []*models.Syrup{
+		{ID: 1, Name: "Honey Syrup", MadeDate: ago(27 * 24 * time.Hour)},
+		{ID: 2, Name: "Orgeat", MadeDate: ago(20 * 24 * time.Hour), Finished: true},
+		{ID: 3, Name: "Rich Simple Syrup", PurchaseDate: ago(24 * time.Hour)},
+	}
+
+	got := Expiring(mixers, fresh, syrups, now, 0)
+	if len(got) != 3 || got[0].Name != "Tonic Water" || got[1].Name != "Lime Juice" || got[2].Name != "Honey Syrup" {
+		t.Fatalf("Expiring() = %+v, want expired tonic, then expiring lime juice and honey syrup", got)
+	}
+	if got[0].Status != StatusExpired || got[1].Status != StatusExpiring || got[2].Status != StatusExpiring || got[2].Kind != "syrup" {
+		t.Errorf("Expiring() = %+v, want expired, expiring, expiring syrup", got)
+	}
+
+	got = Expiring(mixers, fresh, syrups, now, 3*24*time.Hour)
+	if len(got) != 4 || got[3].Name != "Ginger Beer" {
+		t.Errorf("Expiring() within 3 days = %+v, want tonic, lime juice, honey syrup and ginger beer", got)
+	}
+}
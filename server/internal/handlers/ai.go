@@ -1,26 +1,81 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/nguyenjessev/liquor-locker/internal/models"
 	"github.com/nguyenjessev/liquor-locker/internal/repository"
+	"github.com/nguyenjessev/liquor-locker/internal/secret"
 	"github.com/nguyenjessev/liquor-locker/internal/services"
 )
 
+// defaultAITimeout bounds a request to a profile that has no timeout of its
+// own.
+const defaultAITimeout = 60 * time.Second
+
+// defaultProfileName names the profile set through Configure.
+const defaultProfileName = "default"
+
+// aiProfile is an AI profile ready to use, its key decrypted.
+type aiProfile struct {
+	models.AIProfile
+	apiKey string
+	// service is nil if the saved key couldn't be decrypted.
+	service services.Provider
+	// inUse counts the requests using service, which is only closed once
+	// they finish.
+	inUse sync.WaitGroup
+}
+
+func (p *aiProfile) timeout() time.Duration {
+	if p.TimeoutSeconds != nil {
+		return time.Duration(*p.TimeoutSeconds) * time.Second
+	}
+	return defaultAITimeout
+}
+
+func (p *aiProfile) response() models.AIProfileResponse {
+	return models.AIProfileResponse{
+		ID:             p.ID,
+		Name:           p.Name,
+		Provider:       p.Provider,
+		BaseURL:        p.BaseURL,
+		APIKey:         redact(p.apiKey),
+		DefaultModel:   p.DefaultModel,
+		TimeoutSeconds: p.TimeoutSeconds,
+		IsDefault:      p.IsDefault,
+		Ready:          p.service != nil,
+	}
+}
+
 // AIHandler handles AI-related endpoints
 type AIHandler struct {
-	aiService *services.OpenAIService
-	mu        sync.Mutex
+	repo *repository.Repository
+	// box encrypts API keys for storage. Without one, the profile set through
+	// Configure is kept in memory only and is lost on restart.
+	box *secret.Box
+	// profiles are in the order they are tried: the default first, then the
+	// fallbacks.
+	profiles []*aiProfile
+	mu       sync.Mutex
 }
 
 // ListModels godoc
 // @Summary List available AI models
-// @Description Returns a list of available AI models
+// @Description Returns a list of available AI models from the default profile, or from the named one
 // @Tags ai
 // @Produce json
+// @Param profile query string false "Profile name"
 // @Success 200 {array} string
+// @Failure 400 {object} map[string]string
 // @Failure 405 {object} map[string]string
 // @Failure 503 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -32,14 +87,17 @@ func (h *AIHandler) ListModels(w http.ResponseWriter, r *http.Request) {
 	}
 
 	h.mu.Lock()
-	defer h.mu.Unlock()
-
-	if h.aiService == nil {
-		http.Error(w, "AI service not configured", http.StatusServiceUnavailable)
+	profile, ok := h.profile(w, r.URL.Query().Get("profile"))
+	if ok {
+		profile.inUse.Add(1)
+	}
+	h.mu.Unlock()
+	if !ok {
 		return
 	}
+	defer profile.inUse.Done()
 
-	models, err := h.aiService.ListModels(r.Context())
+	models, err := profile.service.ListModels(r.Context())
 	if err != nil {
 		http.Error(w, "Failed to list models: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -52,18 +110,152 @@ func (h *AIHandler) ListModels(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// NewAIHandler creates a new AIHandler
-func NewAIHandler() *AIHandler {
-	return &AIHandler{}
+// NewAIHandler creates a new AIHandler with the saved profiles that box can
+// decrypt. box may be nil.
+func NewAIHandler(repo *repository.Repository, box *secret.Box) *AIHandler {
+	h := &AIHandler{repo: repo, box: box}
+	if box == nil {
+		return h
+	}
+
+	if err := h.load(context.Background()); err != nil {
+		log.Printf("ERROR: Loading AI profiles failed - error=%v", err)
+	}
+	return h
+}
+
+// load replaces the profiles with the saved ones. The caller must hold h.mu,
+// or be the constructor.
+func (h *AIHandler) load(ctx context.Context) error {
+	saved, err := h.repo.GetAIProfiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	profiles := make([]*aiProfile, 0, len(saved))
+	for _, s := range saved {
+		profile := &aiProfile{AIProfile: *s}
+		apiKey, err := h.box.Open(s.EncryptedAPIKey)
+		if err != nil {
+			log.Printf("WARNING: AI profile %q can't be decrypted with ENCRYPTION_KEY; enter its API key again - error=%v", s.Name, err)
+			profiles = append(profiles, profile)
+			continue
+		}
+		profile.apiKey = apiKey
+		profile.service, err = services.NewProvider(services.ProviderKind(s.Provider), s.BaseURL, apiKey)
+		if err != nil {
+			log.Printf("WARNING: AI profile %q has an unknown provider %q - error=%v", s.Name, s.Provider, err)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	h.replaceProfiles(profiles)
+	return nil
+}
+
+// replaceProfiles swaps in new profiles. The old services are cleaned up once
+// the requests still using them finish. The caller must hold h.mu.
+func (h *AIHandler) replaceProfiles(profiles []*aiProfile) {
+	for _, old := range h.profiles {
+		if old.service != nil {
+			go func() {
+				old.inUse.Wait()
+				if err := old.service.Close(); err != nil {
+					log.Printf("ERROR: Closing AI service failed - profile=%s, error=%v", old.Name, err)
+				}
+			}()
+		}
+	}
+	h.profiles = profiles
+}
+
+// profile finds a usable profile by name, or the default one if name is
+// empty. It writes an error and returns false if there isn't one. The caller
+// must hold h.mu.
+func (h *AIHandler) profile(w http.ResponseWriter, name string) (*aiProfile, bool) {
+	if len(h.profiles) == 0 {
+		http.Error(w, "AI service not configured", http.StatusServiceUnavailable)
+		return nil, false
+	}
+
+	profile := h.profiles[0]
+	if name != "" {
+		if profile = h.findProfile(name); profile == nil {
+			http.Error(w, fmt.Sprintf("Unknown AI profile: %s", name), http.StatusBadRequest)
+			return nil, false
+		}
+	}
+	if profile.service == nil {
+		http.Error(w, fmt.Sprintf("AI profile %s needs its API key entered again", profile.Name), http.StatusServiceUnavailable)
+		return nil, false
+	}
+	return profile, true
+}
+
+func (h *AIHandler) findProfile(name string) *aiProfile {
+	for _, profile := range h.profiles {
+		if strings.EqualFold(profile.Name, name) {
+			return profile
+		}
+	}
+	return nil
+}
+
+var (
+	errAINotConfigured  = errors.New("AI service not configured")
+	errUnknownAIProfile = errors.New("unknown AI profile")
+	errNoModel          = errors.New("no model given and the profile has no default model")
+)
+
+// aiAttempt is one profile to ask for a recommendation, and the model to use.
+type aiAttempt struct {
+	profile *aiProfile
+	model   string
+}
+
+// attempts lists the profiles to try in order: the named profile, or the
+// default one, with the requested model or its default model, then every
+// other usable profile with its default model. Profiles whose keys couldn't
+// be decrypted are left out. The caller must hold h.mu, and mark the
+// profiles in use before releasing it.
+func (h *AIHandler) attempts(name, model string) ([]aiAttempt, error) {
+	if len(h.profiles) == 0 {
+		return nil, errAINotConfigured
+	}
+
+	first := h.profiles[0]
+	if name != "" {
+		if first = h.findProfile(name); first == nil {
+			return nil, errUnknownAIProfile
+		}
+	}
+
+	if model == "" {
+		if first.DefaultModel == nil {
+			return nil, errNoModel
+		}
+		model = *first.DefaultModel
+	}
+
+	var attempts []aiAttempt
+	if first.service != nil {
+		attempts = append(attempts, aiAttempt{first, model})
+	}
+	for _, profile := range h.profiles {
+		if profile != first && profile.service != nil && profile.DefaultModel != nil {
+			attempts = append(attempts, aiAttempt{profile, *profile.DefaultModel})
+		}
+	}
+	return attempts, nil
 }
 
 // RecommendCocktailHandler godoc
 // @Summary Recommend a cocktail
-// @Description Get a cocktail recommendation from the AI
+// @Description Get a cocktail recommendation from the AI. Set mode to "use_expiring" to build it around fresh ingredients and opened mixers nearing expiry. The named profile, or the default one, is asked first with the given model or its default model; if it fails or times out, the other profiles are tried in turn with their default models. The X-AI-Profile header names the profile that answered
 // @Tags ai
 // @Accept json
 // @Produce json
-// @Param request body object true "Model selection"
+// @Param request body object true "Model, mode and profile selection"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
 // @Failure 405 {object} map[string]string
@@ -78,33 +270,77 @@ func (h *AIHandler) RecommendCocktailHandler(repo *repository.Repository) http.H
 		}
 
 		var req struct {
+			// Model may be left out if the profile has a default model.
 			Model string `json:"model"`
+			// Mode is empty for a general recommendation or "use_expiring"
+			// to use up items nearing expiry first.
+			Mode services.RecommendMode `json:"mode"`
+			// Profile names the profile to ask first; empty is the default.
+			Profile string `json:"profile"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, "Invalid request body", http.StatusBadRequest)
 			return
 		}
-		if req.Model == "" {
-			http.Error(w, "Missing required field: model", http.StatusBadRequest)
+		if req.Mode != services.RecommendDefault && req.Mode != services.RecommendUseExpiring {
+			http.Error(w, "Invalid mode: use \"use_expiring\" or leave it empty", http.StatusBadRequest)
 			return
 		}
 
+		// The providers are called without holding h.mu, which would hold up
+		// every other AI request for as long as the fallbacks take.
 		h.mu.Lock()
-		defer h.mu.Unlock()
+		attempts, err := h.attempts(req.Profile, req.Model)
+		for _, attempt := range attempts {
+			attempt.profile.inUse.Add(1)
+		}
+		h.mu.Unlock()
+		defer func() {
+			for _, attempt := range attempts {
+				attempt.profile.inUse.Done()
+			}
+		}()
 
-		if h.aiService == nil {
+		switch {
+		case err == errAINotConfigured:
 			http.Error(w, "AI service not configured", http.StatusServiceUnavailable)
 			return
+		case err == errUnknownAIProfile:
+			http.Error(w, fmt.Sprintf("Unknown AI profile: %s", req.Profile), http.StatusBadRequest)
+			return
+		case err == errNoModel:
+			http.Error(w, "Missing required field: model", http.StatusBadRequest)
+			return
+		case len(attempts) == 0:
+			http.Error(w, "No AI profile is ready; enter the API keys again", http.StatusServiceUnavailable)
+			return
 		}
 
-		resp, err := h.aiService.RecommendCocktail(r.Context(), repo, req.Model)
-		if err != nil {
-			http.Error(w, "Failed to recommend cocktail: "+err.Error(), http.StatusInternalServerError)
-			return
+		var failures []string
+		for _, attempt := range attempts {
+			timeout := attempt.profile.timeout()
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			resp, err := services.RecommendCocktail(ctx, attempt.profile.service, repo, attempt.model, req.Mode)
+			if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				err = fmt.Errorf("timed out after %s", timeout)
+			}
+			cancel()
+
+			if err == nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("X-AI-Profile", attempt.profile.Name)
+				json.NewEncoder(w).Encode(resp)
+				return
+			}
+
+			log.Printf("WARNING: Recommendation failed - profile=%s, model=%s, error=%v", attempt.profile.Name, attempt.model, err)
+			failures = append(failures, fmt.Sprintf("%s: %v", attempt.profile.Name, err))
+			if r.Context().Err() != nil {
+				break
+			}
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(resp)
+		http.Error(w, "Failed to recommend cocktail: "+strings.Join(failures, "; "), http.StatusInternalServerError)
 	}
 }
 
@@ -116,7 +352,7 @@ type ConfigureRequest struct {
 
 // Configure godoc
 // @Summary Configure the AI service
-// @Description Configure the OpenAI service with base URL and API key
+// @Description Configure the OpenAI service with base URL and API key. This sets the profile named "default" and makes it the default. The settings are saved, with the key encrypted, if the server has an ENCRYPTION_KEY
 // @Tags ai
 // @Accept json
 // @Produce json
@@ -146,32 +382,55 @@ func (h *AIHandler) Configure(w http.ResponseWriter, r *http.Request) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	// Clean up old service if it exists
-	if h.aiService != nil {
-		if err := h.aiService.Close(); err != nil {
-			http.Error(w, "Failed to clean up existing service", http.StatusInternalServerError)
+	if h.box != nil {
+		encrypted, err := h.box.Seal(req.APIKey)
+		if err != nil {
+			log.Printf("ERROR: Seal failed - error=%v", err)
+			http.Error(w, "Unable to save AI settings. Please try again.", http.StatusInternalServerError)
+			return
+		}
+		profile := &models.AIProfile{Name: defaultProfileName, BaseURL: req.BaseURL, EncryptedAPIKey: encrypted}
+		if _, err := h.repo.SaveDefaultAIProfile(r.Context(), profile); err != nil {
+			log.Printf("ERROR: SaveDefaultAIProfile failed - base_url=%s, error=%v", req.BaseURL, err)
+			http.Error(w, "Unable to save AI settings. Please try again.", http.StatusInternalServerError)
+			return
+		}
+		if err := h.load(r.Context()); err != nil {
+			log.Printf("ERROR: Loading AI profiles failed - error=%v", err)
+			http.Error(w, "Unable to load AI settings. Please try again.", http.StatusInternalServerError)
 			return
 		}
+	} else {
+		h.replaceProfiles([]*aiProfile{{
+			AIProfile: models.AIProfile{Name: defaultProfileName, Provider: string(services.ProviderOpenAI), BaseURL: req.BaseURL, IsDefault: true},
+			apiKey:    req.APIKey,
+			service:   services.NewOpenAIService(req.BaseURL, req.APIKey),
+		}})
 	}
 
-	h.aiService = services.NewOpenAIService(req.BaseURL, req.APIKey)
-
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"status": "configured"})
 }
 
-// GetAIService returns the configured OpenAI service
-func (h *AIHandler) GetAIService() *services.OpenAIService {
-	return h.aiService
+// GetAIService returns the default profile's provider, or nil if there isn't
+// one
+func (h *AIHandler) GetAIService() services.Provider {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.profiles) == 0 {
+		return nil
+	}
+	return h.profiles[0].service
 }
 
 // ServiceStatusHandler godoc
 // @Summary Get AI service status
-// @Description Check if the AI service is initialized
+// @Description Check if the AI service is initialized, and see the default profile's base URL and the last four characters of its API key
 // @Tags ai
 // @Produce json
-// @Success 200 {object} map[string]bool
+// @Success 200 {object} models.AIServiceStatus
 // @Failure 405 {object} map[string]string
 // @Router /ai/service [get]
 func (h *AIHandler) ServiceStatusHandler(w http.ResponseWriter, r *http.Request) {
@@ -183,8 +442,16 @@ func (h *AIHandler) ServiceStatusHandler(w http.ResponseWriter, r *http.Request)
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	status := map[string]bool{
-		"initialized": h.aiService != nil,
+	status := models.AIServiceStatus{
+		Profiles:  len(h.profiles),
+		Persisted: len(h.profiles) > 0 && h.box != nil,
+	}
+	if len(h.profiles) > 0 {
+		profile := h.profiles[0]
+		status.Initialized = profile.service != nil
+		status.Profile = profile.Name
+		status.BaseURL = profile.BaseURL
+		status.APIKey = redact(profile.apiKey)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -193,3 +460,15 @@ func (h *AIHandler) ServiceStatusHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 }
+
+// redact hides all but the last four characters of an API key, and all of a
+// key too short to spare them.
+func redact(key string) string {
+	if key == "" {
+		return ""
+	}
+	if len(key) <= 8 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
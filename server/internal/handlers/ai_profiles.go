@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+	"github.com/nguyenjessev/liquor-locker/internal/services"
+)
+
+// GetProfiles godoc
+// @Summary      List AI profiles
+// @Description  Lists the AI provider profiles in the order they are tried, the default first. API keys are redacted
+// @Tags         ai
+// @Produce      json
+// @Success      200  {array}   models.AIProfileResponse
+// @Router       /api/ai/profiles [get]
+func (h *AIHandler) GetProfiles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	responses := make([]models.AIProfileResponse, 0, len(h.profiles))
+	for _, profile := range h.profiles {
+		responses = append(responses, profile.response())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(responses); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetProfile godoc
+// @Summary      Get an AI profile by ID
+// @Description  Returns a single AI provider profile with its API key redacted
+// @Tags         ai
+// @Produce      json
+// @Param        id   path      int  true  "Profile ID"
+// @Success      200  {object}  models.AIProfileResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /api/ai/profiles/{id} [get]
+func (h *AIHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := aiProfileIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.writeProfile(w, http.StatusOK, id)
+}
+
+// CreateProfile godoc
+// @Summary      Create an AI profile
+// @Description  Saves an AI provider profile with its API key encrypted. Set provider to "ollama" to use Ollama's native API at its root URL, such as http://localhost:11434; otherwise the API must be OpenAI-compatible. An Ollama profile needs no API key. The first profile becomes the default. Needs the server's ENCRYPTION_KEY
+// @Tags         ai
+// @Accept       json
+// @Produce      json
+// @Param        profile  body      models.AIProfileRequest  true  "Profile to add"
+// @Success      201      {object}  models.AIProfileResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Failure      503      {object}  map[string]string
+// @Router       /api/ai/profiles [post]
+func (h *AIHandler) CreateProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	profile, ok := h.decodeProfile(w, r, true)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	created, err := h.repo.CreateAIProfile(r.Context(), profile)
+	if err != nil {
+		log.Printf("ERROR: CreateAIProfile failed - name=%s, error=%v", profile.Name, err)
+		if err == repository.ErrDuplicateAIProfile {
+			http.Error(w, fmt.Sprintf("An AI profile named %s already exists", profile.Name), http.StatusConflict)
+			return
+		}
+		http.Error(w, "Unable to save AI profile. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	if !h.reload(w, r) {
+		return
+	}
+	h.writeProfile(w, http.StatusCreated, created.ID)
+}
+
+// UpdateProfile godoc
+// @Summary      Update an AI profile
+// @Description  Replaces an AI provider profile's settings. Leave api_key empty to keep the current key. Setting is_default makes it the default; a profile stops being the default only when another one takes over
+// @Tags         ai
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                      true  "Profile ID"
+// @Param        profile  body      models.AIProfileRequest  true  "Profile settings"
+// @Success      200      {object}  models.AIProfileResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Failure      503      {object}  map[string]string
+// @Router       /api/ai/profiles/{id} [put]
+func (h *AIHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := aiProfileIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	profile, ok := h.decodeProfile(w, r, false)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, err := h.repo.UpdateAIProfile(r.Context(), id, profile); err != nil {
+		log.Printf("ERROR: UpdateAIProfile failed - id=%d, name=%s, error=%v", id, profile.Name, err)
+		switch err {
+		case repository.ErrAIProfileNotFound:
+			http.Error(w, fmt.Sprintf("AI profile with ID %d not found", id), http.StatusNotFound)
+		case repository.ErrDuplicateAIProfile:
+			http.Error(w, fmt.Sprintf("An AI profile named %s already exists", profile.Name), http.StatusConflict)
+		default:
+			http.Error(w, "Unable to update AI profile. Please try again.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if !h.reload(w, r) {
+		return
+	}
+	h.writeProfile(w, http.StatusOK, id)
+}
+
+// DeleteProfile godoc
+// @Summary      Delete an AI profile
+// @Description  Deletes an AI provider profile. If it was the default, the oldest remaining profile becomes the default
+// @Tags         ai
+// @Param        id  path  int  true  "Profile ID"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Failure      503  {object}  map[string]string
+// @Router       /api/ai/profiles/{id} [delete]
+func (h *AIHandler) DeleteProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := aiProfileIDFromPath(w, r)
+	if !ok {
+		return
+	}
+	if !h.requireBox(w) {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := h.repo.DeleteAIProfile(r.Context(), id); err != nil {
+		log.Printf("ERROR: DeleteAIProfile failed - id=%d, error=%v", id, err)
+		if err == repository.ErrAIProfileNotFound {
+			http.Error(w, fmt.Sprintf("AI profile with ID %d not found", id), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Unable to delete AI profile. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	if !h.reload(w, r) {
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requireBox writes a 503 and returns false if profiles can't be saved
+// because the server has no encryption key.
+func (h *AIHandler) requireBox(w http.ResponseWriter) bool {
+	if h.box == nil {
+		http.Error(w, "Set ENCRYPTION_KEY on the server to save AI profiles", http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+// decodeProfile reads and checks a profile request, encrypting its API key.
+// The key is required when creating a profile. It writes an error and returns
+// false if the request is no good.
+func (h *AIHandler) decodeProfile(w http.ResponseWriter, r *http.Request, create bool) (*models.AIProfile, bool) {
+	var req models.AIProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return nil, false
+	}
+
+	provider, err := validateAIProfile(&req, create)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	if !h.requireBox(w) {
+		return nil, false
+	}
+
+	profile := &models.AIProfile{
+		Name:           strings.TrimSpace(req.Name),
+		Provider:       string(provider),
+		BaseURL:        strings.TrimSpace(req.BaseURL),
+		DefaultModel:   req.DefaultModel,
+		TimeoutSeconds: req.TimeoutSeconds,
+		IsDefault:      req.IsDefault,
+	}
+	if profile.DefaultModel != nil && strings.TrimSpace(*profile.DefaultModel) == "" {
+		profile.DefaultModel = nil
+	}
+
+	// A local Ollama server needs no key, but one is saved anyway so there
+	// is something to decrypt.
+	if req.APIKey != "" || create {
+		encrypted, err := h.box.Seal(req.APIKey)
+		if err != nil {
+			log.Printf("ERROR: Seal failed - error=%v", err)
+			http.Error(w, "Unable to save AI profile. Please try again.", http.StatusInternalServerError)
+			return nil, false
+		}
+		profile.EncryptedAPIKey = encrypted
+	}
+
+	return profile, true
+}
+
+func validateAIProfile(req *models.AIProfileRequest, create bool) (services.ProviderKind, error) {
+	provider, err := services.ParseProviderKind(req.Provider)
+	switch {
+	case strings.TrimSpace(req.Name) == "":
+		return "", errors.New("Profile name is required")
+	case err != nil:
+		return "", errors.New("Provider must be openai or ollama")
+	case strings.TrimSpace(req.BaseURL) == "":
+		return "", errors.New("Base URL is required")
+	case create && req.APIKey == "" && provider != services.ProviderOllama:
+		return "", errors.New("API key is required")
+	case req.TimeoutSeconds != nil && *req.TimeoutSeconds <= 0:
+		return "", errors.New("timeout_seconds must be greater than zero")
+	}
+	return provider, nil
+}
+
+// reload picks up the saved profiles after a change, writing a 500 and
+// returning false if it can't. The caller must hold h.mu.
+func (h *AIHandler) reload(w http.ResponseWriter, r *http.Request) bool {
+	if err := h.load(r.Context()); err != nil {
+		log.Printf("ERROR: Loading AI profiles failed - error=%v", err)
+		http.Error(w, "AI profile saved, but it couldn't be loaded. Please try again.", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// writeProfile writes the loaded profile with the given ID, or a 404 if there
+// isn't one. The caller must hold h.mu.
+func (h *AIHandler) writeProfile(w http.ResponseWriter, status int, id int) {
+	for _, profile := range h.profiles {
+		if profile.ID != id {
+			continue
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(profile.response()); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		}
+		return
+	}
+	http.Error(w, fmt.Sprintf("AI profile with ID %d not found", id), http.StatusNotFound)
+}
+
+func aiProfileIDFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/ai/profiles/")
+	if path == "" {
+		http.Error(w, "AI profile ID is required", http.StatusBadRequest)
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(path)
+	if err != nil {
+		http.Error(w, "Invalid AI profile ID", http.StatusBadRequest)
+		return 0, false
+	}
+
+	return id, true
+}
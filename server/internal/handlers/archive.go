@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+)
+
+// maxRestoreBytes caps the size of an uploaded archive.
+const maxRestoreBytes = 100 << 20
+
+type ArchiveHandler struct {
+	repo *repository.Repository
+}
+
+func NewArchiveHandler(repo *repository.Repository) *ArchiveHandler {
+	return &ArchiveHandler{repo: repo}
+}
+
+// Export godoc
+// @Summary      Export the database
+// @Description  Downloads every table as a versioned JSON archive that POST /api/restore accepts, on this or another server at the same schema version
+// @Tags         archive
+// @Produce      json
+// @Success      200  {object}  models.Archive
+// @Failure      500  {object}  map[string]string
+// @Router       /api/export [get]
+func (h *ArchiveHandler) Export(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	archive, err := h.repo.Export(r.Context())
+	if err != nil {
+		log.Printf("ERROR: Export failed - error=%v", err)
+		http.Error(w, "Unable to export the database. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("liquor-locker-%s.json", archive.ExportedAt.Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	if err := json.NewEncoder(w).Encode(archive); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Restore godoc
+// @Summary      Restore the database from an archive
+// @Description  Writes an archive from GET /api/export back in one transaction. The archive must be at the database's schema version. replace empties every table first and keeps the archived IDs; merge keeps existing rows, adds the archived ones under new IDs with their references rewritten, and skips those already in the database
+// @Tags         archive
+// @Accept       json
+// @Produce      json
+// @Param        mode     query     string          true  "replace or merge"
+// @Param        archive  body      models.Archive  true  "Archive from GET /api/export"
+// @Success      200      {object}  models.RestoreReport
+// @Failure      400      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/restore [post]
+func (h *ArchiveHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	mode := repository.RestoreMode(r.URL.Query().Get("mode"))
+	if mode != repository.RestoreReplace && mode != repository.RestoreMerge {
+		http.Error(w, "Invalid mode, use replace or merge", http.StatusBadRequest)
+		return
+	}
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRestoreBytes))
+	dec.UseNumber()
+	var archive models.Archive
+	if err := dec.Decode(&archive); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.repo.Restore(r.Context(), &archive, mode)
+	if err != nil {
+		log.Printf("ERROR: Restore failed - mode=%s, error=%v", mode, err)
+		switch {
+		case errors.Is(err, repository.ErrSchemaMismatch), errors.Is(err, repository.ErrDirtySchema):
+			http.Error(w, fmt.Sprintf("Unable to restore: %v. Nothing was changed.", err), http.StatusConflict)
+		case errors.Is(err, repository.ErrInvalidArchive),
+			errors.Is(err, repository.ErrArchiveVersion),
+			errors.Is(err, repository.ErrUnknownTable),
+			errors.Is(err, repository.ErrUnknownColumn),
+			errors.Is(err, repository.ErrInvalidArchiveValue):
+			http.Error(w, fmt.Sprintf("Invalid archive: %v. Nothing was changed.", err), http.StatusBadRequest)
+		default:
+			http.Error(w, "Unable to restore the database. Nothing was changed; please try again.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
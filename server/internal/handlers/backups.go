@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/nguyenjessev/liquor-locker/internal/backup"
+)
+
+type BackupHandler struct {
+	backups *backup.Manager
+}
+
+func NewBackupHandler(backups *backup.Manager) *BackupHandler {
+	return &BackupHandler{backups: backups}
+}
+
+// Backups godoc
+// @Summary      List or take database backups
+// @Description  GET lists the backups in the backup directory, newest first. POST takes a backup now and prunes old ones by the retention policy
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   models.Backup
+// @Success      201  {object}  models.Backup
+// @Failure      500  {object}  map[string]string
+// @Router       /api/admin/backups [get]
+// @Router       /api/admin/backups [post]
+func (h *BackupHandler) Backups(w http.ResponseWriter, r *http.Request) {
+	var status int
+	var response any
+
+	switch r.Method {
+	case http.MethodGet:
+		backups, err := h.backups.List()
+		if err != nil {
+			log.Printf("ERROR: List backups failed - error=%v", err)
+			http.Error(w, "Unable to list backups. Please try again.", http.StatusInternalServerError)
+			return
+		}
+		status, response = http.StatusOK, backups
+	case http.MethodPost:
+		b, err := h.backups.Run(r.Context())
+		if err != nil {
+			log.Printf("ERROR: Backup failed - error=%v", err)
+			http.Error(w, "Unable to back up the database. Please try again.", http.StatusInternalServerError)
+			return
+		}
+		status, response = http.StatusCreated, b
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+)
+
+type BittersHandler struct {
+	items *inventoryHandler[models.Bitters, models.CreateBittersRequest, models.UpdateBittersRequest, models.BittersResponse]
+}
+
+func NewBittersHandler(repo *repository.Repository) *BittersHandler {
+	return &BittersHandler{items: newInventoryHandler(repo, repo.Bitters(), bittersKind)}
+}
+
+var bittersKind = inventoryKind[models.Bitters, models.CreateBittersRequest, models.UpdateBittersRequest, models.BittersResponse]{
+	name:     "bitters",
+	noun:     "bitters",
+	plural:   "bitters",
+	path:     "/api/bitters/",
+	notFound: repository.ErrBittersNotFound,
+	errNil:   repository.ErrNilBitters,
+	fromCreate: func(req *models.CreateBittersRequest) *models.Bitters {
+		return &models.Bitters{
+			Name:            req.Name,
+			Brand:           req.Brand,
+			Opened:          req.Opened,
+			OpenDate:        req.OpenDate,
+			PurchaseDate:    req.PurchaseDate,
+			Price:           req.Price,
+			DashesRemaining: req.DashesRemaining,
+		}
+	},
+	fromUpdate: func(req *models.UpdateBittersRequest) *models.Bitters {
+		return &models.Bitters{
+			Name:            req.Name,
+			Brand:           req.Brand,
+			Opened:          req.Opened,
+			OpenDate:        req.OpenDate,
+			PurchaseDate:    req.PurchaseDate,
+			Price:           req.Price,
+			DashesRemaining: req.DashesRemaining,
+		}
+	},
+	response: func(bitters *models.Bitters) models.BittersResponse {
+		return models.BittersResponse{
+			ID:              bitters.ID,
+			Name:            bitters.Name,
+			Brand:           bitters.Brand,
+			Opened:          bitters.Opened,
+			OpenDate:        bitters.OpenDate,
+			PurchaseDate:    bitters.PurchaseDate,
+			Price:           bitters.Price,
+			DashesRemaining: bitters.DashesRemaining,
+		}
+	},
+}
+
+// CreateBitters godoc
+// @Summary      Create bitters
+// @Description  Adds bitters to the collection
+// @Tags         bitters
+// @Accept       json
+// @Produce      json
+// @Param        bitters body models.CreateBittersRequest true "Bitters to create"
+// @Success      201 {object} models.BittersResponse
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /bitters [post]
+func (h *BittersHandler) CreateBitters(w http.ResponseWriter, r *http.Request) {
+	h.items.create(w, r)
+}
+
+// GetBitters godoc
+// @Summary      Get bitters by ID
+// @Description  Returns the bitters with the given ID
+// @Tags         bitters
+// @Produce      json
+// @Param        id path int true "Bitters ID"
+// @Success      200 {object} models.BittersResponse
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /bitters/{id} [get]
+func (h *BittersHandler) GetBitters(w http.ResponseWriter, r *http.Request) {
+	h.items.get(w, r)
+}
+
+// DeleteBitters godoc
+// @Summary      Delete bitters
+// @Description  Deletes the bitters with the given ID
+// @Tags         bitters
+// @Param        id path int true "Bitters ID"
+// @Success      204 {object} nil
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /bitters/{id} [delete]
+func (h *BittersHandler) DeleteBitters(w http.ResponseWriter, r *http.Request) {
+	h.items.delete(w, r)
+}
+
+// UpdateBitters godoc
+// @Summary      Update bitters
+// @Description  Replaces the bitters' fields. A brand or dash count left out is kept.
+// @Tags         bitters
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Bitters ID"
+// @Param        bitters body models.UpdateBittersRequest true "Updated bitters"
+// @Success      200 {object} models.BittersResponse
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /bitters/{id} [put]
+func (h *BittersHandler) UpdateBitters(w http.ResponseWriter, r *http.Request) {
+	h.items.update(w, r)
+}
+
+// GetAllBitters godoc
+// @Summary      Get all bitters
+// @Description  Returns a list of bitters, newest first unless sorted, optionally filtered and paged. When there are more pages, the X-Next-Cursor and Link headers point to the next one.
+// @Tags         bitters
+// @Produce      json
+// @Param        opened query boolean false "Only opened (true) or sealed (false) items"
+// @Param        name query string false "Name contains, ignoring case"
+// @Param        purchased_after query string false "Purchased after this date (YYYY-MM-DD or RFC 3339)"
+// @Param        min_price query number false "Minimum price"
+// @Param        max_price query number false "Maximum price"
+// @Param        sort query string false "name, price or open_date; prefix with - for descending"
+// @Param        limit query int false "Page size, 1 to 500"
+// @Param        cursor query string false "Cursor from the previous page's X-Next-Cursor header"
+// @Success      200 {array} models.BittersResponse
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /bitters [get]
+func (h *BittersHandler) GetAllBitters(w http.ResponseWriter, r *http.Request) {
+	h.items.list(w, r)
+}
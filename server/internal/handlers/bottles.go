@@ -1,23 +1,127 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"net/http"
+	"net/url"
 	"strconv"
-	"strings"
 
 	"github.com/nguyenjessev/liquor-locker/internal/models"
 	"github.com/nguyenjessev/liquor-locker/internal/repository"
 )
 
 type BottleHandler struct {
-	repo *repository.Repository
+	items *inventoryHandler[models.Bottle, models.CreateBottleRequest, models.UpdateBottleRequest, models.BottleResponse]
 }
 
 func NewBottleHandler(repo *repository.Repository) *BottleHandler {
-	return &BottleHandler{repo: repo}
+	return &BottleHandler{items: newInventoryHandler(repo, repo.Bottles(), bottleKind)}
+}
+
+var bottleKind = inventoryKind[models.Bottle, models.CreateBottleRequest, models.UpdateBottleRequest, models.BottleResponse]{
+	name:     "bottle",
+	noun:     "bottle",
+	plural:   "bottles",
+	path:     "/api/bottles/",
+	notFound: repository.ErrBottleNotFound,
+	errNil:   repository.ErrNilBottle,
+	fromCreate: func(req *models.CreateBottleRequest) *models.Bottle {
+		return &models.Bottle{
+			Name:         req.Name,
+			Opened:       req.Opened,
+			OpenDate:     req.OpenDate,
+			PurchaseDate: req.PurchaseDate,
+			Price:        req.Price,
+			SizeML:       req.SizeML,
+			RemainingML:  req.RemainingML,
+			Category:     req.Category,
+			Subcategory:  req.Subcategory,
+			Brand:        req.Brand,
+			ABV:          req.ABV,
+			Country:      req.Country,
+			Region:       req.Region,
+			AgeStatement: req.AgeStatement,
+		}
+	},
+	fromUpdate: func(req *models.UpdateBottleRequest) *models.Bottle {
+		return &models.Bottle{
+			Name:         req.Name,
+			Opened:       req.Opened,
+			OpenDate:     req.OpenDate,
+			PurchaseDate: req.PurchaseDate,
+			Price:        req.Price,
+			SizeML:       req.SizeML,
+			RemainingML:  req.RemainingML,
+			Category:     req.Category,
+			Subcategory:  req.Subcategory,
+			Brand:        req.Brand,
+			ABV:          req.ABV,
+			Country:      req.Country,
+			Region:       req.Region,
+			AgeStatement: req.AgeStatement,
+		}
+	},
+	response: func(bottle *models.Bottle) models.BottleResponse {
+		return models.BottleResponse{
+			ID:           bottle.ID,
+			Name:         bottle.Name,
+			Opened:       bottle.Opened,
+			OpenDate:     bottle.OpenDate,
+			PurchaseDate: bottle.PurchaseDate,
+			Price:        bottle.Price,
+			IngredientID: bottle.IngredientID,
+			SizeML:       bottle.SizeML,
+			RemainingML:  bottle.RemainingML,
+			Finished:     bottle.Finished,
+			FinishedAt:   bottle.FinishedAt,
+			Category:     bottle.Category,
+			Subcategory:  bottle.Subcategory,
+			Brand:        bottle.Brand,
+			ABV:          bottle.ABV,
+			Country:      bottle.Country,
+			Region:       bottle.Region,
+			AgeStatement: bottle.AgeStatement,
+			Proof:        proof(bottle.ABV),
+		}
+	},
+	validate: func(bottle *models.Bottle) error {
+		if bottle.ABV != nil && (*bottle.ABV < 0 || *bottle.ABV > 100) {
+			return errors.New("ABV must be between 0 and 100")
+		}
+		return nil
+	},
+	filter: bottleFilter,
+}
+
+// bottleFilter adds the bottle detail filters to a list.
+func bottleFilter(query url.Values, opts repository.ListOptions) (repository.Filter, error) {
+	filter := repository.BottleFilter{
+		ListOptions: opts,
+		Category:    query.Get("category"),
+		Subcategory: query.Get("subcategory"),
+		Brand:       query.Get("brand"),
+		Country:     query.Get("country"),
+		Region:      query.Get("region"),
+	}
+	for _, bound := range []struct {
+		param string
+		value **float64
+	}{
+		{"min_abv", &filter.MinABV},
+		{"max_abv", &filter.MaxABV},
+	} {
+		raw := query.Get(bound.param)
+		if raw == "" {
+			continue
+		}
+		abv, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s", bound.param)
+		}
+		*bound.value = &abv
+	}
+	return filter, nil
 }
 
 // CreateBottle godoc
@@ -32,51 +136,7 @@ func NewBottleHandler(repo *repository.Repository) *BottleHandler {
 // @Failure      500     {object}  map[string]string
 // @Router       /api/bottles [post]
 func (h *BottleHandler) CreateBottle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var req models.CreateBottleRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
-		return
-	}
-
-	bottle := &models.Bottle{
-		Name:         req.Name,
-		Opened:       req.Opened,
-		OpenDate:     req.OpenDate,
-		PurchaseDate: req.PurchaseDate,
-		Price:        req.Price,
-	}
-
-	createdBottle, err := h.repo.CreateBottle(r.Context(), bottle)
-	if err != nil {
-		log.Printf("ERROR: CreateBottle failed - bottle=%+v, error=%v", bottle, err)
-		if err == repository.ErrNilBottle {
-			http.Error(w, "Invalid bottle data", http.StatusBadRequest)
-			return
-		}
-		http.Error(w, "Unable to save bottle. Please try again.", http.StatusInternalServerError)
-		return
-	}
-
-	response := models.BottleResponse{
-		ID:           createdBottle.ID,
-		Name:         createdBottle.Name,
-		Opened:       createdBottle.Opened,
-		OpenDate:     createdBottle.OpenDate,
-		PurchaseDate: createdBottle.PurchaseDate,
-		Price:        createdBottle.Price,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
+	h.items.create(w, r)
 }
 
 // GetBottle godoc
@@ -90,49 +150,7 @@ func (h *BottleHandler) CreateBottle(w http.ResponseWriter, r *http.Request) {
 // @Failure      404  {object}  map[string]string
 // @Router       /api/bottles/{id} [get]
 func (h *BottleHandler) GetBottle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	// Extract ID from URL path
-	path := strings.TrimPrefix(r.URL.Path, "/api/bottles/")
-	if path == "" {
-		http.Error(w, "Bottle ID is required", http.StatusBadRequest)
-		return
-	}
-
-	id, err := strconv.Atoi(path)
-	if err != nil {
-		http.Error(w, "Invalid bottle ID", http.StatusBadRequest)
-		return
-	}
-
-	bottle, err := h.repo.GetBottleByID(r.Context(), id)
-	if err != nil {
-		log.Printf("ERROR: GetBottleByID failed - id=%d, error=%v", id, err)
-		if err == repository.ErrBottleNotFound {
-			http.Error(w, fmt.Sprintf("Bottle with ID %d not found", id), http.StatusNotFound)
-			return
-		}
-		http.Error(w, "Unable to retrieve bottle. Please try again.", http.StatusInternalServerError)
-		return
-	}
-
-	response := models.BottleResponse{
-		ID:           bottle.ID,
-		Name:         bottle.Name,
-		Opened:       bottle.Opened,
-		OpenDate:     bottle.OpenDate,
-		PurchaseDate: bottle.PurchaseDate,
-		Price:        bottle.Price,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
+	h.items.get(w, r)
 }
 
 // DeleteBottle godoc
@@ -145,36 +163,7 @@ func (h *BottleHandler) GetBottle(w http.ResponseWriter, r *http.Request) {
 // @Failure      404  {object}  map[string]string
 // @Router       /api/bottles/{id} [delete]
 func (h *BottleHandler) DeleteBottle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	// Extract ID from URL path
-	path := strings.TrimPrefix(r.URL.Path, "/api/bottles/")
-	if path == "" {
-		http.Error(w, "Bottle ID is required", http.StatusBadRequest)
-		return
-	}
-
-	id, err := strconv.Atoi(path)
-	if err != nil {
-		http.Error(w, "Invalid bottle ID", http.StatusBadRequest)
-		return
-	}
-
-	err = h.repo.DeleteBottleByID(r.Context(), id)
-	if err != nil {
-		log.Printf("ERROR: DeleteBottleByID failed - id=%d, error=%v", id, err)
-		if err == repository.ErrBottleNotFound {
-			http.Error(w, fmt.Sprintf("Bottle with ID %d not found", id), http.StatusNotFound)
-			return
-		}
-		http.Error(w, "Unable to delete bottle. Please try again.", http.StatusInternalServerError)
-		return
-	}
-
-	w.WriteHeader(http.StatusNoContent)
+	h.items.delete(w, r)
 }
 
 // UpdateBottle godoc
@@ -190,105 +179,73 @@ func (h *BottleHandler) DeleteBottle(w http.ResponseWriter, r *http.Request) {
 // @Failure      404     {object}  map[string]string
 // @Router       /api/bottles/{id} [put]
 func (h *BottleHandler) UpdateBottle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPut {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	// Extract ID from URL path
-	path := strings.TrimPrefix(r.URL.Path, "/api/bottles/")
-	if path == "" {
-		http.Error(w, "Bottle ID is required", http.StatusBadRequest)
-		return
-	}
-
-	id, err := strconv.Atoi(path)
-	if err != nil {
-		http.Error(w, "Invalid bottle ID", http.StatusBadRequest)
-		return
-	}
-
-	var req models.UpdateBottleRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
-		return
-	}
-
-	updates := &models.Bottle{
-		Name:         req.Name,
-		Opened:       req.Opened,
-		OpenDate:     req.OpenDate,
-		PurchaseDate: req.PurchaseDate,
-		Price:        req.Price,
-	}
-
-	updatedBottle, err := h.repo.UpdateBottle(r.Context(), id, updates)
-	if err != nil {
-		log.Printf("ERROR: UpdateBottle failed - id=%d, updates=%+v, error=%v", id, updates, err)
-		if err == repository.ErrBottleNotFound {
-			http.Error(w, fmt.Sprintf("Bottle with ID %d not found", id), http.StatusNotFound)
-			return
-		}
-		if err == repository.ErrNilBottle {
-			http.Error(w, "Invalid bottle data", http.StatusBadRequest)
-			return
-		}
-		http.Error(w, "Unable to update bottle. Please try again.", http.StatusInternalServerError)
-		return
-	}
-
-	response := models.BottleResponse{
-		ID:           updatedBottle.ID,
-		Name:         updatedBottle.Name,
-		Opened:       updatedBottle.Opened,
-		OpenDate:     updatedBottle.OpenDate,
-		PurchaseDate: updatedBottle.PurchaseDate,
-		Price:        updatedBottle.Price,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
+	h.items.update(w, r)
 }
 
 // GetAllBottles godoc
 // @Summary      Get all bottles
-// @Description  Returns a list of all bottles
+// @Description  Returns a list of bottles, newest first unless sorted, optionally filtered by their details and paged. Text filters ignore case. When there are more pages, the X-Next-Cursor and Link headers point to the next one.
 // @Tags         bottles
 // @Produce      json
+// @Param        category         query     string   false  "Category, e.g. whiskey"
+// @Param        subcategory      query     string   false  "Subcategory, e.g. bourbon"
+// @Param        brand            query     string   false  "Brand"
+// @Param        country          query     string   false  "Country of origin"
+// @Param        region           query     string   false  "Region"
+// @Param        min_abv          query     number   false  "Minimum ABV in percent"
+// @Param        max_abv          query     number   false  "Maximum ABV in percent"
+// @Param        opened           query     boolean  false  "Only opened (true) or sealed (false) items"
+// @Param        name             query     string   false  "Name contains, ignoring case"
+// @Param        purchased_after  query     string   false  "Purchased after this date (YYYY-MM-DD or RFC 3339)"
+// @Param        min_price        query     number   false  "Minimum price"
+// @Param        max_price        query     number   false  "Maximum price"
+// @Param        sort             query     string   false  "name, price or open_date; prefix with - for descending"
+// @Param        limit            query     int      false  "Page size, 1 to 500"
+// @Param        cursor           query     string   false  "Cursor from the previous page's X-Next-Cursor header"
 // @Success      200  {array}   models.BottleResponse
+// @Failure      400  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /api/bottles [get]
 func (h *BottleHandler) GetAllBottles(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
+	h.items.list(w, r)
+}
 
-	bottles, err := h.repo.GetAllBottles(r.Context())
-	if err != nil {
-		log.Printf("ERROR: GetAllBottles failed - error=%v", err)
-		http.Error(w, "Unable to load bottles. Please refresh the page.", http.StatusInternalServerError)
-		return
-	}
+// PourBottle godoc
+// @Summary      Pour from a bottle
+// @Description  Decrements the bottle's remaining volume, records the pour, and marks the bottle finished when it reaches zero. A pour larger than what is left only takes what is left; a finished bottle can't be poured from
+// @Tags         bottles
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                 true  "Bottle ID"
+// @Param        pour  body      models.PourRequest  true  "Amount poured in ml"
+// @Success      200   {object}  models.BottleResponse
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Router       /api/bottles/{id}/pour [post]
+func (h *BottleHandler) PourBottle(w http.ResponseWriter, r *http.Request) {
+	h.items.pour(w, r)
+}
 
-	responses := make([]models.BottleResponse, 0)
-	for _, bottle := range bottles {
-		responses = append(responses, models.BottleResponse{
-			ID:           bottle.ID,
-			Name:         bottle.Name,
-			Opened:       bottle.Opened,
-			OpenDate:     bottle.OpenDate,
-			PurchaseDate: bottle.PurchaseDate,
-			Price:        bottle.Price,
-		})
-	}
+// GetBottlePours godoc
+// @Summary      Get a bottle's pour history
+// @Description  Returns every recorded pour from the bottle, newest first
+// @Tags         bottles
+// @Produce      json
+// @Param        id   path      int  true  "Bottle ID"
+// @Success      200  {array}   models.Pour
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/bottles/{id}/pours [get]
+func (h *BottleHandler) GetBottlePours(w http.ResponseWriter, r *http.Request) {
+	h.items.pours(w, r)
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(responses); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
+// proof converts an ABV percentage to US proof.
+func proof(abv *float64) *float64 {
+	if abv == nil {
+		return nil
 	}
+	p := *abv * 2
+	return &p
 }
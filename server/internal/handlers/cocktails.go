@@ -0,0 +1,666 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/nguyenjessev/liquor-locker/internal/batch"
+	"github.com/nguyenjessev/liquor-locker/internal/catalog"
+	"github.com/nguyenjessev/liquor-locker/internal/matcher"
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+	"github.com/nguyenjessev/liquor-locker/internal/services"
+)
+
+type CocktailHandler struct {
+	repo *repository.Repository
+}
+
+func NewCocktailHandler(repo *repository.Repository) *CocktailHandler {
+	return &CocktailHandler{repo: repo}
+}
+
+// CreateCocktail godoc
+// @Summary      Create a new cocktail recipe
+// @Description  Saves a cocktail recipe with its ingredients and ordered steps
+// @Tags         cocktails
+// @Accept       json
+// @Produce      json
+// @Param        cocktail  body      models.CreateCocktailRequest  true  "Cocktail to save"
+// @Success      201       {object}  models.SavedCocktailResponse
+// @Failure      400       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /api/cocktails [post]
+func (h *CocktailHandler) CreateCocktail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req models.CreateCocktailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		http.Error(w, "Cocktail name is required", http.StatusBadRequest)
+		return
+	}
+
+	cocktail := &models.Cocktail{
+		Name:        req.Name,
+		Description: req.Description,
+		Ingredients: ingredientsFromRequest(req.Ingredients),
+		Steps:       stepsFromRequest(req.Steps),
+	}
+
+	createdCocktail, err := h.repo.CreateCocktail(r.Context(), cocktail)
+	if err != nil {
+		log.Printf("ERROR: CreateCocktail failed - cocktail=%+v, error=%v", cocktail, err)
+		if err == repository.ErrNilCocktail {
+			http.Error(w, "Invalid cocktail data", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Unable to save cocktail. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(cocktailResponse(createdCocktail)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetCocktail godoc
+// @Summary      Get a cocktail recipe by ID
+// @Description  Returns a single saved cocktail recipe by its ID
+// @Tags         cocktails
+// @Produce      json
+// @Param        id   path      int  true  "Cocktail ID"
+// @Success      200  {object}  models.SavedCocktailResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /api/cocktails/{id} [get]
+func (h *CocktailHandler) GetCocktail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := cocktailIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	cocktail, err := h.repo.GetCocktailByID(r.Context(), id)
+	if err != nil {
+		log.Printf("ERROR: GetCocktailByID failed - id=%d, error=%v", id, err)
+		if err == repository.ErrCocktailNotFound {
+			http.Error(w, fmt.Sprintf("Cocktail with ID %d not found", id), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Unable to retrieve cocktail. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(cocktailResponse(cocktail)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// DeleteCocktail godoc
+// @Summary      Delete a cocktail recipe by ID
+// @Description  Deletes a saved cocktail recipe along with its ingredients and steps
+// @Tags         cocktails
+// @Param        id   path      int  true  "Cocktail ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /api/cocktails/{id} [delete]
+func (h *CocktailHandler) DeleteCocktail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := cocktailIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.repo.DeleteCocktailByID(r.Context(), id)
+	if err != nil {
+		log.Printf("ERROR: DeleteCocktailByID failed - id=%d, error=%v", id, err)
+		if err == repository.ErrCocktailNotFound {
+			http.Error(w, fmt.Sprintf("Cocktail with ID %d not found", id), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Unable to delete cocktail. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateCocktail godoc
+// @Summary      Update a cocktail recipe by ID
+// @Description  Replaces a saved cocktail recipe, including its ingredients and steps
+// @Tags         cocktails
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                           true  "Cocktail ID"
+// @Param        cocktail  body      models.UpdateCocktailRequest  true  "Cocktail update info"
+// @Success      200       {object}  models.SavedCocktailResponse
+// @Failure      400       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Router       /api/cocktails/{id} [put]
+func (h *CocktailHandler) UpdateCocktail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := cocktailIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateCocktailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		http.Error(w, "Cocktail name is required", http.StatusBadRequest)
+		return
+	}
+
+	updates := &models.Cocktail{
+		Name:        req.Name,
+		Description: req.Description,
+		Ingredients: ingredientsFromRequest(req.Ingredients),
+		Steps:       stepsFromRequest(req.Steps),
+	}
+
+	updatedCocktail, err := h.repo.UpdateCocktail(r.Context(), id, updates)
+	if err != nil {
+		log.Printf("ERROR: UpdateCocktail failed - id=%d, updates=%+v, error=%v", id, updates, err)
+		if err == repository.ErrCocktailNotFound {
+			http.Error(w, fmt.Sprintf("Cocktail with ID %d not found", id), http.StatusNotFound)
+			return
+		}
+		if err == repository.ErrNilCocktail {
+			http.Error(w, "Invalid cocktail data", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Unable to update cocktail. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(cocktailResponse(updatedCocktail)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetAllCocktails godoc
+// @Summary      Get all cocktail recipes
+// @Description  Returns every saved cocktail recipe with ingredients and steps
+// @Tags         cocktails
+// @Produce      json
+// @Success      200  {array}   models.SavedCocktailResponse
+// @Failure      500  {object}  map[string]string
+// @Router       /api/cocktails [get]
+func (h *CocktailHandler) GetAllCocktails(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cocktails, err := h.repo.GetAllCocktails(r.Context())
+	if err != nil {
+		log.Printf("ERROR: GetAllCocktails failed - error=%v", err)
+		http.Error(w, "Unable to load cocktails. Please refresh the page.", http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]models.SavedCocktailResponse, 0)
+	for _, cocktail := range cocktails {
+		responses = append(responses, cocktailResponse(cocktail))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(responses); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// SaveRecommendation godoc
+// @Summary      Save an AI recommendation as a recipe
+// @Description  Persists one cocktail returned by the recommendation endpoint, recording the model and prompt it came from
+// @Tags         cocktails
+// @Accept       json
+// @Produce      json
+// @Param        recommendation  body      models.SaveRecommendationRequest  true  "Recommended cocktail to save"
+// @Success      201             {object}  models.SavedCocktailResponse
+// @Failure      400             {object}  map[string]string
+// @Failure      500             {object}  map[string]string
+// @Router       /api/cocktails/recommendation/save [post]
+func (h *CocktailHandler) SaveRecommendation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req models.SaveRecommendationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.Cocktail.Name) == "" {
+		http.Error(w, "Cocktail name is required", http.StatusBadRequest)
+		return
+	}
+	if req.Model == "" {
+		http.Error(w, "Missing required field: model", http.StatusBadRequest)
+		return
+	}
+
+	prompt := req.Prompt
+	if prompt == "" {
+		prompt = services.RecommendMode(req.Mode).Prompt()
+	}
+
+	cocktail := &models.Cocktail{
+		Name:         req.Cocktail.Name,
+		Description:  req.Cocktail.Description,
+		SourceModel:  &req.Model,
+		SourcePrompt: &prompt,
+	}
+	for _, ingredient := range req.Cocktail.Ingredients {
+		cocktail.Ingredients = append(cocktail.Ingredients, models.Ingredient{
+			Name:     ingredient.Name,
+			Quantity: ingredient.Quantity,
+		})
+	}
+	for _, step := range req.Cocktail.Steps {
+		cocktail.Steps = append(cocktail.Steps, models.Step{
+			Order: step.Order,
+			Text:  step.Text,
+		})
+	}
+
+	createdCocktail, err := h.repo.CreateCocktail(r.Context(), cocktail)
+	if err != nil {
+		log.Printf("ERROR: SaveRecommendation failed - cocktail=%+v, error=%v", cocktail, err)
+		http.Error(w, "Unable to save cocktail. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(cocktailResponse(createdCocktail)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetMakeable godoc
+// @Summary      List cocktails makeable from the inventory
+// @Description  Compares saved recipes against bottles, mixers, fresh ingredients, bitters, syrups and garnishes that are in stock, returning recipes that can be made now and recipes missing exactly one ingredient
+// @Tags         cocktails
+// @Produce      json
+// @Success      200  {object}  models.MakeableResponse
+// @Failure      500  {object}  map[string]string
+// @Router       /api/cocktails/makeable [get]
+func (h *CocktailHandler) GetMakeable(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cocktails, err := h.repo.GetAllCocktails(r.Context())
+	if err != nil {
+		log.Printf("ERROR: GetAllCocktails failed - error=%v", err)
+		http.Error(w, "Unable to load cocktails. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	m, err := inventoryMatcher(r, h.repo)
+	if err != nil {
+		log.Printf("ERROR: loading inventory failed - error=%v", err)
+		http.Error(w, "Unable to load inventory. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	result := m.Match(cocktails)
+
+	response := models.MakeableResponse{
+		Makeable:   make([]models.SavedCocktailResponse, 0, len(result.Makeable)),
+		MissingOne: make([]models.MissingOneResponse, 0, len(result.MissingOne)),
+	}
+	for _, cocktail := range result.Makeable {
+		response.Makeable = append(response.Makeable, cocktailResponse(cocktail))
+	}
+	for _, near := range result.MissingOne {
+		response.MissingOne = append(response.MissingOne, models.MissingOneResponse{
+			Cocktail: cocktailResponse(near.Cocktail),
+			Missing:  near.Missing,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// MakeCocktail godoc
+// @Summary      Make a cocktail
+// @Description  Records that the cocktail was made and deducts each ingredient's quantity, scaled by servings, from matching bottles, mixers, fresh items and syrups with a tracked volume, bitters with tracked dashes and garnishes with a tracked count. Only volumes are listed in deductions. Ingredients, or the part of one, that could not be deducted are listed in skipped
+// @Tags         cocktails
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                         true   "Cocktail ID"
+// @Param        make  body      models.MakeCocktailRequest  false  "Number of servings, default 1"
+// @Success      201   {object}  models.CocktailEvent
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /api/cocktails/{id}/make [post]
+func (h *CocktailHandler) MakeCocktail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/cocktails/"), "/make")
+	id, err := strconv.Atoi(path)
+	if err != nil {
+		http.Error(w, "Invalid cocktail ID", http.StatusBadRequest)
+		return
+	}
+
+	req := models.MakeCocktailRequest{Servings: 1}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	event, err := h.repo.MakeCocktail(r.Context(), id, req.Servings)
+	if err != nil {
+		log.Printf("ERROR: MakeCocktail failed - id=%d, servings=%d, error=%v", id, req.Servings, err)
+		switch err {
+		case repository.ErrCocktailNotFound:
+			http.Error(w, fmt.Sprintf("Cocktail with ID %d not found", id), http.StatusNotFound)
+		case repository.ErrInvalidServings:
+			http.Error(w, "Servings must be greater than zero", http.StatusBadRequest)
+		default:
+			http.Error(w, "Unable to record cocktail. Please try again.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(event); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetCocktailHistory godoc
+// @Summary      List made cocktails
+// @Description  Returns every recorded cocktail event with the inventory it used, newest first
+// @Tags         cocktails
+// @Produce      json
+// @Success      200  {array}   models.CocktailEvent
+// @Failure      500  {object}  map[string]string
+// @Router       /api/cocktails/history [get]
+func (h *CocktailHandler) GetCocktailHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.writeCocktailEvents(w, r, 0)
+}
+
+// GetCocktailEvents godoc
+// @Summary      List times a cocktail was made
+// @Description  Returns the recorded events for one cocktail with the inventory each used, newest first
+// @Tags         cocktails
+// @Produce      json
+// @Param        id   path      int  true  "Cocktail ID"
+// @Success      200  {array}   models.CocktailEvent
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/cocktails/{id}/history [get]
+func (h *CocktailHandler) GetCocktailEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/cocktails/"), "/history")
+	id, err := strconv.Atoi(path)
+	if err != nil {
+		http.Error(w, "Invalid cocktail ID", http.StatusBadRequest)
+		return
+	}
+
+	h.writeCocktailEvents(w, r, id)
+}
+
+func (h *CocktailHandler) writeCocktailEvents(w http.ResponseWriter, r *http.Request, cocktailID int) {
+	events, err := h.repo.GetCocktailEvents(r.Context(), cocktailID)
+	if err != nil {
+		log.Printf("ERROR: GetCocktailEvents failed - cocktail_id=%d, error=%v", cocktailID, err)
+		http.Error(w, "Unable to load cocktail history. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(events); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetCocktailBatch godoc
+// @Summary      Batch a cocktail
+// @Description  Scales the recipe to a number of servings, optionally converting volumes to metric or imperial units and adding water for pre-batched dilution
+// @Tags         cocktails
+// @Produce      json
+// @Param        id        path      int     true   "Cocktail ID"
+// @Param        servings  query     int     true   "Number of servings"
+// @Param        dilution  query     number  false  "Fraction of the liquid volume to add as water, e.g. 0.2"
+// @Param        units     query     string  false  "metric or imperial; defaults to the recipe's units"
+// @Success      200       {object}  models.BatchResponse
+// @Failure      400       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /api/cocktails/{id}/batch [get]
+func (h *CocktailHandler) GetCocktailBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/cocktails/"), "/batch")
+	id, err := strconv.Atoi(path)
+	if err != nil {
+		http.Error(w, "Invalid cocktail ID", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	servings, err := strconv.Atoi(query.Get("servings"))
+	if err != nil {
+		http.Error(w, "servings must be a whole number", http.StatusBadRequest)
+		return
+	}
+
+	var dilution float64
+	if value := query.Get("dilution"); value != "" {
+		dilution, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			http.Error(w, "dilution must be a number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	units, err := batch.ParseUnits(query.Get("units"))
+	if err != nil {
+		http.Error(w, "units must be metric or imperial", http.StatusBadRequest)
+		return
+	}
+
+	cocktail, err := h.repo.GetCocktailByID(r.Context(), id)
+	if err != nil {
+		log.Printf("ERROR: GetCocktailByID failed - id=%d, error=%v", id, err)
+		if err == repository.ErrCocktailNotFound {
+			http.Error(w, fmt.Sprintf("Cocktail with ID %d not found", id), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Unable to retrieve cocktail. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := batch.Scale(cocktail, batch.Options{Servings: servings, Dilution: dilution, Units: units})
+	if err != nil {
+		switch err {
+		case batch.ErrInvalidServings:
+			http.Error(w, "Servings must be greater than zero", http.StatusBadRequest)
+		case batch.ErrInvalidDilution:
+			http.Error(w, "Dilution must be between 0 and 1", http.StatusBadRequest)
+		default:
+			http.Error(w, "Unable to batch cocktail. Please try again.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// inventoryMatcher builds a matcher over the bottles, mixers and fresh items
+// in stock, backed by the ingredient catalog.
+func inventoryMatcher(r *http.Request, repo *repository.Repository) (*matcher.Matcher, error) {
+	bottles, err := repo.GetAllBottles(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	mixers, err := repo.GetAllMixers(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	fresh, err := repo.GetAllFresh(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	bitters, err := repo.Bitters().All(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	syrups, err := repo.Syrups().All(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	garnishes, err := repo.Garnishes().All(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := repo.GetAllCatalogIngredients(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := matcher.Inventory(matcher.Stock{
+		Bottles:   bottles,
+		Mixers:    mixers,
+		Fresh:     fresh,
+		Bitters:   bitters,
+		Syrups:    syrups,
+		Garnishes: garnishes,
+	})
+	return matcher.New(inventory).WithResolver(catalog.New(entries)), nil
+}
+
+// cocktailIDFromPath extracts the cocktail ID from /api/cocktails/{id}, writing
+// a 400 response and returning false when it is missing or malformed.
+func cocktailIDFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/cocktails/")
+	if path == "" {
+		http.Error(w, "Cocktail ID is required", http.StatusBadRequest)
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(path)
+	if err != nil {
+		http.Error(w, "Invalid cocktail ID", http.StatusBadRequest)
+		return 0, false
+	}
+
+	return id, true
+}
+
+func ingredientsFromRequest(reqs []models.IngredientRequest) []models.Ingredient {
+	ingredients := make([]models.Ingredient, 0, len(reqs))
+	for _, req := range reqs {
+		ingredients = append(ingredients, models.Ingredient{
+			Name:     req.Name,
+			Quantity: req.Quantity,
+		})
+	}
+	return ingredients
+}
+
+func stepsFromRequest(reqs []models.StepRequest) []models.Step {
+	steps := make([]models.Step, 0, len(reqs))
+	for _, req := range reqs {
+		steps = append(steps, models.Step{
+			Order: req.Order,
+			Text:  req.Text,
+		})
+	}
+	return steps
+}
+
+func cocktailResponse(cocktail *models.Cocktail) models.SavedCocktailResponse {
+	response := models.SavedCocktailResponse{
+		ID:           cocktail.ID,
+		Name:         cocktail.Name,
+		Description:  cocktail.Description,
+		Ingredients:  cocktail.Ingredients,
+		Steps:        cocktail.Steps,
+		SourceModel:  cocktail.SourceModel,
+		SourcePrompt: cocktail.SourcePrompt,
+	}
+	if response.Ingredients == nil {
+		response.Ingredients = []models.Ingredient{}
+	}
+	if response.Steps == nil {
+		response.Steps = []models.Step{}
+	}
+	return response
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nguyenjessev/liquor-locker/internal/freshness"
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+)
+
+type ExpiryHandler struct {
+	repo *repository.Repository
+}
+
+func NewExpiryHandler(repo *repository.Repository) *ExpiryHandler {
+	return &ExpiryHandler{repo: repo}
+}
+
+// GetExpiring godoc
+// @Summary      List items about to go bad
+// @Description  Returns opened mixers, prepared fresh items and syrups that are expiring or have expired, soonest first. Passing days lists everything that expires within that many days instead
+// @Tags         expiring
+// @Produce      json
+// @Param        days  query     int  false  "Expiry window in days"
+// @Success      200   {array}   models.ExpiringItem
+// @Failure      400   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /api/expiring [get]
+func (h *ExpiryHandler) GetExpiring(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var within time.Duration
+	if value := r.URL.Query().Get("days"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days <= 0 {
+			http.Error(w, "days must be a positive whole number", http.StatusBadRequest)
+			return
+		}
+		within = time.Duration(days) * 24 * time.Hour
+	}
+
+	mixers, err := h.repo.GetAllMixers(r.Context())
+	if err != nil {
+		log.Printf("ERROR: GetAllMixers failed - error=%v", err)
+		http.Error(w, "Unable to load mixers. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	fresh, err := h.repo.GetAllFresh(r.Context())
+	if err != nil {
+		log.Printf("ERROR: GetAllFresh failed - error=%v", err)
+		http.Error(w, "Unable to load fresh items. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	syrups, err := h.repo.Syrups().All(r.Context())
+	if err != nil {
+		log.Printf("ERROR: Syrups().All failed - error=%v", err)
+		http.Error(w, "Unable to load syrups. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	items := freshness.Expiring(mixers, fresh, syrups, time.Now(), within)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(items); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
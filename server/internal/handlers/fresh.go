@@ -1,23 +1,66 @@
 package handlers
 
 import (
-	"encoding/json"
-	"fmt"
-	"log"
 	"net/http"
-	"strconv"
-	"strings"
+	"time"
 
+	"github.com/nguyenjessev/liquor-locker/internal/freshness"
 	"github.com/nguyenjessev/liquor-locker/internal/models"
 	"github.com/nguyenjessev/liquor-locker/internal/repository"
 )
 
 type FreshHandler struct {
-	repo *repository.Repository
+	items *inventoryHandler[models.Fresh, models.CreateFreshRequest, models.UpdateFreshRequest, models.FreshResponse]
 }
 
 func NewFreshHandler(repo *repository.Repository) *FreshHandler {
-	return &FreshHandler{repo: repo}
+	return &FreshHandler{items: newInventoryHandler(repo, repo.Fresh(), freshKind)}
+}
+
+var freshKind = inventoryKind[models.Fresh, models.CreateFreshRequest, models.UpdateFreshRequest, models.FreshResponse]{
+	name:     "fresh",
+	noun:     "fresh item",
+	plural:   "fresh items",
+	path:     "/api/fresh/",
+	notFound: repository.ErrFreshNotFound,
+	errNil:   repository.ErrNilFresh,
+	fromCreate: func(req *models.CreateFreshRequest) *models.Fresh {
+		return &models.Fresh{
+			Name:          req.Name,
+			PreparedDate:  req.PreparedDate,
+			PurchaseDate:  req.PurchaseDate,
+			Price:         req.Price,
+			SizeML:        req.SizeML,
+			RemainingML:   req.RemainingML,
+			ShelfLifeDays: req.ShelfLifeDays,
+		}
+	},
+	fromUpdate: func(req *models.UpdateFreshRequest) *models.Fresh {
+		return &models.Fresh{
+			Name:          req.Name,
+			PreparedDate:  req.PreparedDate,
+			PurchaseDate:  req.PurchaseDate,
+			Price:         req.Price,
+			SizeML:        req.SizeML,
+			RemainingML:   req.RemainingML,
+			ShelfLifeDays: req.ShelfLifeDays,
+		}
+	},
+	response: func(fresh *models.Fresh) models.FreshResponse {
+		return models.FreshResponse{
+			ID:           fresh.ID,
+			Name:         fresh.Name,
+			PreparedDate: fresh.PreparedDate,
+			PurchaseDate: fresh.PurchaseDate,
+			Price:        fresh.Price,
+			IngredientID: fresh.IngredientID,
+			SizeML:       fresh.SizeML,
+			RemainingML:  fresh.RemainingML,
+			Finished:     fresh.Finished,
+			FinishedAt:   fresh.FinishedAt,
+			Expiry:       freshness.Fresh(fresh, time.Now()),
+		}
+	},
 }
 
 // CreateFresh godoc
@@ -32,49 +75,7 @@ func NewFreshHandler(repo *repository.Repository) *FreshHandler {
 // @Failure 500 {object} map[string]string
 // @Router /fresh [post]
 func (h *FreshHandler) CreateFresh(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var req models.CreateFreshRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
-		return
-	}
-
-	fresh := &models.Fresh{
-		Name:         req.Name,
-		PreparedDate: req.PreparedDate,
-		PurchaseDate: req.PurchaseDate,
-		Price:        req.Price,
-	}
-
-	createdFresh, err := h.repo.CreateFresh(r.Context(), fresh)
-	if err != nil {
-		log.Printf("ERROR: CreateFresh failed - fresh=%+v, error=%v", fresh, err)
-		if err == repository.ErrNilFresh {
-			http.Error(w, "Invalid fresh data", http.StatusBadRequest)
-			return
-		}
-		http.Error(w, "Unable to save fresh item. Please try again.", http.StatusInternalServerError)
-		return
-	}
-
-	response := models.FreshResponse{
-		ID:           createdFresh.ID,
-		Name:         createdFresh.Name,
-		PreparedDate: createdFresh.PreparedDate,
-		PurchaseDate: createdFresh.PurchaseDate,
-		Price:        createdFresh.Price,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
+	h.items.create(w, r)
 }
 
 // GetFresh godoc
@@ -89,47 +90,7 @@ func (h *FreshHandler) CreateFresh(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {object} map[string]string
 // @Router /fresh/{id} [get]
 func (h *FreshHandler) GetFresh(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	path := strings.TrimPrefix(r.URL.Path, "/api/fresh/")
-	if path == "" {
-		http.Error(w, "Fresh ID is required", http.StatusBadRequest)
-		return
-	}
-
-	id, err := strconv.Atoi(path)
-	if err != nil {
-		http.Error(w, "Invalid fresh ID", http.StatusBadRequest)
-		return
-	}
-
-	fresh, err := h.repo.GetFreshByID(r.Context(), id)
-	if err != nil {
-		log.Printf("ERROR: GetFreshByID failed - id=%d, error=%v", id, err)
-		if err == repository.ErrFreshNotFound {
-			http.Error(w, fmt.Sprintf("Fresh item with ID %d not found", id), http.StatusNotFound)
-			return
-		}
-		http.Error(w, "Unable to retrieve fresh item. Please try again.", http.StatusInternalServerError)
-		return
-	}
-
-	response := models.FreshResponse{
-		ID:           fresh.ID,
-		Name:         fresh.Name,
-		PreparedDate: fresh.PreparedDate,
-		PurchaseDate: fresh.PurchaseDate,
-		Price:        fresh.Price,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
+	h.items.get(w, r)
 }
 
 // DeleteFresh godoc
@@ -144,35 +105,7 @@ func (h *FreshHandler) GetFresh(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {object} map[string]string
 // @Router /fresh/{id} [delete]
 func (h *FreshHandler) DeleteFresh(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	path := strings.TrimPrefix(r.URL.Path, "/api/fresh/")
-	if path == "" {
-		http.Error(w, "Fresh ID is required", http.StatusBadRequest)
-		return
-	}
-
-	id, err := strconv.Atoi(path)
-	if err != nil {
-		http.Error(w, "Invalid fresh ID", http.StatusBadRequest)
-		return
-	}
-
-	err = h.repo.DeleteFreshByID(r.Context(), id)
-	if err != nil {
-		log.Printf("ERROR: DeleteFreshByID failed - id=%d, error=%v", id, err)
-		if err == repository.ErrFreshNotFound {
-			http.Error(w, fmt.Sprintf("Fresh item with ID %d not found", id), http.StatusNotFound)
-			return
-		}
-		http.Error(w, "Unable to delete fresh item. Please try again.", http.StatusInternalServerError)
-		return
-	}
-
-	w.WriteHeader(http.StatusNoContent)
+	h.items.delete(w, r)
 }
 
 // UpdateFresh godoc
@@ -189,101 +122,25 @@ func (h *FreshHandler) DeleteFresh(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {object} map[string]string
 // @Router /fresh/{id} [put]
 func (h *FreshHandler) UpdateFresh(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPut {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	path := strings.TrimPrefix(r.URL.Path, "/api/fresh/")
-	if path == "" {
-		http.Error(w, "Fresh ID is required", http.StatusBadRequest)
-		return
-	}
-
-	id, err := strconv.Atoi(path)
-	if err != nil {
-		http.Error(w, "Invalid fresh ID", http.StatusBadRequest)
-		return
-	}
-
-	var req models.UpdateFreshRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
-		return
-	}
-
-	updates := &models.Fresh{
-		Name:         req.Name,
-		PurchaseDate: req.PurchaseDate,
-		PreparedDate: req.PreparedDate,
-		Price:        req.Price,
-	}
-
-	updatedFresh, err := h.repo.UpdateFresh(r.Context(), id, updates)
-	if err != nil {
-		log.Printf("ERROR: UpdateFresh failed - id=%d, updates=%+v, error=%v", id, updates, err)
-		if err == repository.ErrFreshNotFound {
-			http.Error(w, fmt.Sprintf("Fresh item with ID %d not found", id), http.StatusNotFound)
-			return
-		}
-		if err == repository.ErrNilFresh {
-			http.Error(w, "Invalid fresh data", http.StatusBadRequest)
-			return
-		}
-		http.Error(w, "Unable to update fresh item. Please try again.", http.StatusInternalServerError)
-		return
-	}
-
-	response := models.FreshResponse{
-		ID:           updatedFresh.ID,
-		Name:         updatedFresh.Name,
-		PreparedDate: updatedFresh.PreparedDate,
-		PurchaseDate: updatedFresh.PurchaseDate,
-		Price:        updatedFresh.Price,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
+	h.items.update(w, r)
 }
 
 // GetAllFresh godoc
 // @Summary Get all fresh items
-// @Description Get a list of all fresh items
+// @Description Get a list of fresh items, newest first unless sorted, optionally filtered and paged. When there are more pages, the X-Next-Cursor and Link headers point to the next one.
 // @Tags fresh
 // @Produce json
+// @Param name query string false "Name contains, ignoring case"
+// @Param purchased_after query string false "Purchased after this date (YYYY-MM-DD or RFC 3339)"
+// @Param min_price query number false "Minimum price"
+// @Param max_price query number false "Maximum price"
+// @Param sort query string false "name, price or open_date (the prepared date); prefix with - for descending"
+// @Param limit query int false "Page size, 1 to 500"
+// @Param cursor query string false "Cursor from the previous page's X-Next-Cursor header"
 // @Success 200 {array} models.FreshResponse
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /fresh [get]
 func (h *FreshHandler) GetAllFresh(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	freshItems, err := h.repo.GetAllFresh(r.Context())
-	if err != nil {
-		log.Printf("ERROR: GetAllFresh failed - error=%v", err)
-		http.Error(w, "Unable to load fresh items. Please refresh the page.", http.StatusInternalServerError)
-		return
-	}
-
-	responses := make([]models.FreshResponse, 0)
-	for _, fresh := range freshItems {
-		responses = append(responses, models.FreshResponse{
-			ID:           fresh.ID,
-			Name:         fresh.Name,
-			PreparedDate: fresh.PreparedDate,
-			PurchaseDate: fresh.PurchaseDate,
-			Price:        fresh.Price,
-		})
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(responses); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
+	h.items.list(w, r)
 }
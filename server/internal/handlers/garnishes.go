@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+)
+
+type GarnishHandler struct {
+	items *inventoryHandler[models.Garnish, models.CreateGarnishRequest, models.UpdateGarnishRequest, models.GarnishResponse]
+}
+
+func NewGarnishHandler(repo *repository.Repository) *GarnishHandler {
+	return &GarnishHandler{items: newInventoryHandler(repo, repo.Garnishes(), garnishKind)}
+}
+
+var garnishKind = inventoryKind[models.Garnish, models.CreateGarnishRequest, models.UpdateGarnishRequest, models.GarnishResponse]{
+	name:     "garnish",
+	noun:     "garnish",
+	plural:   "garnishes",
+	path:     "/api/garnishes/",
+	notFound: repository.ErrGarnishNotFound,
+	errNil:   repository.ErrNilGarnish,
+	fromCreate: func(req *models.CreateGarnishRequest) *models.Garnish {
+		return &models.Garnish{
+			Name:         req.Name,
+			Quantity:     req.Quantity,
+			PurchaseDate: req.PurchaseDate,
+			Price:        req.Price,
+		}
+	},
+	fromUpdate: func(req *models.UpdateGarnishRequest) *models.Garnish {
+		return &models.Garnish{
+			Name:         req.Name,
+			Quantity:     req.Quantity,
+			PurchaseDate: req.PurchaseDate,
+			Price:        req.Price,
+		}
+	},
+	response: func(garnish *models.Garnish) models.GarnishResponse {
+		return models.GarnishResponse{
+			ID:           garnish.ID,
+			Name:         garnish.Name,
+			Quantity:     garnish.Quantity,
+			PurchaseDate: garnish.PurchaseDate,
+			Price:        garnish.Price,
+		}
+	},
+}
+
+// CreateGarnish godoc
+// @Summary      Create a garnish
+// @Description  Adds a garnish to the collection
+// @Tags         garnishes
+// @Accept       json
+// @Produce      json
+// @Param        garnish body models.CreateGarnishRequest true "Garnish to create"
+// @Success      201 {object} models.GarnishResponse
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /garnishes [post]
+func (h *GarnishHandler) CreateGarnish(w http.ResponseWriter, r *http.Request) {
+	h.items.create(w, r)
+}
+
+// GetGarnish godoc
+// @Summary      Get a garnish by ID
+// @Description  Returns the garnish with the given ID
+// @Tags         garnishes
+// @Produce      json
+// @Param        id path int true "Garnish ID"
+// @Success      200 {object} models.GarnishResponse
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /garnishes/{id} [get]
+func (h *GarnishHandler) GetGarnish(w http.ResponseWriter, r *http.Request) {
+	h.items.get(w, r)
+}
+
+// DeleteGarnish godoc
+// @Summary      Delete a garnish
+// @Description  Deletes the garnish with the given ID
+// @Tags         garnishes
+// @Param        id path int true "Garnish ID"
+// @Success      204 {object} nil
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /garnishes/{id} [delete]
+func (h *GarnishHandler) DeleteGarnish(w http.ResponseWriter, r *http.Request) {
+	h.items.delete(w, r)
+}
+
+// UpdateGarnish godoc
+// @Summary      Update a garnish
+// @Description  Replaces the garnish's fields. A quantity left out is kept.
+// @Tags         garnishes
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Garnish ID"
+// @Param        garnish body models.UpdateGarnishRequest true "Updated garnish"
+// @Success      200 {object} models.GarnishResponse
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /garnishes/{id} [put]
+func (h *GarnishHandler) UpdateGarnish(w http.ResponseWriter, r *http.Request) {
+	h.items.update(w, r)
+}
+
+// GetAllGarnishes godoc
+// @Summary      Get all garnishes
+// @Description  Returns a list of garnishes, newest first unless sorted, optionally filtered and paged. When there are more pages, the X-Next-Cursor and Link headers point to the next one.
+// @Tags         garnishes
+// @Produce      json
+// @Param        name query string false "Name contains, ignoring case"
+// @Param        purchased_after query string false "Purchased after this date (YYYY-MM-DD or RFC 3339)"
+// @Param        min_price query number false "Minimum price"
+// @Param        max_price query number false "Maximum price"
+// @Param        sort query string false "name or price; prefix with - for descending"
+// @Param        limit query int false "Page size, 1 to 500"
+// @Param        cursor query string false "Cursor from the previous page's X-Next-Cursor header"
+// @Success      200 {array} models.GarnishResponse
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /garnishes [get]
+func (h *GarnishHandler) GetAllGarnishes(w http.ResponseWriter, r *http.Request) {
+	h.items.list(w, r)
+}
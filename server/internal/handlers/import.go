@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nguyenjessev/liquor-locker/internal/importer"
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+)
+
+// maxImportBytes caps the size of an import upload.
+const maxImportBytes = 10 << 20
+
+// itemImporter imports an upload of one kind of inventory item.
+type itemImporter interface {
+	importItems(ctx context.Context, upload io.Reader, format importer.Format, mapping map[string]string, dryRun bool) (*models.ImportReport, error)
+}
+
+type ImportHandler struct {
+	kinds map[string]itemImporter
+}
+
+func NewImportHandler(repo *repository.Repository) *ImportHandler {
+	return &ImportHandler{kinds: map[string]itemImporter{
+		bottleKind.name:  newInventoryHandler(repo, repo.Bottles(), bottleKind),
+		mixerKind.name:   newInventoryHandler(repo, repo.Mixers(), mixerKind),
+		freshKind.name:   newInventoryHandler(repo, repo.Fresh(), freshKind),
+		bittersKind.name: newInventoryHandler(repo, repo.Bitters(), bittersKind),
+		syrupKind.name:   newInventoryHandler(repo, repo.Syrups(), syrupKind),
+		garnishKind.name: newInventoryHandler(repo, repo.Garnishes(), garnishKind),
+	}}
+}
+
+func (h *ImportHandler) kindNames() string {
+	names := make([]string, 0, len(h.kinds))
+	for name := range h.kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Import godoc
+// @Summary      Import inventory in bulk
+// @Description  Creates many items of one kind from a CSV file with a header row or a JSON array of the kind's create requests. CSV headers match fields by name; map renames a header, as in map=Cost:price, or skips it with map=Notes:-. Every row is checked first and the report lists each bad row; only when all rows are good, and it isn't a dry run, are they saved, in one transaction
+// @Tags         import
+// @Accept       json
+// @Accept       text/csv
+// @Produce      json
+// @Param        kind     query     string    true   "bottle, mixer, fresh, bitters, syrup or garnish"
+// @Param        format   query     string    false  "csv or json; defaults to csv for a text/csv body and json otherwise"
+// @Param        map      query     []string  false  "CSV header renames as header:field"  collectionFormat(multi)
+// @Param        dry_run  query     boolean   false  "Check the rows without saving them"
+// @Success      200      {object}  models.ImportReport  "Dry run, with any bad rows"
+// @Success      201      {object}  models.ImportReport
+// @Failure      400      {object}  models.ImportReport  "Some rows are bad; nothing was saved"
+// @Failure      500      {object}  map[string]string
+// @Router       /api/import [post]
+func (h *ImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	kind, ok := h.kinds[query.Get("kind")]
+	if !ok {
+		http.Error(w, "Invalid kind, use one of "+h.kindNames(), http.StatusBadRequest)
+		return
+	}
+
+	rawFormat := query.Get("format")
+	if rawFormat == "" {
+		rawFormat = string(importer.JSON)
+		if strings.Contains(r.Header.Get("Content-Type"), "csv") {
+			rawFormat = string(importer.CSV)
+		}
+	}
+	format, err := importer.ParseFormat(rawFormat)
+	if err != nil {
+		http.Error(w, "Invalid format, use csv or json", http.StatusBadRequest)
+		return
+	}
+
+	mapping := make(map[string]string)
+	for _, m := range query["map"] {
+		i := strings.LastIndex(m, ":")
+		if i < 0 {
+			http.Error(w, fmt.Sprintf("Invalid map %q, use header:field", m), http.StatusBadRequest)
+			return
+		}
+		mapping[m[:i]] = m[i+1:]
+	}
+
+	dryRun := false
+	if raw := query.Get("dry_run"); raw != "" {
+		if dryRun, err = strconv.ParseBool(raw); err != nil {
+			http.Error(w, "Invalid dry_run, must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	upload := http.MaxBytesReader(w, r.Body, maxImportBytes)
+	report, err := kind.importItems(r.Context(), upload, format, mapping, dryRun)
+	if err != nil {
+		if _, ok := err.(importError); ok {
+			http.Error(w, "Unable to read upload: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("ERROR: Import failed - kind=%s, error=%v", query.Get("kind"), err)
+		http.Error(w, "Unable to import items. Nothing was saved; please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusCreated
+	switch {
+	case dryRun:
+		status = http.StatusOK
+	case len(report.Errors) > 0:
+		status = http.StatusBadRequest
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// importError is an upload that can't be read at all, as opposed to a
+// failure to save it.
+type importError struct{ error }
+
+// importItems reads an upload of the kind, checks every row as the create
+// endpoint would, and saves them all at once unless it's a dry run or some
+// row is bad.
+func (h *inventoryHandler[T, C, U, R]) importItems(ctx context.Context, upload io.Reader, format importer.Format, mapping map[string]string, dryRun bool) (*models.ImportReport, error) {
+	rows, err := importer.Read[C](upload, format, mapping)
+	if err != nil {
+		return nil, importError{err}
+	}
+
+	report := &models.ImportReport{Kind: h.kind.name, DryRun: dryRun, Rows: len(rows), Errors: []models.ImportError{}}
+	items := make([]*T, 0, len(rows))
+	for _, row := range rows {
+		if row.Err == nil {
+			item := h.kind.fromCreate(&row.Item)
+			if h.kind.validate != nil {
+				row.Err = h.kind.validate(item)
+			}
+			items = append(items, item)
+		}
+		if row.Err != nil {
+			report.Errors = append(report.Errors, models.ImportError{Row: row.Number, Error: row.Err.Error()})
+		}
+	}
+
+	if dryRun || len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	created, err := h.items.CreateAll(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+	report.Imported = len(created)
+	return report, nil
+}
@@ -0,0 +1,376 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/nguyenjessev/liquor-locker/internal/catalog"
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+)
+
+type IngredientHandler struct {
+	repo *repository.Repository
+}
+
+func NewIngredientHandler(repo *repository.Repository) *IngredientHandler {
+	return &IngredientHandler{repo: repo}
+}
+
+// CreateIngredient godoc
+// @Summary      Create a catalog ingredient
+// @Description  Adds a canonical ingredient, optionally under a parent and with aliases
+// @Tags         ingredients
+// @Accept       json
+// @Produce      json
+// @Param        ingredient  body      models.CreateCatalogIngredientRequest  true  "Ingredient to add"
+// @Success      201         {object}  models.CatalogIngredientResponse
+// @Failure      400         {object}  map[string]string
+// @Failure      500         {object}  map[string]string
+// @Router       /api/ingredients [post]
+func (h *IngredientHandler) CreateIngredient(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req models.CreateCatalogIngredientRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		http.Error(w, "Ingredient name is required", http.StatusBadRequest)
+		return
+	}
+
+	ingredient := &models.CatalogIngredient{
+		Name:     strings.TrimSpace(req.Name),
+		ParentID: req.ParentID,
+		Aliases:  req.Aliases,
+	}
+
+	createdIngredient, err := h.repo.CreateCatalogIngredient(r.Context(), ingredient)
+	if err != nil {
+		log.Printf("ERROR: CreateCatalogIngredient failed - ingredient=%+v, error=%v", ingredient, err)
+		if err == repository.ErrCatalogIngredientNotFound {
+			http.Error(w, "Parent ingredient not found", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Unable to save ingredient. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(catalogIngredientResponse(createdIngredient)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetIngredient godoc
+// @Summary      Get a catalog ingredient by ID
+// @Description  Returns a single catalog ingredient with its aliases
+// @Tags         ingredients
+// @Produce      json
+// @Param        id   path      int  true  "Ingredient ID"
+// @Success      200  {object}  models.CatalogIngredientResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /api/ingredients/{id} [get]
+func (h *IngredientHandler) GetIngredient(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := ingredientIDFromPath(w, r, "")
+	if !ok {
+		return
+	}
+
+	ingredient, err := h.repo.GetCatalogIngredientByID(r.Context(), id)
+	if err != nil {
+		log.Printf("ERROR: GetCatalogIngredientByID failed - id=%d, error=%v", id, err)
+		if err == repository.ErrCatalogIngredientNotFound {
+			http.Error(w, fmt.Sprintf("Ingredient with ID %d not found", id), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Unable to retrieve ingredient. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(catalogIngredientResponse(ingredient)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// DeleteIngredient godoc
+// @Summary      Delete a catalog ingredient by ID
+// @Description  Deletes a catalog ingredient and its aliases, re-parenting its children and unlinking inventory
+// @Tags         ingredients
+// @Param        id   path      int  true  "Ingredient ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /api/ingredients/{id} [delete]
+func (h *IngredientHandler) DeleteIngredient(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := ingredientIDFromPath(w, r, "")
+	if !ok {
+		return
+	}
+
+	err := h.repo.DeleteCatalogIngredientByID(r.Context(), id)
+	if err != nil {
+		log.Printf("ERROR: DeleteCatalogIngredientByID failed - id=%d, error=%v", id, err)
+		if err == repository.ErrCatalogIngredientNotFound {
+			http.Error(w, fmt.Sprintf("Ingredient with ID %d not found", id), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Unable to delete ingredient. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetAllIngredients godoc
+// @Summary      Get the ingredient catalog
+// @Description  Returns every catalog ingredient with its parent and aliases
+// @Tags         ingredients
+// @Produce      json
+// @Success      200  {array}   models.CatalogIngredientResponse
+// @Failure      500  {object}  map[string]string
+// @Router       /api/ingredients [get]
+func (h *IngredientHandler) GetAllIngredients(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ingredients, err := h.repo.GetAllCatalogIngredients(r.Context())
+	if err != nil {
+		log.Printf("ERROR: GetAllCatalogIngredients failed - error=%v", err)
+		http.Error(w, "Unable to load ingredients. Please refresh the page.", http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]models.CatalogIngredientResponse, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		responses = append(responses, catalogIngredientResponse(ingredient))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(responses); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// AddAlias godoc
+// @Summary      Add an alias to a catalog ingredient
+// @Description  Registers another name that should resolve to the ingredient
+// @Tags         ingredients
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                     true  "Ingredient ID"
+// @Param        alias  body      models.AddAliasRequest  true  "Alias to add"
+// @Success      201    {object}  models.CatalogIngredientResponse
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Router       /api/ingredients/{id}/aliases [post]
+func (h *IngredientHandler) AddAlias(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := ingredientIDFromPath(w, r, "/aliases")
+	if !ok {
+		return
+	}
+
+	var req models.AddAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	alias := strings.TrimSpace(req.Alias)
+	if alias == "" {
+		http.Error(w, "Alias is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.repo.AddIngredientAlias(r.Context(), id, alias); err != nil {
+		log.Printf("ERROR: AddIngredientAlias failed - id=%d, alias=%q, error=%v", id, alias, err)
+		if err == repository.ErrCatalogIngredientNotFound {
+			http.Error(w, fmt.Sprintf("Ingredient with ID %d not found", id), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Unable to add alias. It may already be in use.", http.StatusInternalServerError)
+		return
+	}
+
+	ingredient, err := h.repo.GetCatalogIngredientByID(r.Context(), id)
+	if err != nil {
+		log.Printf("ERROR: GetCatalogIngredientByID failed - id=%d, error=%v", id, err)
+		http.Error(w, "Unable to retrieve ingredient. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(catalogIngredientResponse(ingredient)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ResolveIngredient godoc
+// @Summary      Resolve a free-text name to a catalog ingredient
+// @Description  Matches a name such as "Buffalo Trace Bourbon" against catalog names and aliases
+// @Tags         ingredients
+// @Produce      json
+// @Param        name  query     string  true  "Name to resolve"
+// @Success      200   {object}  models.ResolveIngredientResponse
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Router       /api/ingredients/resolve [get]
+func (h *IngredientHandler) ResolveIngredient(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if strings.TrimSpace(name) == "" {
+		http.Error(w, "Missing required query parameter: name", http.StatusBadRequest)
+		return
+	}
+
+	entries, err := h.repo.GetAllCatalogIngredients(r.Context())
+	if err != nil {
+		log.Printf("ERROR: GetAllCatalogIngredients failed - error=%v", err)
+		http.Error(w, "Unable to load ingredients. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	c := catalog.New(entries)
+	ingredient, ok := c.Resolve(name)
+	if !ok {
+		http.Error(w, fmt.Sprintf("No catalog ingredient matches %q", name), http.StatusNotFound)
+		return
+	}
+
+	resolved := catalogIngredientResponse(ingredient)
+	response := models.ResolveIngredientResponse{
+		Query:      name,
+		Ingredient: &resolved,
+		Lineage:    []models.CatalogIngredientResponse{},
+	}
+	for _, entry := range c.Lineage(ingredient.ID) {
+		response.Lineage = append(response.Lineage, catalogIngredientResponse(entry))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// LinkIngredient godoc
+// @Summary      Link an inventory item to a catalog ingredient
+// @Description  Sets or clears the catalog ingredient of a bottle, mixer or fresh item
+// @Tags         ingredients
+// @Accept       json
+// @Param        link  body  models.LinkIngredientRequest  true  "Link to set; kind is bottle, mixer or fresh"
+// @Success      204   {object}  nil
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Router       /api/ingredients/links [put]
+func (h *IngredientHandler) LinkIngredient(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req models.LinkIngredientRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	switch req.Kind {
+	case "bottle":
+		err = h.repo.LinkBottleIngredient(r.Context(), req.ItemID, req.IngredientID)
+	case "mixer":
+		err = h.repo.LinkMixerIngredient(r.Context(), req.ItemID, req.IngredientID)
+	case "fresh":
+		err = h.repo.LinkFreshIngredient(r.Context(), req.ItemID, req.IngredientID)
+	default:
+		http.Error(w, "Kind must be one of: bottle, mixer, fresh", http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		log.Printf("ERROR: LinkIngredient failed - request=%+v, error=%v", req, err)
+		switch err {
+		case repository.ErrCatalogIngredientNotFound:
+			http.Error(w, "Ingredient not found", http.StatusNotFound)
+		case repository.ErrBottleNotFound, repository.ErrMixerNotFound, repository.ErrFreshNotFound:
+			http.Error(w, fmt.Sprintf("%s with ID %d not found", req.Kind, req.ItemID), http.StatusNotFound)
+		default:
+			http.Error(w, "Unable to link ingredient. Please try again.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ingredientIDFromPath extracts the ID from /api/ingredients/{id}{suffix},
+// writing a 400 response and returning false when it is missing or malformed.
+func ingredientIDFromPath(w http.ResponseWriter, r *http.Request, suffix string) (int64, bool) {
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/ingredients/"), suffix)
+	if path == "" {
+		http.Error(w, "Ingredient ID is required", http.StatusBadRequest)
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(path, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid ingredient ID", http.StatusBadRequest)
+		return 0, false
+	}
+
+	return id, true
+}
+
+func catalogIngredientResponse(ingredient *models.CatalogIngredient) models.CatalogIngredientResponse {
+	aliases := ingredient.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return models.CatalogIngredientResponse{
+		ID:       ingredient.ID,
+		Name:     ingredient.Name,
+		ParentID: ingredient.ParentID,
+		Aliases:  aliases,
+	}
+}
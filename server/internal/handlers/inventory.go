@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+)
+
+// inventoryKind declares a kind of inventory item to the generic handlers:
+// how requests become items, how items become responses, and what to call
+// the item in error messages.
+type inventoryKind[T, Create, Update, Response any] struct {
+	// name is the item's short name, as in "Invalid fresh ID"; noun and
+	// plural name it in sentences, as in "Fresh item with ID 1 not found".
+	name   string
+	noun   string
+	plural string
+	// path is the collection path with a trailing slash, e.g. "/api/fresh/".
+	path     string
+	notFound error
+	errNil   error
+
+	fromCreate func(*Create) *T
+	fromUpdate func(*Update) *T
+	response   func(*T) Response
+	// validate optionally rejects an item built from a request. Its error is
+	// shown to the client.
+	validate func(*T) error
+	// filter optionally adds the kind's own filters to a list. Its errors are
+	// shown to the client.
+	filter func(url.Values, repository.ListOptions) (repository.Filter, error)
+}
+
+// inventoryHandler serves the create, read, update, delete, list and pour
+// endpoints of one kind of inventory item.
+type inventoryHandler[T, Create, Update, Response any] struct {
+	repo  *repository.Repository
+	items *repository.Inventory[T]
+	kind  inventoryKind[T, Create, Update, Response]
+}
+
+func newInventoryHandler[T, Create, Update, Response any](repo *repository.Repository, items *repository.Inventory[T], kind inventoryKind[T, Create, Update, Response]) *inventoryHandler[T, Create, Update, Response] {
+	return &inventoryHandler[T, Create, Update, Response]{repo: repo, items: items, kind: kind}
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func (h *inventoryHandler[T, C, U, R]) writeNotFound(w http.ResponseWriter, id int) {
+	http.Error(w, fmt.Sprintf("%s with ID %d not found", capitalize(h.kind.noun), id), http.StatusNotFound)
+}
+
+// id reads the item ID from the path, after trimming suffix. It writes a 400
+// and returns false if there isn't a valid one.
+func (h *inventoryHandler[T, C, U, R]) id(w http.ResponseWriter, r *http.Request, suffix string) (int, bool) {
+	path := strings.TrimPrefix(r.URL.Path, h.kind.path)
+	if suffix != "" {
+		path = strings.TrimSuffix(path, suffix)
+	} else if path == "" {
+		http.Error(w, fmt.Sprintf("%s ID is required", capitalize(h.kind.name)), http.StatusBadRequest)
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(path)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid %s ID", h.kind.name), http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *inventoryHandler[T, C, U, R]) encode(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+	}
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *inventoryHandler[T, C, U, R]) create(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req C
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	item := h.kind.fromCreate(&req)
+	if h.kind.validate != nil {
+		if err := h.kind.validate(item); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	created, err := h.items.Create(r.Context(), item)
+	if err != nil {
+		log.Printf("ERROR: Create failed - %s=%+v, error=%v", h.kind.name, item, err)
+		if err == h.kind.errNil {
+			http.Error(w, fmt.Sprintf("Invalid %s data", h.kind.name), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Unable to save %s. Please try again.", h.kind.noun), http.StatusInternalServerError)
+		return
+	}
+
+	h.encode(w, http.StatusCreated, h.kind.response(created))
+}
+
+func (h *inventoryHandler[T, C, U, R]) get(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := h.id(w, r, "")
+	if !ok {
+		return
+	}
+
+	item, err := h.items.Get(r.Context(), id)
+	if err != nil {
+		log.Printf("ERROR: Get failed - %s=%d, error=%v", h.kind.name, id, err)
+		if err == h.kind.notFound {
+			h.writeNotFound(w, id)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Unable to retrieve %s. Please try again.", h.kind.noun), http.StatusInternalServerError)
+		return
+	}
+
+	h.encode(w, http.StatusOK, h.kind.response(item))
+}
+
+func (h *inventoryHandler[T, C, U, R]) delete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := h.id(w, r, "")
+	if !ok {
+		return
+	}
+
+	if err := h.items.Delete(r.Context(), id); err != nil {
+		log.Printf("ERROR: Delete failed - %s=%d, error=%v", h.kind.name, id, err)
+		if err == h.kind.notFound {
+			h.writeNotFound(w, id)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Unable to delete %s. Please try again.", h.kind.noun), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *inventoryHandler[T, C, U, R]) update(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := h.id(w, r, "")
+	if !ok {
+		return
+	}
+
+	var req U
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	updates := h.kind.fromUpdate(&req)
+	if h.kind.validate != nil {
+		if err := h.kind.validate(updates); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	updated, err := h.items.Update(r.Context(), id, updates)
+	if err != nil {
+		log.Printf("ERROR: Update failed - %s=%d, updates=%+v, error=%v", h.kind.name, id, updates, err)
+		switch err {
+		case h.kind.notFound:
+			h.writeNotFound(w, id)
+		case h.kind.errNil:
+			http.Error(w, fmt.Sprintf("Invalid %s data", h.kind.name), http.StatusBadRequest)
+		default:
+			http.Error(w, fmt.Sprintf("Unable to update %s. Please try again.", h.kind.noun), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.encode(w, http.StatusOK, h.kind.response(updated))
+}
+
+func (h *inventoryHandler[T, C, U, R]) list(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	opts, err := listOptions(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var filter repository.Filter = opts
+	if h.kind.filter != nil {
+		if filter, err = h.kind.filter(query, opts); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	items, next, err := h.items.Find(r.Context(), filter)
+	if err != nil {
+		if listError(w, err) {
+			return
+		}
+		log.Printf("ERROR: Find failed - kind=%s, filter=%+v, error=%v", h.kind.name, filter, err)
+		http.Error(w, fmt.Sprintf("Unable to load %s. Please refresh the page.", h.kind.plural), http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]R, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, h.kind.response(item))
+	}
+
+	setNextPage(w, r, next)
+	h.encode(w, http.StatusOK, responses)
+}
+
+func (h *inventoryHandler[T, C, U, R]) pour(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := h.id(w, r, "/pour")
+	if !ok {
+		return
+	}
+
+	var req models.PourRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	item, err := h.items.Pour(r.Context(), id, req.AmountML)
+	if err != nil {
+		log.Printf("ERROR: Pour failed - %s=%d, amount=%v, error=%v", h.kind.name, id, req.AmountML, err)
+		switch err {
+		case h.kind.notFound:
+			h.writeNotFound(w, id)
+		case repository.ErrInvalidPourAmount:
+			http.Error(w, "Pour amount must be greater than zero", http.StatusBadRequest)
+		case repository.ErrVolumeNotTracked:
+			http.Error(w, fmt.Sprintf("Set the %s's size before pouring from it", h.kind.noun), http.StatusConflict)
+		case repository.ErrItemFinished:
+			http.Error(w, fmt.Sprintf("The %s is finished", h.kind.noun), http.StatusConflict)
+		default:
+			http.Error(w, "Unable to record pour. Please try again.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.encode(w, http.StatusOK, h.kind.response(item))
+}
+
+func (h *inventoryHandler[T, C, U, R]) pours(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := h.id(w, r, "/pours")
+	if !ok {
+		return
+	}
+
+	pours, err := h.repo.GetPours(r.Context(), h.items.Kind(), id)
+	if err != nil {
+		log.Printf("ERROR: GetPours failed - %s=%d, error=%v", h.kind.name, id, err)
+		http.Error(w, "Unable to load pours. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	h.encode(w, http.StatusOK, pours)
+}
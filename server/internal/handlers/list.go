@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+)
+
+// maxListLimit caps the page size clients can ask the list endpoints for.
+const maxListLimit = 500
+
+// listOptions reads the filtering, sorting and paging parameters shared by
+// the bottle, mixer and fresh list endpoints. Its errors are fit to show to
+// the client.
+func listOptions(query url.Values) (repository.ListOptions, error) {
+	opts := repository.ListOptions{
+		NameContains: query.Get("name"),
+		Sort:         query.Get("sort"),
+		Cursor:       query.Get("cursor"),
+	}
+
+	if raw := query.Get("opened"); raw != "" {
+		opened, err := strconv.ParseBool(raw)
+		if err != nil {
+			return opts, errors.New("Invalid opened, must be true or false")
+		}
+		opts.Opened = &opened
+	}
+
+	if raw := query.Get("purchased_after"); raw != "" {
+		purchasedAfter, err := parseDate(raw)
+		if err != nil {
+			return opts, errors.New("Invalid purchased_after, use YYYY-MM-DD or RFC 3339")
+		}
+		opts.PurchasedAfter = &purchasedAfter
+	}
+
+	for _, bound := range []struct {
+		param string
+		value **float64
+	}{
+		{"min_price", &opts.MinPrice},
+		{"max_price", &opts.MaxPrice},
+	} {
+		raw := query.Get(bound.param)
+		if raw == "" {
+			continue
+		}
+		price, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return opts, fmt.Errorf("Invalid %s", bound.param)
+		}
+		*bound.value = &price
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxListLimit {
+			return opts, fmt.Errorf("Invalid limit, must be between 1 and %d", maxListLimit)
+		}
+		opts.Limit = limit
+	}
+
+	return opts, nil
+}
+
+func parseDate(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
+
+// listError reports whether err is a bad list option from the repository and,
+// if so, writes a 400 for it.
+func listError(w http.ResponseWriter, err error) bool {
+	switch err {
+	case repository.ErrInvalidSort:
+		http.Error(w, "Invalid sort, use name, price or open_date, optionally prefixed with -", http.StatusBadRequest)
+	case repository.ErrInvalidCursor:
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+	case repository.ErrOpenedNotSupported:
+		http.Error(w, "This list can't be filtered by opened", http.StatusBadRequest)
+	default:
+		return false
+	}
+	return true
+}
+
+// setNextPage points the client at the next page of a list, both as an
+// X-Next-Cursor header and as a Link header with rel="next".
+func setNextPage(w http.ResponseWriter, r *http.Request, next string) {
+	if next == "" {
+		return
+	}
+
+	query := r.URL.Query()
+	query.Set("cursor", next)
+	nextURL := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+
+	w.Header().Set("X-Next-Cursor", next)
+	w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, nextURL.String()))
+}
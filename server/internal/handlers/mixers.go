@@ -1,23 +1,69 @@
 package handlers
 
 import (
-	"encoding/json"
-	"fmt"
-	"log"
 	"net/http"
-	"strconv"
-	"strings"
+	"time"
 
+	"github.com/nguyenjessev/liquor-locker/internal/freshness"
 	"github.com/nguyenjessev/liquor-locker/internal/models"
 	"github.com/nguyenjessev/liquor-locker/internal/repository"
 )
 
 type MixerHandler struct {
-	repo *repository.Repository
+	items *inventoryHandler[models.Mixer, models.CreateMixerRequest, models.UpdateMixerRequest, models.MixerResponse]
 }
 
 func NewMixerHandler(repo *repository.Repository) *MixerHandler {
-	return &MixerHandler{repo: repo}
+	return &MixerHandler{items: newInventoryHandler(repo, repo.Mixers(), mixerKind)}
+}
+
+var mixerKind = inventoryKind[models.Mixer, models.CreateMixerRequest, models.UpdateMixerRequest, models.MixerResponse]{
+	name:     "mixer",
+	noun:     "mixer",
+	plural:   "mixers",
+	path:     "/api/mixers/",
+	notFound: repository.ErrMixerNotFound,
+	errNil:   repository.ErrNilMixer,
+	fromCreate: func(req *models.CreateMixerRequest) *models.Mixer {
+		return &models.Mixer{
+			Name:          req.Name,
+			Opened:        req.Opened,
+			OpenDate:      req.OpenDate,
+			PurchaseDate:  req.PurchaseDate,
+			Price:         req.Price,
+			SizeML:        req.SizeML,
+			RemainingML:   req.RemainingML,
+			ShelfLifeDays: req.ShelfLifeDays,
+		}
+	},
+	fromUpdate: func(req *models.UpdateMixerRequest) *models.Mixer {
+		return &models.Mixer{
+			Name:          req.Name,
+			Opened:        req.Opened,
+			OpenDate:      req.OpenDate,
+			PurchaseDate:  req.PurchaseDate,
+			Price:         req.Price,
+			SizeML:        req.SizeML,
+			RemainingML:   req.RemainingML,
+			ShelfLifeDays: req.ShelfLifeDays,
+		}
+	},
+	response: func(mixer *models.Mixer) models.MixerResponse {
+		return models.MixerResponse{
+			ID:           mixer.ID,
+			Name:         mixer.Name,
+			Opened:       mixer.Opened,
+			OpenDate:     mixer.OpenDate,
+			PurchaseDate: mixer.PurchaseDate,
+			Price:        mixer.Price,
+			IngredientID: mixer.IngredientID,
+			SizeML:       mixer.SizeML,
+			RemainingML:  mixer.RemainingML,
+			Finished:     mixer.Finished,
+			FinishedAt:   mixer.FinishedAt,
+			Expiry:       freshness.Mixer(mixer, time.Now()),
+		}
+	},
 }
 
 // CreateMixers godoc
@@ -32,51 +78,7 @@ func NewMixerHandler(repo *repository.Repository) *MixerHandler {
 // @Failure      500 {object} map[string]string
 // @Router       /mixers [post]
 func (h *MixerHandler) CreateMixer(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var req models.CreateMixerRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
-		return
-	}
-
-	mixer := &models.Mixer{
-		Name:         req.Name,
-		Opened:       req.Opened,
-		OpenDate:     req.OpenDate,
-		PurchaseDate: req.PurchaseDate,
-		Price:        req.Price,
-	}
-
-	createdMixer, err := h.repo.CreateMixer(r.Context(), mixer)
-	if err != nil {
-		log.Printf("ERROR: CreateMixer failed - mixer=%+v, error=%v", mixer, err)
-		if err == repository.ErrNilMixer {
-			http.Error(w, "Invalid mixer data", http.StatusBadRequest)
-			return
-		}
-		http.Error(w, "Unable to save mixer. Please try again.", http.StatusInternalServerError)
-		return
-	}
-
-	response := models.MixerResponse{
-		ID:           createdMixer.ID,
-		Name:         createdMixer.Name,
-		Opened:       createdMixer.Opened,
-		OpenDate:     createdMixer.OpenDate,
-		PurchaseDate: createdMixer.PurchaseDate,
-		Price:        createdMixer.Price,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
+	h.items.create(w, r)
 }
 
 // GetMixer godoc
@@ -89,47 +91,7 @@ func (h *MixerHandler) CreateMixer(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {object} map[string]string
 // @Router /mixers/{id} [get]
 func (h *MixerHandler) GetMixer(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	path := strings.TrimPrefix(r.URL.Path, "/api/mixers/")
-	if path == "" {
-		http.Error(w, "Mixer ID is required", http.StatusBadRequest)
-		return
-	}
-	id, err := strconv.Atoi(path)
-	if err != nil {
-		http.Error(w, "Invalid mixer ID", http.StatusBadRequest)
-		return
-	}
-
-	mixer, err := h.repo.GetMixerByID(r.Context(), id)
-	if err != nil {
-		log.Printf("ERROR: GetMixerByID failed - id=%d, error=%v", id, err)
-		if err == repository.ErrMixerNotFound {
-			http.Error(w, fmt.Sprintf("Mixer with ID %d not found", id), http.StatusNotFound)
-			return
-		}
-		http.Error(w, "Unable to retrieve mixer. Please try again.", http.StatusInternalServerError)
-		return
-	}
-
-	response := models.MixerResponse{
-		ID:           mixer.ID,
-		Name:         mixer.Name,
-		Opened:       mixer.Opened,
-		OpenDate:     mixer.OpenDate,
-		PurchaseDate: mixer.PurchaseDate,
-		Price:        mixer.Price,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
+	h.items.get(w, r)
 }
 
 // DeleteMixer godoc
@@ -142,33 +104,7 @@ func (h *MixerHandler) GetMixer(w http.ResponseWriter, r *http.Request) {
 // @Failure      404  {object}  map[string]string
 // @Router       /api/mixers/{id} [delete]
 func (h *MixerHandler) DeleteMixer(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	path := strings.TrimPrefix(r.URL.Path, "/api/mixers/")
-	if path == "" {
-		http.Error(w, "Mixer ID is required", http.StatusBadRequest)
-		return
-	}
-	id, err := strconv.Atoi(path)
-	if err != nil {
-		http.Error(w, "Invalid mixer ID", http.StatusBadRequest)
-		return
-	}
-
-	err = h.repo.DeleteMixerByID(r.Context(), id)
-	if err != nil {
-		log.Printf("ERROR: DeleteMixerByID failed - id=%d, error=%v", id, err)
-		if err == repository.ErrMixerNotFound {
-			http.Error(w, fmt.Sprintf("Mixer with ID %d not found", id), http.StatusNotFound)
-			return
-		}
-		http.Error(w, "Unable to delete mixer. Please try again.", http.StatusInternalServerError)
-		return
-	}
-	w.WriteHeader(http.StatusNoContent)
+	h.items.delete(w, r)
 }
 
 // UpdateMixer godoc
@@ -184,99 +120,57 @@ func (h *MixerHandler) DeleteMixer(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {object} map[string]string
 // @Router /mixers/{id} [put]
 func (h *MixerHandler) UpdateMixer(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPut {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	path := strings.TrimPrefix(r.URL.Path, "/api/mixers/")
-	if path == "" {
-		http.Error(w, "Mixer ID is required", http.StatusBadRequest)
-		return
-	}
-	id, err := strconv.Atoi(path)
-	if err != nil {
-		http.Error(w, "Invalid mixer ID", http.StatusBadRequest)
-		return
-	}
-
-	var req models.UpdateMixerRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
-		return
-	}
-
-	updates := &models.Mixer{
-		Name:         req.Name,
-		Opened:       req.Opened,
-		OpenDate:     req.OpenDate,
-		PurchaseDate: req.PurchaseDate,
-		Price:        req.Price,
-	}
-
-	updatedMixer, err := h.repo.UpdateMixer(r.Context(), id, updates)
-	if err != nil {
-		log.Printf("ERROR: UpdateMixer failed - id=%d, updates=%+v, error=%v", id, updates, err)
-		if err == repository.ErrMixerNotFound {
-			http.Error(w, fmt.Sprintf("Mixer with ID %d not found", id), http.StatusNotFound)
-			return
-		}
-		if err == repository.ErrNilMixer {
-			http.Error(w, "Invalid mixer data", http.StatusBadRequest)
-			return
-		}
-		http.Error(w, "Unable to update mixer. Please try again.", http.StatusInternalServerError)
-		return
-	}
-
-	response := models.MixerResponse{
-		ID:           updatedMixer.ID,
-		Name:         updatedMixer.Name,
-		Opened:       updatedMixer.Opened,
-		OpenDate:     updatedMixer.OpenDate,
-		PurchaseDate: updatedMixer.PurchaseDate,
-		Price:        updatedMixer.Price,
-	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	h.items.update(w, r)
 }
 
 // GetAllMixers godoc
 // @Summary      Get all mixers
-// @Description  Returns a list of all mixers
+// @Description  Returns a list of mixers, newest first unless sorted, optionally filtered and paged. When there are more pages, the X-Next-Cursor and Link headers point to the next one.
 // @Tags         mixers
 // @Produce      json
+// @Param        opened query boolean false "Only opened (true) or sealed (false) items"
+// @Param        name query string false "Name contains, ignoring case"
+// @Param        purchased_after query string false "Purchased after this date (YYYY-MM-DD or RFC 3339)"
+// @Param        min_price query number false "Minimum price"
+// @Param        max_price query number false "Maximum price"
+// @Param        sort query string false "name, price or open_date; prefix with - for descending"
+// @Param        limit query int false "Page size, 1 to 500"
+// @Param        cursor query string false "Cursor from the previous page's X-Next-Cursor header"
 // @Success      200 {array} models.MixerResponse
+// @Failure      400 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /mixers [get]
 func (h *MixerHandler) GetAllMixers(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	mixers, err := h.repo.GetAllMixers(r.Context())
-	if err != nil {
-		log.Printf("ERROR: GetAllMixers failed - error=%v", err)
-		http.Error(w, "Unable to load mixers. Please refresh the page.", http.StatusInternalServerError)
-		return
-	}
+	h.items.list(w, r)
+}
 
-	responses := make([]models.MixerResponse, 0)
-	for _, mixer := range mixers {
-		responses = append(responses, models.MixerResponse{
-			ID:           mixer.ID,
-			Name:         mixer.Name,
-			Opened:       mixer.Opened,
-			OpenDate:     mixer.OpenDate,
-			PurchaseDate: mixer.PurchaseDate,
-			Price:        mixer.Price,
-		})
-	}
+// PourMixer godoc
+// @Summary      Pour from a mixer
+// @Description  Decrements the mixer's remaining volume, records the pour, and marks the mixer finished when it reaches zero. A pour larger than what is left only takes what is left; a finished mixer can't be poured from
+// @Tags         mixers
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                 true  "Mixer ID"
+// @Param        pour  body      models.PourRequest  true  "Amount poured in ml"
+// @Success      200   {object}  models.MixerResponse
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Router       /api/mixers/{id}/pour [post]
+func (h *MixerHandler) PourMixer(w http.ResponseWriter, r *http.Request) {
+	h.items.pour(w, r)
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(responses); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
+// GetMixerPours godoc
+// @Summary      Get a mixer's pour history
+// @Description  Returns every recorded pour from the mixer, newest first
+// @Tags         mixers
+// @Produce      json
+// @Param        id   path      int  true  "Mixer ID"
+// @Success      200  {array}   models.Pour
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/mixers/{id}/pours [get]
+func (h *MixerHandler) GetMixerPours(w http.ResponseWriter, r *http.Request) {
+	h.items.pours(w, r)
 }
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+type SearchHandler struct {
+	repo *repository.Repository
+}
+
+func NewSearchHandler(repo *repository.Repository) *SearchHandler {
+	return &SearchHandler{repo: repo}
+}
+
+// Search godoc
+// @Summary      Search the whole bar
+// @Description  Finds inventory items and saved cocktail recipes by name. Bottles also match on their category, brand and origin, bitters on their brand, and recipes on their description and ingredients. Every word must match the start of a word; hits come best first
+// @Tags         search
+// @Produce      json
+// @Param        q      query     string  true   "Search text"
+// @Param        limit  query     int     false  "Maximum number of hits, 1 to 100 (default 20)"
+// @Success      200    {array}   models.SearchHit
+// @Failure      400    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /api/search [get]
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query().Get("q")
+	limit := defaultSearchLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxSearchLimit {
+			http.Error(w, "limit must be between 1 and 100", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	hits, err := h.repo.Search(r.Context(), query, limit)
+	if err != nil {
+		if err == repository.ErrEmptySearch {
+			http.Error(w, "Search query is required", http.StatusBadRequest)
+			return
+		}
+		log.Printf("ERROR: Search failed - q=%q, error=%v", query, err)
+		http.Error(w, "Unable to search. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(hits); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
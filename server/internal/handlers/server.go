@@ -10,21 +10,34 @@ import (
 
 	httpSwagger "github.com/swaggo/http-swagger"
 
+	"github.com/nguyenjessev/liquor-locker/internal/backup"
 	"github.com/nguyenjessev/liquor-locker/internal/repository"
+	"github.com/nguyenjessev/liquor-locker/internal/secret"
 )
 
 type Server struct {
-	repo           *repository.Repository
-	bottleHandler  *BottleHandler
-	freshHandler   *FreshHandler
-	mixerHandler   *MixerHandler
-	aiHandler      *AIHandler
-	router         *http.ServeMux
-	allowedOrigins []string
-	apiKey         string
+	repo              *repository.Repository
+	bottleHandler     *BottleHandler
+	freshHandler      *FreshHandler
+	mixerHandler      *MixerHandler
+	bittersHandler    *BittersHandler
+	syrupHandler      *SyrupHandler
+	garnishHandler    *GarnishHandler
+	cocktailHandler   *CocktailHandler
+	ingredientHandler *IngredientHandler
+	shoppingHandler   *ShoppingHandler
+	expiryHandler     *ExpiryHandler
+	searchHandler     *SearchHandler
+	importHandler     *ImportHandler
+	archiveHandler    *ArchiveHandler
+	backupHandler     *BackupHandler
+	aiHandler         *AIHandler
+	router            *http.ServeMux
+	allowedOrigins    []string
+	apiKey            string
 }
 
-func NewServer(repo *repository.Repository) *Server {
+func NewServer(repo *repository.Repository, backups *backup.Manager) *Server {
 	// Get allowed origins from environment, default to localhost for development
 	allowedOrigins := strings.Split(os.Getenv("ALLOWED_ORIGINS"), ",")
 	if len(allowedOrigins) == 1 && allowedOrigins[0] == "" {
@@ -37,15 +50,33 @@ func NewServer(repo *repository.Repository) *Server {
 		log.Println("WARNING: API_KEY not set. API will be unsecured.")
 	}
 
+	// Get the key that encrypts stored secrets, such as the AI provider's API
+	// key, from environment
+	box, err := secret.NewBox(os.Getenv("ENCRYPTION_KEY"))
+	if err != nil {
+		log.Println("WARNING: ENCRYPTION_KEY not set. AI settings will not be saved across restarts, and AI profiles can't be added.")
+	}
+
 	server := &Server{
-		repo:           repo,
-		bottleHandler:  NewBottleHandler(repo),
-		freshHandler:   NewFreshHandler(repo),
-		mixerHandler:   NewMixerHandler(repo),
-		aiHandler:      NewAIHandler(),
-		allowedOrigins: allowedOrigins,
-		apiKey:         apiKey,
-		router:         http.NewServeMux(),
+		repo:              repo,
+		bottleHandler:     NewBottleHandler(repo),
+		freshHandler:      NewFreshHandler(repo),
+		mixerHandler:      NewMixerHandler(repo),
+		bittersHandler:    NewBittersHandler(repo),
+		syrupHandler:      NewSyrupHandler(repo),
+		garnishHandler:    NewGarnishHandler(repo),
+		cocktailHandler:   NewCocktailHandler(repo),
+		ingredientHandler: NewIngredientHandler(repo),
+		shoppingHandler:   NewShoppingHandler(repo),
+		expiryHandler:     NewExpiryHandler(repo),
+		searchHandler:     NewSearchHandler(repo),
+		importHandler:     NewImportHandler(repo),
+		archiveHandler:    NewArchiveHandler(repo),
+		backupHandler:     NewBackupHandler(backups),
+		aiHandler:         NewAIHandler(repo, box),
+		allowedOrigins:    allowedOrigins,
+		apiKey:            apiKey,
+		router:            http.NewServeMux(),
 	}
 
 	server.registerRoutes()
@@ -65,12 +96,46 @@ func (s *Server) registerRoutes() {
 	s.router.HandleFunc("/api/fresh", s.handleFreshCollection)
 	s.router.HandleFunc("/api/fresh/", s.handleFreshResource)
 
+	s.router.HandleFunc("/api/bitters", s.handleBittersCollection)
+	s.router.HandleFunc("/api/bitters/", s.handleBittersResource)
+
+	s.router.HandleFunc("/api/syrups", s.handleSyrupsCollection)
+	s.router.HandleFunc("/api/syrups/", s.handleSyrupResource)
+
+	s.router.HandleFunc("/api/garnishes", s.handleGarnishesCollection)
+	s.router.HandleFunc("/api/garnishes/", s.handleGarnishResource)
+
+	s.router.HandleFunc("/api/expiring", s.expiryHandler.GetExpiring)
+
+	s.router.HandleFunc("/api/search", s.searchHandler.Search)
+
+	s.router.HandleFunc("/api/import", s.importHandler.Import)
+	s.router.HandleFunc("/api/export", s.archiveHandler.Export)
+	s.router.HandleFunc("/api/restore", s.archiveHandler.Restore)
+	s.router.HandleFunc("/api/admin/backups", s.backupHandler.Backups)
+
+	s.router.HandleFunc("/api/cocktails", s.handleCocktailsCollection)
+	s.router.HandleFunc("/api/cocktails/", s.handleCocktailResource)
+	s.router.HandleFunc("/api/cocktails/makeable", s.cocktailHandler.GetMakeable)
+	s.router.HandleFunc("/api/cocktails/history", s.cocktailHandler.GetCocktailHistory)
+
+	s.router.HandleFunc("/api/ingredients", s.handleIngredientsCollection)
+	s.router.HandleFunc("/api/ingredients/", s.handleIngredientResource)
+	s.router.HandleFunc("/api/ingredients/resolve", s.ingredientHandler.ResolveIngredient)
+	s.router.HandleFunc("/api/ingredients/links", s.ingredientHandler.LinkIngredient)
+
+	s.router.HandleFunc("/api/shopping-lists", s.handleShoppingListsCollection)
+	s.router.HandleFunc("/api/shopping-lists/", s.handleShoppingListResource)
+
 	s.router.HandleFunc("/health", s.handleHealth)
 
 	s.router.HandleFunc("/api/ai/configure", s.aiHandler.Configure)
 	s.router.HandleFunc("/api/ai/models", s.aiHandler.ListModels)
 	s.router.HandleFunc("/api/ai/service", s.aiHandler.ServiceStatusHandler)
+	s.router.HandleFunc("/api/ai/profiles", s.handleAIProfilesCollection)
+	s.router.HandleFunc("/api/ai/profiles/", s.handleAIProfileResource)
 	s.router.Handle("/api/cocktails/recommendation", s.aiHandler.RecommendCocktailHandler(s.repo))
+	s.router.HandleFunc("/api/cocktails/recommendation/save", s.cocktailHandler.SaveRecommendation)
 
 	s.router.Handle("/", http.FileServer(http.Dir("dist")))
 }
@@ -164,6 +229,15 @@ func (s *Server) handleBottlesCollection(w http.ResponseWriter, r *http.Request)
 }
 
 func (s *Server) handleBottleResource(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/pour"):
+		s.bottleHandler.PourBottle(w, r)
+		return
+	case strings.HasSuffix(r.URL.Path, "/pours"):
+		s.bottleHandler.GetBottlePours(w, r)
+		return
+	}
+
 	switch r.Method {
 	case http.MethodGet:
 		s.bottleHandler.GetBottle(w, r)
@@ -188,6 +262,15 @@ func (s *Server) handleMixersCollection(w http.ResponseWriter, r *http.Request)
 }
 
 func (s *Server) handleMixerResource(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/pour"):
+		s.mixerHandler.PourMixer(w, r)
+		return
+	case strings.HasSuffix(r.URL.Path, "/pours"):
+		s.mixerHandler.GetMixerPours(w, r)
+		return
+	}
+
 	switch r.Method {
 	case http.MethodGet:
 		s.mixerHandler.GetMixer(w, r)
@@ -224,6 +307,205 @@ func (s *Server) handleFreshResource(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (s *Server) handleBittersCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.bittersHandler.GetAllBitters(w, r)
+	case http.MethodPost:
+		s.bittersHandler.CreateBitters(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleBittersResource(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.bittersHandler.GetBitters(w, r)
+	case http.MethodDelete:
+		s.bittersHandler.DeleteBitters(w, r)
+	case http.MethodPut:
+		s.bittersHandler.UpdateBitters(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleSyrupsCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.syrupHandler.GetAllSyrups(w, r)
+	case http.MethodPost:
+		s.syrupHandler.CreateSyrup(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleSyrupResource(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/pour"):
+		s.syrupHandler.PourSyrup(w, r)
+		return
+	case strings.HasSuffix(r.URL.Path, "/pours"):
+		s.syrupHandler.GetSyrupPours(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.syrupHandler.GetSyrup(w, r)
+	case http.MethodDelete:
+		s.syrupHandler.DeleteSyrup(w, r)
+	case http.MethodPut:
+		s.syrupHandler.UpdateSyrup(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleGarnishesCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.garnishHandler.GetAllGarnishes(w, r)
+	case http.MethodPost:
+		s.garnishHandler.CreateGarnish(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleGarnishResource(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.garnishHandler.GetGarnish(w, r)
+	case http.MethodDelete:
+		s.garnishHandler.DeleteGarnish(w, r)
+	case http.MethodPut:
+		s.garnishHandler.UpdateGarnish(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleAIProfilesCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.aiHandler.GetProfiles(w, r)
+	case http.MethodPost:
+		s.aiHandler.CreateProfile(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleAIProfileResource(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.aiHandler.GetProfile(w, r)
+	case http.MethodDelete:
+		s.aiHandler.DeleteProfile(w, r)
+	case http.MethodPut:
+		s.aiHandler.UpdateProfile(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleCocktailsCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.cocktailHandler.GetAllCocktails(w, r)
+	case http.MethodPost:
+		s.cocktailHandler.CreateCocktail(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleCocktailResource(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/make"):
+		s.cocktailHandler.MakeCocktail(w, r)
+		return
+	case strings.HasSuffix(r.URL.Path, "/history"):
+		s.cocktailHandler.GetCocktailEvents(w, r)
+		return
+	case strings.HasSuffix(r.URL.Path, "/batch"):
+		s.cocktailHandler.GetCocktailBatch(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.cocktailHandler.GetCocktail(w, r)
+	case http.MethodDelete:
+		s.cocktailHandler.DeleteCocktail(w, r)
+	case http.MethodPut:
+		s.cocktailHandler.UpdateCocktail(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleShoppingListsCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.shoppingHandler.GetAllShoppingLists(w, r)
+	case http.MethodPost:
+		s.shoppingHandler.CreateShoppingList(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleShoppingListResource(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/convert"):
+		s.shoppingHandler.ConvertShoppingItem(w, r)
+		return
+	case strings.Contains(r.URL.Path, "/items/"):
+		s.shoppingHandler.CheckShoppingItem(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.shoppingHandler.GetShoppingList(w, r)
+	case http.MethodDelete:
+		s.shoppingHandler.DeleteShoppingList(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleIngredientsCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.ingredientHandler.GetAllIngredients(w, r)
+	case http.MethodPost:
+		s.ingredientHandler.CreateIngredient(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleIngredientResource(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/aliases") {
+		s.ingredientHandler.AddAlias(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.ingredientHandler.GetIngredient(w, r)
+	case http.MethodDelete:
+		s.ingredientHandler.DeleteIngredient(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
 func (s *Server) Start(port string) error {
 	if port == "" {
 		port = "8080"
@@ -0,0 +1,365 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+	"github.com/nguyenjessev/liquor-locker/internal/shopping"
+)
+
+type ShoppingHandler struct {
+	repo *repository.Repository
+}
+
+func NewShoppingHandler(repo *repository.Repository) *ShoppingHandler {
+	return &ShoppingHandler{repo: repo}
+}
+
+// CreateShoppingList godoc
+// @Summary      Create a shopping list
+// @Description  Builds a list of the ingredients the chosen recipes need but the inventory lacks, merging ingredients whose names match and adding up their quantities. Recipes are chosen by ID, by missing_one for every saved recipe missing exactly one ingredient, or both
+// @Tags         shopping-lists
+// @Accept       json
+// @Produce      json
+// @Param        list  body      models.CreateShoppingListRequest  true  "Recipes to shop for"
+// @Success      201   {object}  models.ShoppingList
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /api/shopping-lists [post]
+func (h *ShoppingHandler) CreateShoppingList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req models.CreateShoppingListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.CocktailIDs) == 0 && !req.MissingOne {
+		http.Error(w, "Choose cocktail_ids or missing_one", http.StatusBadRequest)
+		return
+	}
+
+	m, err := inventoryMatcher(r, h.repo)
+	if err != nil {
+		log.Printf("ERROR: loading inventory failed - error=%v", err)
+		http.Error(w, "Unable to load inventory. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	var cocktails []*models.Cocktail
+	seen := make(map[int]bool)
+	for _, id := range req.CocktailIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		cocktail, err := h.repo.GetCocktailByID(r.Context(), id)
+		if err != nil {
+			log.Printf("ERROR: GetCocktailByID failed - id=%d, error=%v", id, err)
+			if err == repository.ErrCocktailNotFound {
+				http.Error(w, fmt.Sprintf("Cocktail with ID %d not found", id), http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Unable to load cocktails. Please try again.", http.StatusInternalServerError)
+			return
+		}
+		cocktails = append(cocktails, cocktail)
+	}
+
+	if req.MissingOne {
+		all, err := h.repo.GetAllCocktails(r.Context())
+		if err != nil {
+			log.Printf("ERROR: GetAllCocktails failed - error=%v", err)
+			http.Error(w, "Unable to load cocktails. Please try again.", http.StatusInternalServerError)
+			return
+		}
+		for _, near := range m.Match(all).MissingOne {
+			if !seen[near.Cocktail.ID] {
+				seen[near.Cocktail.ID] = true
+				cocktails = append(cocktails, near.Cocktail)
+			}
+		}
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = "Shopping list"
+	}
+
+	list, err := h.repo.CreateShoppingList(r.Context(), &models.ShoppingList{
+		Name:  name,
+		Items: shopping.Build(cocktails, m),
+	})
+	if err != nil {
+		log.Printf("ERROR: CreateShoppingList failed - name=%s, error=%v", name, err)
+		http.Error(w, "Unable to save shopping list. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetAllShoppingLists godoc
+// @Summary      Get all shopping lists
+// @Description  Returns every saved shopping list with its items, newest first
+// @Tags         shopping-lists
+// @Produce      json
+// @Success      200  {array}   models.ShoppingList
+// @Failure      500  {object}  map[string]string
+// @Router       /api/shopping-lists [get]
+func (h *ShoppingHandler) GetAllShoppingLists(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	lists, err := h.repo.GetAllShoppingLists(r.Context())
+	if err != nil {
+		log.Printf("ERROR: GetAllShoppingLists failed - error=%v", err)
+		http.Error(w, "Unable to load shopping lists. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(lists); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetShoppingList godoc
+// @Summary      Get a shopping list
+// @Description  Returns a shopping list as JSON, a plain-text checklist or CSV
+// @Tags         shopping-lists
+// @Produce      json,plain,text/csv
+// @Param        id      path      int     true   "Shopping list ID"
+// @Param        format  query     string  false  "json (default), text or csv"
+// @Success      200     {object}  models.ShoppingList
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Router       /api/shopping-lists/{id} [get]
+func (h *ShoppingHandler) GetShoppingList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	listID, _, ok := shoppingIDsFromPath(w, r, "")
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "text" && format != "csv" {
+		http.Error(w, "format must be json, text or csv", http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.repo.GetShoppingListByID(r.Context(), listID)
+	if err != nil {
+		log.Printf("ERROR: GetShoppingListByID failed - id=%d, error=%v", listID, err)
+		if err == repository.ErrShoppingListNotFound {
+			http.Error(w, fmt.Sprintf("Shopping list with ID %d not found", listID), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Unable to retrieve shopping list. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	switch format {
+	case "text":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, shopping.Text(list))
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"shopping-list-%d.csv\"", list.ID))
+		if err := shopping.WriteCSV(w, list); err != nil {
+			log.Printf("ERROR: writing shopping list CSV failed - id=%d, error=%v", list.ID, err)
+		}
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(list); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// DeleteShoppingList godoc
+// @Summary      Delete a shopping list
+// @Description  Deletes a shopping list and its items
+// @Tags         shopping-lists
+// @Param        id   path      int  true  "Shopping list ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /api/shopping-lists/{id} [delete]
+func (h *ShoppingHandler) DeleteShoppingList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	listID, _, ok := shoppingIDsFromPath(w, r, "")
+	if !ok {
+		return
+	}
+
+	if err := h.repo.DeleteShoppingListByID(r.Context(), listID); err != nil {
+		log.Printf("ERROR: DeleteShoppingListByID failed - id=%d, error=%v", listID, err)
+		if err == repository.ErrShoppingListNotFound {
+			http.Error(w, fmt.Sprintf("Shopping list with ID %d not found", listID), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Unable to delete shopping list. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CheckShoppingItem godoc
+// @Summary      Check off a shopping list item
+// @Description  Marks a shopping list item as bought, or clears the mark
+// @Tags         shopping-lists
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                              true  "Shopping list ID"
+// @Param        itemId  path      int                              true  "Item ID"
+// @Param        item    body      models.CheckShoppingItemRequest  true  "Checked state"
+// @Success      200     {object}  models.ShoppingListItem
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Router       /api/shopping-lists/{id}/items/{itemId} [put]
+func (h *ShoppingHandler) CheckShoppingItem(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	listID, itemID, ok := shoppingIDsFromPath(w, r, "")
+	if !ok {
+		return
+	}
+
+	var req models.CheckShoppingItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	item, err := h.repo.SetShoppingItemChecked(r.Context(), listID, itemID, req.Checked)
+	if err != nil {
+		log.Printf("ERROR: SetShoppingItemChecked failed - list_id=%d, item_id=%d, error=%v", listID, itemID, err)
+		if err == repository.ErrShoppingItemNotFound {
+			http.Error(w, fmt.Sprintf("Item with ID %d not found on shopping list %d", itemID, listID), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Unable to update shopping list item. Please try again.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(item); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ConvertShoppingItem godoc
+// @Summary      Add a bought item to the inventory
+// @Description  Creates a bottle, mixer, fresh item, bitters, syrup or garnish named after the shopping list item and checks the item off. Each item can be converted once
+// @Tags         shopping-lists
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                                true  "Shopping list ID"
+// @Param        itemId   path      int                                true  "Item ID"
+// @Param        convert  body      models.ConvertShoppingItemRequest  true  "Inventory kind and purchase details"
+// @Success      201      {object}  models.ShoppingListItem
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Router       /api/shopping-lists/{id}/items/{itemId}/convert [post]
+func (h *ShoppingHandler) ConvertShoppingItem(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	listID, itemID, ok := shoppingIDsFromPath(w, r, "/convert")
+	if !ok {
+		return
+	}
+
+	var req models.ConvertShoppingItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	item, err := h.repo.ConvertShoppingItem(r.Context(), listID, itemID, req)
+	if err != nil {
+		log.Printf("ERROR: ConvertShoppingItem failed - list_id=%d, item_id=%d, kind=%s, error=%v", listID, itemID, req.Kind, err)
+		switch err {
+		case repository.ErrInvalidInventoryKind:
+			http.Error(w, "Kind must be bottle, mixer, fresh, bitters, syrup or garnish", http.StatusBadRequest)
+		case repository.ErrShoppingItemNotFound:
+			http.Error(w, fmt.Sprintf("Item with ID %d not found on shopping list %d", itemID, listID), http.StatusNotFound)
+		case repository.ErrShoppingItemConverted:
+			http.Error(w, "Item has already been added to the inventory", http.StatusConflict)
+		default:
+			http.Error(w, "Unable to add item to the inventory. Please try again.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(item); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// shoppingIDsFromPath extracts the list ID, and the item ID when present, from
+// /api/shopping-lists/{id}[/items/{itemId}]{suffix}, writing a 400 response
+// and returning false when either is malformed.
+func shoppingIDsFromPath(w http.ResponseWriter, r *http.Request, suffix string) (int, int, bool) {
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/shopping-lists/"), suffix)
+	listPart, itemPart, hasItem := strings.Cut(path, "/items/")
+
+	listID, err := strconv.Atoi(listPart)
+	if err != nil {
+		http.Error(w, "Invalid shopping list ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+
+	if !hasItem {
+		return listID, 0, true
+	}
+
+	itemID, err := strconv.Atoi(itemPart)
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+
+	return listID, itemID, true
+}
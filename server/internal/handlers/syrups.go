@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+)
+
+type SyrupHandler struct {
+	items *inventoryHandler[models.Syrup, models.CreateSyrupRequest, models.UpdateSyrupRequest, models.SyrupResponse]
+}
+
+func NewSyrupHandler(repo *repository.Repository) *SyrupHandler {
+	return &SyrupHandler{items: newInventoryHandler(repo, repo.Syrups(), syrupKind)}
+}
+
+var syrupKind = inventoryKind[models.Syrup, models.CreateSyrupRequest, models.UpdateSyrupRequest, models.SyrupResponse]{
+	name:     "syrup",
+	noun:     "syrup",
+	plural:   "syrups",
+	path:     "/api/syrups/",
+	notFound: repository.ErrSyrupNotFound,
+	errNil:   repository.ErrNilSyrup,
+	fromCreate: func(req *models.CreateSyrupRequest) *models.Syrup {
+		return &models.Syrup{
+			Name:         req.Name,
+			Ratio:        req.Ratio,
+			MadeDate:     req.MadeDate,
+			PurchaseDate: req.PurchaseDate,
+			Price:        req.Price,
+			SizeML:       req.SizeML,
+			RemainingML:  req.RemainingML,
+		}
+	},
+	fromUpdate: func(req *models.UpdateSyrupRequest) *models.Syrup {
+		return &models.Syrup{
+			Name:         req.Name,
+			Ratio:        req.Ratio,
+			MadeDate:     req.MadeDate,
+			PurchaseDate: req.PurchaseDate,
+			Price:        req.Price,
+			SizeML:       req.SizeML,
+			RemainingML:  req.RemainingML,
+		}
+	},
+	response: func(syrup *models.Syrup) models.SyrupResponse {
+		return models.SyrupResponse{
+			ID:           syrup.ID,
+			Name:         syrup.Name,
+			Ratio:        syrup.Ratio,
+			MadeDate:     syrup.MadeDate,
+			PurchaseDate: syrup.PurchaseDate,
+			Price:        syrup.Price,
+			SizeML:       syrup.SizeML,
+			RemainingML:  syrup.RemainingML,
+			Finished:     syrup.Finished,
+			FinishedAt:   syrup.FinishedAt,
+		}
+	},
+}
+
+// CreateSyrup godoc
+// @Summary      Create a syrup
+// @Description  Adds a syrup to the collection
+// @Tags         syrups
+// @Accept       json
+// @Produce      json
+// @Param        syrup body models.CreateSyrupRequest true "Syrup to create"
+// @Success      201 {object} models.SyrupResponse
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /syrups [post]
+func (h *SyrupHandler) CreateSyrup(w http.ResponseWriter, r *http.Request) {
+	h.items.create(w, r)
+}
+
+// GetSyrup godoc
+// @Summary      Get a syrup by ID
+// @Description  Returns the syrup with the given ID
+// @Tags         syrups
+// @Produce      json
+// @Param        id path int true "Syrup ID"
+// @Success      200 {object} models.SyrupResponse
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /syrups/{id} [get]
+func (h *SyrupHandler) GetSyrup(w http.ResponseWriter, r *http.Request) {
+	h.items.get(w, r)
+}
+
+// DeleteSyrup godoc
+// @Summary      Delete a syrup
+// @Description  Deletes the syrup with the given ID
+// @Tags         syrups
+// @Param        id path int true "Syrup ID"
+// @Success      204 {object} nil
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /syrups/{id} [delete]
+func (h *SyrupHandler) DeleteSyrup(w http.ResponseWriter, r *http.Request) {
+	h.items.delete(w, r)
+}
+
+// UpdateSyrup godoc
+// @Summary      Update a syrup
+// @Description  Replaces the syrup's fields. A ratio, size or remaining volume left out is kept.
+// @Tags         syrups
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Syrup ID"
+// @Param        syrup body models.UpdateSyrupRequest true "Updated syrup"
+// @Success      200 {object} models.SyrupResponse
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /syrups/{id} [put]
+func (h *SyrupHandler) UpdateSyrup(w http.ResponseWriter, r *http.Request) {
+	h.items.update(w, r)
+}
+
+// GetAllSyrups godoc
+// @Summary      Get all syrups
+// @Description  Returns a list of syrups, newest first unless sorted, optionally filtered and paged. When there are more pages, the X-Next-Cursor and Link headers point to the next one.
+// @Tags         syrups
+// @Produce      json
+// @Param        name query string false "Name contains, ignoring case"
+// @Param        purchased_after query string false "Purchased after this date (YYYY-MM-DD or RFC 3339)"
+// @Param        min_price query number false "Minimum price"
+// @Param        max_price query number false "Maximum price"
+// @Param        sort query string false "name, price or open_date (the made date); prefix with - for descending"
+// @Param        limit query int false "Page size, 1 to 500"
+// @Param        cursor query string false "Cursor from the previous page's X-Next-Cursor header"
+// @Success      200 {array} models.SyrupResponse
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /syrups [get]
+func (h *SyrupHandler) GetAllSyrups(w http.ResponseWriter, r *http.Request) {
+	h.items.list(w, r)
+}
+
+// PourSyrup godoc
+// @Summary      Pour from a syrup
+// @Description  Decrements the syrup's remaining volume, records the pour, and marks the syrup finished when it reaches zero. A pour larger than what is left only takes what is left; a finished syrup can't be poured from
+// @Tags         syrups
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                 true  "Syrup ID"
+// @Param        pour  body      models.PourRequest  true  "Amount poured in ml"
+// @Success      200   {object}  models.SyrupResponse
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Router       /syrups/{id}/pour [post]
+func (h *SyrupHandler) PourSyrup(w http.ResponseWriter, r *http.Request) {
+	h.items.pour(w, r)
+}
+
+// GetSyrupPours godoc
+// @Summary      Get a syrup's pour history
+// @Description  Returns every recorded pour from the syrup, newest first
+// @Tags         syrups
+// @Produce      json
+// @Param        id   path      int  true  "Syrup ID"
+// @Success      200  {array}   models.Pour
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /syrups/{id}/pours [get]
+func (h *SyrupHandler) GetSyrupPours(w http.ResponseWriter, r *http.Request) {
+	h.items.pours(w, r)
+}
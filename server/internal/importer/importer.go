@@ -0,0 +1,254 @@
+// Package importer reads inventory uploads in CSV or JSON into the same
+// request structs the API decodes, one row at a time, so a bad row can be
+// reported without giving up on the rest.
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is the encoding of an upload.
+type Format string
+
+const (
+	CSV  Format = "csv"
+	JSON Format = "json"
+)
+
+var (
+	ErrInvalidFormat = errors.New("format must be csv or json")
+	ErrEmpty         = errors.New("upload has no rows")
+	ErrNoNameColumn  = errors.New("upload has no name column")
+)
+
+// ParseFormat reads a format query value.
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(s))); format {
+	case CSV, JSON:
+		return format, nil
+	}
+	return "", ErrInvalidFormat
+}
+
+// Row is one row of an upload, numbered from 1 not counting the CSV header.
+// Err says why the row couldn't be read into Item.
+type Row[T any] struct {
+	Number int
+	Item   T
+	Err    error
+}
+
+// Read decodes every row of r into a T, matching columns and JSON keys to
+// T's json field names. A JSON upload is an array of objects. A CSV upload
+// starts with a header row; mapping renames headers to field names, or to
+// "-" to skip the column, and other headers match a field by name, ignoring
+// case and with spaces for underscores. Every row needs a name.
+//
+// Read fails as a whole only if the upload can't be read at all, such as
+// malformed JSON or a header naming no field; problems with a single row are
+// left in its Err.
+func Read[T any](r io.Reader, format Format, mapping map[string]string) ([]Row[T], error) {
+	fields := jsonFields(reflect.TypeFor[T]())
+	if _, ok := fields["name"]; !ok {
+		return nil, ErrNoNameColumn
+	}
+
+	var rows []Row[T]
+	var err error
+	switch format {
+	case CSV:
+		rows, err = readCSV[T](r, fields, mapping)
+	case JSON:
+		rows, err = readJSON[T](r)
+	default:
+		return nil, ErrInvalidFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmpty
+	}
+
+	for i := range rows {
+		if rows[i].Err != nil {
+			continue
+		}
+		name := reflect.ValueOf(&rows[i].Item).Elem().FieldByIndex(fields["name"])
+		if strings.TrimSpace(name.String()) == "" {
+			rows[i].Err = errors.New("name is required")
+		}
+	}
+	return rows, nil
+}
+
+func readJSON[T any](r io.Reader) ([]Row[T], error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return nil, fmt.Errorf("invalid JSON: %v", err)
+		}
+		return nil, errors.New("upload must be a JSON array of objects")
+	}
+
+	rows := make([]Row[T], len(raw))
+	for i, item := range raw {
+		rows[i].Number = i + 1
+		dec := json.NewDecoder(strings.NewReader(string(item)))
+		dec.DisallowUnknownFields()
+		rows[i].Err = dec.Decode(&rows[i].Item)
+	}
+	return rows, nil
+}
+
+func readCSV[T any](r io.Reader, fields map[string][]int, mapping map[string]string) ([]Row[T], error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrEmpty
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %v", err)
+	}
+
+	// columns[i] is the field of the i-th column, or nil to skip it.
+	columns := make([][]int, len(header))
+	for i, h := range header {
+		// Spreadsheets often start CSV exports with a byte order mark.
+		h = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+		name, mapped := mapping[h]
+		if !mapped {
+			name = strings.ReplaceAll(strings.ToLower(h), " ", "_")
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+		index, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("column %q doesn't match any field", h)
+		}
+		columns[i] = index
+	}
+
+	var rows []Row[T]
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		row := Row[T]{Number: len(rows) + 1}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && parseErr.Err == csv.ErrFieldCount {
+			row.Err = fmt.Errorf("has %d columns, want %d", len(record), len(header))
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %v", err)
+		} else {
+			row.Err = setFields(reflect.ValueOf(&row.Item).Elem(), header, columns, record)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func setFields(item reflect.Value, header []string, columns [][]int, record []string) error {
+	for i, value := range record {
+		if columns[i] == nil {
+			continue
+		}
+		if err := set(item.FieldByIndex(columns[i]), strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("%s: %v", strings.TrimSpace(header[i]), err)
+		}
+	}
+	return nil
+}
+
+// jsonFields indexes the exported fields of struct type t by their json
+// name.
+func jsonFields(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		fields[name] = f.Index
+	}
+	return fields
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// set parses s into v. An empty s leaves v alone.
+func set(v reflect.Value, s string) error {
+	if s == "" {
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		p := reflect.New(v.Type().Elem())
+		if err := set(p.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	}
+
+	if v.Type() == timeType {
+		t, err := parseDate(s)
+		if err != nil {
+			return fmt.Errorf("%q is not a date, use YYYY-MM-DD or RFC 3339", s)
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", s)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", s)
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", s)
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("can't import a %s", v.Type())
+	}
+	return nil
+}
+
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
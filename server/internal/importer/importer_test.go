@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type item struct {
+	Name         string     `json:"name"`
+	Opened       bool       `json:"opened"`
+	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
+	Price        *float64   `json:"price,omitempty"`
+	Dashes       *int       `json:"dashes_remaining,omitempty"`
+}
+
+func TestRead_CSV(t *testing.T) {
+	upload := "\ufeffName,Opened,Purchase Date,Cost,Notes\n" +
+		"Rittenhouse Rye,true,2025-03-01,29.99,gift\n" +
+		"Campari,false,,,\n"
+
+	rows, err := Read[item](strings.NewReader(upload), CSV, map[string]string{"Cost": "price", "Notes": "-"})
+	if err != nil {
+		t.Fatalf("Read() error = %v, want nil", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Read() returned %d rows, want 2", len(rows))
+	}
+
+	rye := rows[0]
+	if rye.Err != nil {
+		t.Fatalf("row 1 error = %v, want nil", rye.Err)
+	}
+	if rye.Item.Name != "Rittenhouse Rye" || !rye.Item.Opened {
+		t.Errorf("row 1 = %+v, want opened Rittenhouse Rye", rye.Item)
+	}
+	if rye.Item.Price == nil || *rye.Item.Price != 29.99 {
+		t.Errorf("row 1 price = %v, want 29.99 from the mapped Cost column", rye.Item.Price)
+	}
+	if rye.Item.PurchaseDate == nil || !rye.Item.PurchaseDate.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("row 1 purchase date = %v, want 2025-03-01", rye.Item.PurchaseDate)
+	}
+
+	campari := rows[1]
+	if campari.Err != nil || campari.Number != 2 || campari.Item.Price != nil || campari.Item.PurchaseDate != nil {
+		t.Errorf("row 2 = %+v, want Campari with empty cells left nil", campari)
+	}
+}
+
+func TestRead_CSVRowErrors(t *testing.T) {
+	upload := "name,price,dashes_remaining\n" +
+		"Angostura,12,abc\n" +
+		",5,\n" +
+		"Peychaud's,9\n" +
+		"Regans,$8,\n" +
+		"Fee Brothers,7,100\n"
+
+	rows, err := Read[item](strings.NewReader(upload), CSV, nil)
+	if err != nil {
+		t.Fatalf("Read() error = %v, want nil", err)
+	}
+
+	want := []string{
+		`dashes_remaining: "abc" is not a whole number`,
+		"name is required",
+		"has 2 columns, want 3",
+		`price: "$8" is not a number`,
+		"",
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("Read() returned %d rows, want %d", len(rows), len(want))
+	}
+	for i, row := range rows {
+		got := ""
+		if row.Err != nil {
+			got = row.Err.Error()
+		}
+		if got != want[i] {
+			t.Errorf("row %d error = %q, want %q", row.Number, got, want[i])
+		}
+	}
+}
+
+func TestRead_CSVUnknownColumn(t *testing.T) {
+	_, err := Read[item](strings.NewReader("name,colour\nRum,gold\n"), CSV, nil)
+	if err == nil || !strings.Contains(err.Error(), `"colour"`) {
+		t.Errorf("Read() error = %v, want one naming the colour column", err)
+	}
+}
+
+func TestRead_JSON(t *testing.T) {
+	upload := `[
+		{"name": "Tonic Water", "price": 4.5},
+		{"name": "Soda", "colour": "clear"},
+		{"name": "Ginger Beer", "price": "cheap"},
+		{"price": 2}
+	]`
+
+	rows, err := Read[item](strings.NewReader(upload), JSON, nil)
+	if err != nil {
+		t.Fatalf("Read() error = %v, want nil", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("Read() returned %d rows, want 4", len(rows))
+	}
+
+	if rows[0].Err != nil || rows[0].Item.Name != "Tonic Water" || *rows[0].Item.Price != 4.5 {
+		t.Errorf("row 1 = %+v, want Tonic Water at 4.5", rows[0])
+	}
+	for _, row := range rows[1:] {
+		if row.Err == nil {
+			t.Errorf("row %d error = nil, want an error", row.Number)
+		}
+	}
+}
+
+func TestRead_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		upload string
+		format Format
+		want   error
+	}{
+		{"empty CSV", "", CSV, ErrEmpty},
+		{"header only", "name,price\n", CSV, ErrEmpty},
+		{"empty array", "[]", JSON, ErrEmpty},
+		{"unknown format", "[]", Format("xml"), ErrInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Read[item](strings.NewReader(tt.upload), tt.format, nil); err != tt.want {
+				t.Errorf("Read() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := Read[item](strings.NewReader(`{"name": "Rum"}`), JSON, nil); err == nil {
+		t.Error("Read() of a JSON object error = nil, want an error")
+	}
+}
@@ -0,0 +1,357 @@
+// Package matcher compares saved cocktail recipes against the bar inventory
+// without involving an LLM.
+package matcher
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+// stopwords are descriptive tokens that don't change which item an
+// ingredient refers to.
+var stopwords = map[string]bool{
+	"a":        true,
+	"an":       true,
+	"and":      true,
+	"of":       true,
+	"the":      true,
+	"fresh":    true,
+	"freshly":  true,
+	"squeezed": true,
+	"chilled":  true,
+	"cold":     true,
+}
+
+// productWords turn a name into a different product when added to it: an
+// "Orange Liqueur" or "Orange Bitters" is no orange, and sloe gin is a
+// liqueur rather than a gin. Words are in the singular form tokens gives.
+var productWords = map[string]bool{
+	"bitter":  true,
+	"cordial": true,
+	"cream":   true,
+	"juice":   true,
+	"liqueur": true,
+	"peel":    true,
+	"schnapp": true,
+	"sloe":    true,
+	"soda":    true,
+	"syrup":   true,
+	"twist":   true,
+	"wedge":   true,
+	"wheel":   true,
+	"zest":    true,
+}
+
+// staples are ingredients every bar is assumed to have on hand.
+var staples = map[string]bool{
+	"ice":         true,
+	"crushed ice": true,
+	"cubed ice":   true,
+	"ice cube":    true,
+	"water":       true,
+	"hot water":   true,
+}
+
+// Normalize lowercases a name, drops punctuation and collapses whitespace so
+// that free-text names can be compared.
+func Normalize(name string) string {
+	return strings.Join(tokens(name), " ")
+}
+
+// Key is like Normalize but also drops stopwords, so "Fresh Lime Juice" and
+// "Lime Juice" have the same key.
+func Key(name string) string {
+	var words []string
+	for _, token := range tokens(name) {
+		if !stopwords[token] {
+			words = append(words, token)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// tokens splits a name into lowercase words with simple plurals removed.
+func tokens(name string) []string {
+	name = strings.NewReplacer("'", "", "’", "").Replace(strings.ToLower(name))
+	fields := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	result := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len(field) > 3 && strings.HasSuffix(field, "s") && !strings.HasSuffix(field, "ss") {
+			field = strings.TrimSuffix(field, "s")
+		}
+		result = append(result, field)
+	}
+	return result
+}
+
+// keywords returns the significant tokens of a name as a set.
+func keywords(name string) map[string]bool {
+	set := make(map[string]bool)
+	for _, token := range tokens(name) {
+		if !stopwords[token] {
+			set[token] = true
+		}
+	}
+	return set
+}
+
+func subset(a, b map[string]bool) bool {
+	if len(a) == 0 {
+		return false
+	}
+	for token := range a {
+		if !b[token] {
+			return false
+		}
+	}
+	return true
+}
+
+// NameMatches reports whether an inventory item can stand in for a recipe
+// ingredient. The item's name must contain every keyword of the ingredient,
+// and none of the words it adds may name another product, so "Hendrick's
+// Gin" can be used for "Gin" but "Sloe Gin" can't, and neither "Orange
+// Liqueur" nor "Orange Bitters" can be used for "Orange". A generic item
+// standing in for a specific ingredient is left to the catalog.
+func NameMatches(ingredient, item string) bool {
+	want, have := keywords(ingredient), keywords(item)
+	if !subset(want, have) {
+		return false
+	}
+	for token := range have {
+		if !want[token] && productWords[token] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsProductWord reports whether adding word to a name makes it name a
+// different product, as "liqueur" does to "Orange". word must be one of the
+// words of a normalized name.
+func IsProductWord(word string) bool {
+	return productWords[word]
+}
+
+// IsStaple reports whether an ingredient is assumed to always be available.
+func IsStaple(ingredient string) bool {
+	return staples[Normalize(ingredient)]
+}
+
+// Item is an inventory entry that can satisfy recipe ingredients.
+type Item struct {
+	// Kind and ID identify the inventory row ("bottle", "mixer", "fresh",
+	// "bitters", "syrup" or "garnish").
+	Kind string
+	ID   int64
+	Name string
+	// IngredientID is the catalog ingredient the item is linked to, if any.
+	IngredientID *int64
+}
+
+// Resolver maps names onto a canonical ingredient hierarchy. It is satisfied
+// by *catalog.Catalog.
+type Resolver interface {
+	// ResolveID resolves an inventory item's name, which may be a brand or
+	// carry extra words, as in "Buffalo Trace Bourbon".
+	ResolveID(name string) (int64, bool)
+	// ResolveExactID resolves a recipe ingredient only by its exact name or
+	// alias. Falling back to part of the name would turn "Orange Juice" into
+	// Juice, which any juice satisfies.
+	ResolveExactID(name string) (int64, bool)
+	Satisfies(have, want int64) bool
+}
+
+// Matcher answers availability questions against a fixed inventory.
+type Matcher struct {
+	inventory []Item
+	resolver  Resolver
+}
+
+// New creates a Matcher over the given inventory items.
+func New(inventory []Item) *Matcher {
+	return &Matcher{inventory: inventory}
+}
+
+// WithResolver makes the matcher fall back to the ingredient catalog when
+// names don't match directly, so "Buffalo Trace" can satisfy "Bourbon" or
+// "Whiskey".
+func (m *Matcher) WithResolver(resolver Resolver) *Matcher {
+	m.resolver = resolver
+	return m
+}
+
+// Find returns the inventory item that best satisfies the ingredient, as
+// FindAll orders them.
+func (m *Matcher) Find(ingredient string) (Item, bool) {
+	items := m.FindAll(ingredient)
+	if len(items) == 0 {
+		return Item{}, false
+	}
+	return items[0], true
+}
+
+// FindAll returns every inventory item that satisfies the ingredient, best
+// first: items with the same name, then items the catalog says will do, and
+// only then items whose name contains the ingredient's. Items that match
+// equally well keep their inventory order.
+func (m *Matcher) FindAll(ingredient string) []Item {
+	key := Key(ingredient)
+	var want int64
+	var resolved bool
+	if m.resolver != nil {
+		want, resolved = m.resolver.ResolveExactID(ingredient)
+	}
+
+	var sameName, inCatalog, containsName []Item
+	for _, item := range m.inventory {
+		switch {
+		case key != "" && Key(item.Name) == key:
+			sameName = append(sameName, item)
+		case resolved && m.satisfies(item, want):
+			inCatalog = append(inCatalog, item)
+		case NameMatches(ingredient, item.Name):
+			containsName = append(containsName, item)
+		}
+	}
+	return slices.Concat(sameName, inCatalog, containsName)
+}
+
+// SameIngredient reports whether two ingredient names ask for the same
+// thing: their keys are equal, as for "Lime Juice" and "Fresh Lime Juice", or
+// the catalog resolves both exactly to the same entry, as for "Rye" and "Rye
+// Whiskey".
+func (m *Matcher) SameIngredient(a, b string) bool {
+	if key := Key(a); key != "" && key == Key(b) {
+		return true
+	}
+	if m.resolver == nil {
+		return false
+	}
+	idA, okA := m.resolver.ResolveExactID(a)
+	idB, okB := m.resolver.ResolveExactID(b)
+	return okA && okB && idA == idB
+}
+
+// satisfies reports whether the catalog says the item will do for the
+// ingredient want.
+func (m *Matcher) satisfies(item Item, want int64) bool {
+	have, ok := m.itemIngredientID(item)
+	return ok && m.resolver.Satisfies(have, want)
+}
+
+// itemIngredientID prefers an item's explicit catalog link over resolving its
+// name.
+func (m *Matcher) itemIngredientID(item Item) (int64, bool) {
+	if item.IngredientID != nil {
+		return *item.IngredientID, true
+	}
+	return m.resolver.ResolveID(item.Name)
+}
+
+// Has reports whether the ingredient is a staple or present in the inventory.
+func (m *Matcher) Has(ingredient string) bool {
+	if IsStaple(ingredient) {
+		return true
+	}
+	_, ok := m.Find(ingredient)
+	return ok
+}
+
+// Missing returns the recipe ingredients that the inventory cannot supply.
+func (m *Matcher) Missing(cocktail *models.Cocktail) []models.Ingredient {
+	var missing []models.Ingredient
+	for _, ingredient := range cocktail.Ingredients {
+		if !m.Has(ingredient.Name) {
+			missing = append(missing, ingredient)
+		}
+	}
+	return missing
+}
+
+// NearMiss is a recipe that lacks exactly one ingredient.
+type NearMiss struct {
+	Cocktail *models.Cocktail
+	Missing  models.Ingredient
+}
+
+// Result groups recipes by how close they are to being makeable.
+type Result struct {
+	Makeable   []*models.Cocktail
+	MissingOne []NearMiss
+}
+
+// Match sorts the cocktails into fully makeable recipes and recipes that are
+// missing a single ingredient. Anything missing more is left out.
+func (m *Matcher) Match(cocktails []*models.Cocktail) Result {
+	var result Result
+	for _, cocktail := range cocktails {
+		missing := m.Missing(cocktail)
+		switch len(missing) {
+		case 0:
+			result.Makeable = append(result.Makeable, cocktail)
+		case 1:
+			result.MissingOne = append(result.MissingOne, NearMiss{Cocktail: cocktail, Missing: missing[0]})
+		}
+	}
+	return result
+}
+
+// Stock is the bar's inventory, by kind.
+type Stock struct {
+	Bottles   []*models.Bottle
+	Mixers    []*models.Mixer
+	Fresh     []*models.Fresh
+	Bitters   []*models.Bitters
+	Syrups    []*models.Syrup
+	Garnishes []*models.Garnish
+}
+
+// Inventory collects the items of stock that are in stock as matchable
+// items. Finished items and those with nothing left are skipped.
+func Inventory(stock Stock) []Item {
+	var items []Item
+	for _, bottle := range stock.Bottles {
+		if inStock(bottle.Finished, bottle.RemainingML) {
+			items = append(items, Item{Kind: "bottle", ID: bottle.ID, Name: bottle.Name, IngredientID: bottle.IngredientID})
+		}
+	}
+	for _, mixer := range stock.Mixers {
+		if inStock(mixer.Finished, mixer.RemainingML) {
+			items = append(items, Item{Kind: "mixer", ID: mixer.ID, Name: mixer.Name, IngredientID: mixer.IngredientID})
+		}
+	}
+	for _, item := range stock.Fresh {
+		if inStock(item.Finished, item.RemainingML) {
+			items = append(items, Item{Kind: "fresh", ID: item.ID, Name: item.Name, IngredientID: item.IngredientID})
+		}
+	}
+	for _, bitters := range stock.Bitters {
+		if bitters.DashesRemaining == nil || *bitters.DashesRemaining > 0 {
+			items = append(items, Item{Kind: "bitters", ID: bitters.ID, Name: bitters.Name})
+		}
+	}
+	for _, syrup := range stock.Syrups {
+		if inStock(syrup.Finished, syrup.RemainingML) {
+			items = append(items, Item{Kind: "syrup", ID: syrup.ID, Name: syrup.Name})
+		}
+	}
+	for _, garnish := range stock.Garnishes {
+		if garnish.Quantity == nil || *garnish.Quantity > 0 {
+			items = append(items, Item{Kind: "garnish", ID: garnish.ID, Name: garnish.Name})
+		}
+	}
+	return items
+}
+
+// inStock reports whether an item can still be used. Items without volume
+// tracking are in stock until they are marked finished.
+func inStock(finished bool, remainingML *float64) bool {
+	return !finished && (remainingML == nil || *remainingML > 0)
+}
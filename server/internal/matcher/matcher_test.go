@@ -0,0 +1,266 @@
+package matcher
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+func items(names ...string) []Item {
+	result := make([]Item, 0, len(names))
+	for _, name := range names {
+		result = append(result, Item{Name: name})
+	}
+	return result
+}
+
+func TestNameMatches(t *testing.T) {
+	tests := []struct {
+		ingredient string
+		item       string
+		want       bool
+	}{
+		{"Gin", "Hendrick's Gin", true},
+		{"gin", "GIN", true},
+		{"Sweet Vermouth", "Carpano Antica Sweet Vermouth", true},
+		{"Angostura", "Angostura Bitters", false},
+		{"Angostura Bitters", "Angostura", false},
+		{"Orange Liqueur", "Orange", false},
+		{"Orange", "Orange Liqueur", false},
+		{"Orange", "Orange Bitters", false},
+		{"Gin", "Sloe Gin", false},
+		{"Lime Juice", "Lime", false},
+		{"Lime", "Lime Juice", false},
+		{"Orange Liqueur", "Pierre Ferrand Orange Liqueur", true},
+		{"Fresh Lime Juice", "Lime Juice", true},
+		{"Limes", "Lime", true},
+		{"Lime Juice", "Lemon Juice", false},
+		{"Dry Vermouth", "Sweet Vermouth", false},
+		{"Bourbon", "Buffalo Trace", false},
+		{"", "Gin", false},
+	}
+
+	for _, tt := range tests {
+		if got := NameMatches(tt.ingredient, tt.item); got != tt.want {
+			t.Errorf("NameMatches(%q, %q) = %v, want %v", tt.ingredient, tt.item, got, tt.want)
+		}
+	}
+}
+
+func TestHas_Staples(t *testing.T) {
+	m := New(nil)
+
+	for _, name := range []string{"Ice", "crushed ice", "Water"} {
+		if !m.Has(name) {
+			t.Errorf("Has(%q) = false, want true for staple", name)
+		}
+	}
+
+	if m.Has("Tonic Water") {
+		t.Error("Has(\"Tonic Water\") = true, want false")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	negroni := &models.Cocktail{
+		Name: "Negroni",
+		Ingredients: []models.Ingredient{
+			{Name: "Gin"},
+			{Name: "Campari"},
+			{Name: "Sweet Vermouth"},
+		},
+	}
+	daiquiri := &models.Cocktail{
+		Name: "Daiquiri",
+		Ingredients: []models.Ingredient{
+			{Name: "White Rum"},
+			{Name: "Lime Juice"},
+			{Name: "Simple Syrup"},
+		},
+	}
+	gimlet := &models.Cocktail{
+		Name: "Gimlet",
+		Ingredients: []models.Ingredient{
+			{Name: "Gin"},
+			{Name: "Lime Juice"},
+			{Name: "Simple Syrup"},
+			{Name: "Ice"},
+		},
+	}
+
+	m := New(items("Beefeater Gin", "Campari", "Lime Juice", "Simple Syrup"))
+	result := m.Match([]*models.Cocktail{negroni, daiquiri, gimlet})
+
+	if len(result.Makeable) != 1 || result.Makeable[0] != gimlet {
+		t.Errorf("Match() makeable = %v, want only Gimlet", result.Makeable)
+	}
+
+	if len(result.MissingOne) != 2 {
+		t.Fatalf("Match() missing one = %d recipes, want 2", len(result.MissingOne))
+	}
+
+	want := map[string]string{
+		"Negroni":  "Sweet Vermouth",
+		"Daiquiri": "White Rum",
+	}
+	for _, near := range result.MissingOne {
+		if want[near.Cocktail.Name] != near.Missing.Name {
+			t.Errorf("Match() %s missing = %q, want %q", near.Cocktail.Name, near.Missing.Name, want[near.Cocktail.Name])
+		}
+	}
+}
+
+// stubResolver knows bourbon (2) is a whiskey (1), and lime juice (4) is a
+// juice (3) and campari (6) a liqueur (5). Like the catalog, it resolves
+// names it doesn't know to their last word's entry unless asked for an exact
+// match.
+type stubResolver struct{}
+
+var stubNames = map[string]int64{
+	"whiskey":       1,
+	"bourbon":       2,
+	"buffalo trace": 2,
+	"juice":         3,
+	"lime juice":    4,
+	"liqueur":       5,
+	"campari":       6,
+}
+
+func (stubResolver) ResolveID(name string) (int64, bool) {
+	if id, ok := stubNames[Normalize(name)]; ok {
+		return id, true
+	}
+	words := tokens(name)
+	if len(words) == 0 {
+		return 0, false
+	}
+	id, ok := stubNames[words[len(words)-1]]
+	return id, ok
+}
+
+func (stubResolver) ResolveExactID(name string) (int64, bool) {
+	id, ok := stubNames[Key(name)]
+	return id, ok
+}
+
+func (stubResolver) Satisfies(have, want int64) bool {
+	parents := map[int64]int64{2: 1, 4: 3, 6: 5}
+	return have == want || parents[have] == want
+}
+
+func TestFind_WithResolver(t *testing.T) {
+	linked := int64(2)
+	m := New([]Item{{Name: "Buffalo Trace"}, {Name: "House Pour", IngredientID: &linked}})
+
+	if _, ok := m.Find("Whiskey"); ok {
+		t.Error("Find(\"Whiskey\") matched without a resolver")
+	}
+
+	m.WithResolver(stubResolver{})
+
+	item, ok := m.Find("Whiskey")
+	if !ok || item.Name != "Buffalo Trace" {
+		t.Errorf("Find(\"Whiskey\") = %v, %v, want Buffalo Trace", item, ok)
+	}
+
+	m = New([]Item{{Name: "House Pour", IngredientID: &linked}}).WithResolver(stubResolver{})
+	if _, ok := m.Find("Bourbon"); !ok {
+		t.Error("Find(\"Bourbon\") did not use the item's catalog link")
+	}
+}
+
+func TestFind_SpecificIngredientNotSatisfiedBySibling(t *testing.T) {
+	m := New(items("Lime Juice", "Campari")).WithResolver(stubResolver{})
+
+	for _, ingredient := range []string{"Orange Juice", "Grapefruit Juice", "Maraschino Liqueur"} {
+		if item, ok := m.Find(ingredient); ok {
+			t.Errorf("Find(%q) = %s, want no match", ingredient, item.Name)
+		}
+	}
+
+	if _, ok := m.Find("Juice"); !ok {
+		t.Error("Find(\"Juice\") = no match, want Lime Juice through the resolver")
+	}
+}
+
+func TestFind_PrefersSameName(t *testing.T) {
+	m := New(items("Sloe Gin", "Hendrick's Gin", "Gin", "Orange Liqueur", "Orange")).WithResolver(stubResolver{})
+
+	for ingredient, want := range map[string]string{
+		"Gin":            "Gin",
+		"Orange":         "Orange",
+		"Orange Liqueur": "Orange Liqueur",
+	} {
+		if item, ok := m.Find(ingredient); !ok || item.Name != want {
+			t.Errorf("Find(%q) = %v, %v; want %s", ingredient, item, ok, want)
+		}
+	}
+
+	var names []string
+	for _, item := range m.FindAll("Gin") {
+		names = append(names, item.Name)
+	}
+	if want := []string{"Gin", "Hendrick's Gin"}; !slices.Equal(names, want) {
+		t.Errorf("FindAll(\"Gin\") = %v, want %v", names, want)
+	}
+
+	m = New(items("Sloe Gin", "Orange Bitters", "Orange Liqueur", "Hendrick's Gin")).WithResolver(stubResolver{})
+	if item, ok := m.Find("Gin"); !ok || item.Name != "Hendrick's Gin" {
+		t.Errorf("Find(\"Gin\") = %v, %v; want Hendrick's Gin", item, ok)
+	}
+	if item, ok := m.Find("Orange"); ok {
+		t.Errorf("Find(\"Orange\") = %s, want no match", item.Name)
+	}
+}
+
+func TestSameIngredient(t *testing.T) {
+	m := New(nil).WithResolver(stubResolver{})
+
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Lime Juice", "Fresh Lime Juice", true},
+		{"Bourbon", "Buffalo Trace", true},
+		{"Gin", "Sloe Gin", false},
+		{"Lime", "Lime Juice", false},
+		{"Whiskey", "Bourbon", false},
+	}
+	for _, tt := range tests {
+		if got := m.SameIngredient(tt.a, tt.b); got != tt.want {
+			t.Errorf("SameIngredient(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestInventory_SkipsFinished(t *testing.T) {
+	empty, left := 0.0, 20.0
+	none, some := 0, 3
+	m := New(Inventory(Stock{
+		Bottles: []*models.Bottle{
+			{ID: 1, Name: "Finished Gin", Finished: true},
+			{ID: 2, Name: "Empty Rum", RemainingML: &empty},
+			{ID: 3, Name: "Campari", RemainingML: &left},
+			{ID: 4, Name: "Sweet Vermouth"},
+		},
+		Mixers:    []*models.Mixer{{ID: 1, Name: "Tonic Water", Finished: true}},
+		Fresh:     []*models.Fresh{{ID: 1, Name: "Lime Juice", RemainingML: &empty}},
+		Bitters:   []*models.Bitters{{ID: 1, Name: "Angostura Bitters"}, {ID: 2, Name: "Orange Bitters", DashesRemaining: &none}},
+		Syrups:    []*models.Syrup{{ID: 1, Name: "Simple Syrup", RemainingML: &left}, {ID: 2, Name: "Honey Syrup", Finished: true}},
+		Garnishes: []*models.Garnish{{ID: 1, Name: "Orange Peel", Quantity: &some}, {ID: 2, Name: "Cherry", Quantity: &none}},
+	}))
+	for _, name := range []string{"Gin", "Rum", "Tonic Water", "Lime Juice", "Orange Bitters", "Honey Syrup", "Cherry"} {
+		if m.Has(name) {
+			t.Errorf("Has(%q) = true, want false for a finished or empty item", name)
+		}
+	}
+	for _, name := range []string{"Campari", "Sweet Vermouth", "Angostura Bitters", "Simple Syrup", "Orange Peel"} {
+		if !m.Has(name) {
+			t.Errorf("Has(%q) = false, want true", name)
+		}
+	}
+	if item, ok := m.Find("Simple Syrup"); !ok || item.Kind != "syrup" {
+		t.Errorf("Find(\"Simple Syrup\") = %+v, %v; want the syrup", item, ok)
+	}
+}
@@ -0,0 +1,297 @@
+// Package measure parses recipe quantities such as "1 1/2 oz" or "2 dashes"
+// into an amount and unit, converts between bar units and scales them.
+package measure
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Unit is a unit of measure. Count is used for bare numbers such as "2" or
+// "1 lime wheel", which have no volume.
+type Unit string
+
+const (
+	Count      Unit = ""
+	Milliliter Unit = "ml"
+	Centiliter Unit = "cl"
+	Ounce      Unit = "oz"
+	Teaspoon   Unit = "tsp"
+	Tablespoon Unit = "tbsp"
+	Barspoon   Unit = "barspoon"
+	Dash       Unit = "dash"
+)
+
+var (
+	ErrEmptyQuantity     = errors.New("quantity is empty")
+	ErrInvalidAmount     = errors.New("quantity has no valid amount")
+	ErrIncompatibleUnits = errors.New("units cannot be converted")
+)
+
+// milliliters is the volume of one of each unit.
+var milliliters = map[Unit]float64{
+	Milliliter: 1,
+	Centiliter: 10,
+	Ounce:      29.5735,
+	Teaspoon:   4.92892,
+	Tablespoon: 14.7868,
+	Barspoon:   5,
+	Dash:       0.92,
+}
+
+// unitNames maps the spellings found in recipes to units.
+var unitNames = map[string]Unit{
+	"ml": Milliliter, "milliliter": Milliliter, "milliliters": Milliliter, "millilitre": Milliliter, "millilitres": Milliliter,
+	"cl": Centiliter, "centiliter": Centiliter, "centiliters": Centiliter, "centilitre": Centiliter, "centilitres": Centiliter,
+	"oz": Ounce, "ozs": Ounce, "ounce": Ounce, "ounces": Ounce, "fl oz": Ounce,
+	"tsp": Teaspoon, "tsps": Teaspoon, "teaspoon": Teaspoon, "teaspoons": Teaspoon,
+	"tbsp": Tablespoon, "tbsps": Tablespoon, "tablespoon": Tablespoon, "tablespoons": Tablespoon,
+	"barspoon": Barspoon, "barspoons": Barspoon, "bar spoon": Barspoon, "bar spoons": Barspoon, "bsp": Barspoon,
+	"dash": Dash, "dashes": Dash,
+}
+
+// unicodeFractions maps vulgar fraction characters to their values.
+var unicodeFractions = map[rune]float64{
+	'¼': 0.25, '½': 0.5, '¾': 0.75,
+	'⅓': 1.0 / 3, '⅔': 2.0 / 3,
+	'⅛': 0.125, '⅜': 0.375, '⅝': 0.625, '⅞': 0.875,
+}
+
+// Quantity is a parsed amount in a unit. Item holds any trailing words that
+// are not a unit, such as "lime wheel" in "1 lime wheel".
+type Quantity struct {
+	Amount float64
+	Unit   Unit
+	Item   string
+}
+
+// Parse reads a quantity such as "2 oz", "1 1/2 oz", "¾ oz", "1.5oz",
+// "30 ml", "2 dashes" or "a barspoon". A bare number or a number followed by
+// words that are not a unit parses with the Count unit.
+func Parse(s string) (Quantity, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return Quantity{}, ErrEmptyQuantity
+	}
+
+	amount, rest, err := parseAmount(s)
+	if err != nil {
+		return Quantity{}, fmt.Errorf("%w: %q", err, s)
+	}
+
+	rest = strings.Join(strings.Fields(strings.TrimSuffix(rest, ".")), " ")
+	if unit, ok := unitNames[rest]; ok {
+		return Quantity{Amount: amount, Unit: unit}, nil
+	}
+	// "2 dashes angostura" names the unit and then the ingredient.
+	for _, size := range []int{2, 1} {
+		words := strings.Fields(rest)
+		if len(words) < size {
+			continue
+		}
+		if unit, ok := unitNames[strings.Join(words[:size], " ")]; ok {
+			return Quantity{Amount: amount, Unit: unit, Item: strings.Join(words[size:], " ")}, nil
+		}
+	}
+
+	return Quantity{Amount: amount, Unit: Count, Item: rest}, nil
+}
+
+// parseAmount consumes the leading amount of s: whole numbers, decimals,
+// fractions, mixed numbers and the word "a" or "an", returning the rest.
+func parseAmount(s string) (float64, string, error) {
+	var amount float64
+	found := false
+
+	for {
+		s = strings.TrimLeft(s, " ")
+		if s == "" {
+			break
+		}
+
+		if !found {
+			if word, rest, _ := strings.Cut(s, " "); word == "a" || word == "an" {
+				amount, found, s = 1, true, rest
+				continue
+			}
+		}
+
+		if value, ok := unicodeFractions[[]rune(s)[0]]; ok {
+			amount += value
+			found = true
+			s = s[len(string([]rune(s)[0])):]
+			continue
+		}
+
+		// A range such as "1-2 dashes" is read as its upper bound.
+		if found && strings.HasPrefix(s, "-") {
+			upper, rest, err := parseAmount(s[1:])
+			if err != nil {
+				return 0, "", err
+			}
+			return upper, rest, nil
+		}
+
+		end := strings.IndexFunc(s, func(r rune) bool {
+			return !unicode.IsDigit(r) && r != '.' && r != '/'
+		})
+		if end == 0 {
+			break
+		}
+		if end < 0 {
+			end = len(s)
+		}
+
+		token := s[:end]
+		value, err := parseNumber(token)
+		if err != nil {
+			return 0, "", err
+		}
+		amount += value
+		found = true
+		s = s[end:]
+	}
+
+	if !found || amount <= 0 {
+		return 0, "", ErrInvalidAmount
+	}
+
+	return amount, s, nil
+}
+
+func parseNumber(token string) (float64, error) {
+	if num, den, ok := strings.Cut(token, "/"); ok {
+		n, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return 0, ErrInvalidAmount
+		}
+		d, err := strconv.ParseFloat(den, 64)
+		if err != nil || d == 0 {
+			return 0, ErrInvalidAmount
+		}
+		return n / d, nil
+	}
+
+	value, err := strconv.ParseFloat(token, 64)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	return value, nil
+}
+
+// IsVolume reports whether the quantity is a volume that can be converted.
+func (q Quantity) IsVolume() bool {
+	_, ok := milliliters[q.Unit]
+	return ok
+}
+
+// ML returns the quantity in millilitres. It reports false for counts.
+func (q Quantity) ML() (float64, bool) {
+	perUnit, ok := milliliters[q.Unit]
+	if !ok {
+		return 0, false
+	}
+	return q.Amount * perUnit, true
+}
+
+// Convert expresses q in another unit. Only volumes convert; converting a
+// count to anything but Count fails.
+func Convert(q Quantity, to Unit) (Quantity, error) {
+	if q.Unit == to {
+		return q, nil
+	}
+
+	ml, ok := q.ML()
+	perUnit, toVolume := milliliters[to]
+	if !ok || !toVolume {
+		return Quantity{}, fmt.Errorf("%w: %q to %q", ErrIncompatibleUnits, q.Unit, to)
+	}
+
+	return Quantity{Amount: ml / perUnit, Unit: to, Item: q.Item}, nil
+}
+
+// Scale multiplies the amount by factor, e.g. a number of servings.
+func Scale(q Quantity, factor float64) Quantity {
+	q.Amount *= factor
+	return q
+}
+
+// ScaleString parses s, scales it by factor and formats it again. Strings
+// that do not parse, such as "to taste", are returned unchanged with false.
+func ScaleString(s string, factor float64) (string, bool) {
+	q, err := Parse(s)
+	if err != nil {
+		return s, false
+	}
+	return Scale(q, factor).String(), true
+}
+
+// String formats the quantity the way a recipe would write it. Metric units
+// use decimals; bar units use fractions such as "1 1/2 oz" when the amount is
+// close to a quarter or third.
+func (q Quantity) String() string {
+	var amount string
+	switch q.Unit {
+	case Milliliter, Centiliter:
+		amount = formatDecimal(q.Amount)
+	default:
+		amount = formatFraction(q.Amount)
+	}
+
+	parts := []string{amount}
+	if q.Unit != Count {
+		parts = append(parts, q.unitName())
+	}
+	if q.Item != "" {
+		parts = append(parts, q.Item)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (q Quantity) unitName() string {
+	if q.Amount <= 1 {
+		return string(q.Unit)
+	}
+	switch q.Unit {
+	case Dash:
+		return "dashes"
+	case Barspoon:
+		return "barspoons"
+	}
+	return string(q.Unit)
+}
+
+func formatDecimal(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64)
+}
+
+// fractions are the denominators tried, in order, when formatting.
+var fractions = []float64{2, 4, 3, 8}
+
+func formatFraction(v float64) string {
+	whole := math.Floor(v)
+	part := v - whole
+	if part < 0.01 {
+		return strconv.FormatFloat(whole, 'f', -1, 64)
+	}
+	if part > 0.99 {
+		return strconv.FormatFloat(whole+1, 'f', -1, 64)
+	}
+
+	for _, den := range fractions {
+		num := math.Round(part * den)
+		if num > 0 && math.Abs(part-num/den) < 0.01 {
+			fraction := fmt.Sprintf("%d/%d", int(num), int(den))
+			if whole == 0 {
+				return fraction
+			}
+			return fmt.Sprintf("%d %s", int(whole), fraction)
+		}
+	}
+
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,142 @@
+package measure
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Quantity
+	}{
+		{"2 oz", Quantity{Amount: 2, Unit: Ounce}},
+		{"1 1/2 oz", Quantity{Amount: 1.5, Unit: Ounce}},
+		{"3/4 oz", Quantity{Amount: 0.75, Unit: Ounce}},
+		{"¾ oz", Quantity{Amount: 0.75, Unit: Ounce}},
+		{"1½ ounces", Quantity{Amount: 1.5, Unit: Ounce}},
+		{"1.5oz", Quantity{Amount: 1.5, Unit: Ounce}},
+		{"2 fl oz", Quantity{Amount: 2, Unit: Ounce}},
+		{"30 ml", Quantity{Amount: 30, Unit: Milliliter}},
+		{"4 cl", Quantity{Amount: 4, Unit: Centiliter}},
+		{"1 tsp", Quantity{Amount: 1, Unit: Teaspoon}},
+		{"1 tbsp", Quantity{Amount: 1, Unit: Tablespoon}},
+		{"a barspoon", Quantity{Amount: 1, Unit: Barspoon}},
+		{"2 bar spoons", Quantity{Amount: 2, Unit: Barspoon}},
+		{"2 dashes", Quantity{Amount: 2, Unit: Dash}},
+		{"1 dash", Quantity{Amount: 1, Unit: Dash}},
+		{"2 Dashes Angostura", Quantity{Amount: 2, Unit: Dash, Item: "angostura"}},
+		{"1-2 dashes", Quantity{Amount: 2, Unit: Dash}},
+		{"2", Quantity{Amount: 2, Unit: Count}},
+		{"1 lime wheel", Quantity{Amount: 1, Unit: Count, Item: "lime wheel"}},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v, want nil", tt.input, err)
+			continue
+		}
+		if math.Abs(got.Amount-tt.want.Amount) > 1e-9 || got.Unit != tt.want.Unit || got.Item != tt.want.Item {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"", ErrEmptyQuantity},
+		{"   ", ErrEmptyQuantity},
+		{"to taste", ErrInvalidAmount},
+		{"top up", ErrInvalidAmount},
+		{"1/0 oz", ErrInvalidAmount},
+		{"0 oz", ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		if _, err := Parse(tt.input); !errors.Is(err, tt.want) {
+			t.Errorf("Parse(%q) error = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		from Quantity
+		to   Unit
+		want float64
+	}{
+		{Quantity{Amount: 1, Unit: Ounce}, Milliliter, 29.5735},
+		{Quantity{Amount: 3, Unit: Centiliter}, Milliliter, 30},
+		{Quantity{Amount: 60, Unit: Milliliter}, Centiliter, 6},
+		{Quantity{Amount: 29.5735, Unit: Milliliter}, Ounce, 1},
+		{Quantity{Amount: 1, Unit: Tablespoon}, Teaspoon, 3},
+		{Quantity{Amount: 2, Unit: Barspoon}, Milliliter, 10},
+		{Quantity{Amount: 1, Unit: Dash}, Dash, 1},
+	}
+
+	for _, tt := range tests {
+		got, err := Convert(tt.from, tt.to)
+		if err != nil {
+			t.Errorf("Convert(%v, %q) error = %v, want nil", tt.from, tt.to, err)
+			continue
+		}
+		if got.Unit != tt.to || math.Abs(got.Amount-tt.want) > 0.001 {
+			t.Errorf("Convert(%v, %q) = %v, want %v %s", tt.from, tt.to, got, tt.want, tt.to)
+		}
+	}
+
+	if _, err := Convert(Quantity{Amount: 1, Unit: Count}, Ounce); !errors.Is(err, ErrIncompatibleUnits) {
+		t.Errorf("Convert(count, oz) error = %v, want %v", err, ErrIncompatibleUnits)
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		q    Quantity
+		want string
+	}{
+		{Quantity{Amount: 1.5, Unit: Ounce}, "1 1/2 oz"},
+		{Quantity{Amount: 0.75, Unit: Ounce}, "3/4 oz"},
+		{Quantity{Amount: 2, Unit: Ounce}, "2 oz"},
+		{Quantity{Amount: 1.0 / 3, Unit: Ounce}, "1/3 oz"},
+		{Quantity{Amount: 1.1, Unit: Ounce}, "1.1 oz"},
+		{Quantity{Amount: 44.36, Unit: Milliliter}, "44.4 ml"},
+		{Quantity{Amount: 4, Unit: Dash}, "4 dashes"},
+		{Quantity{Amount: 1, Unit: Dash}, "1 dash"},
+		{Quantity{Amount: 2, Unit: Count, Item: "lime wheel"}, "2 lime wheel"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.q.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestScaleString(t *testing.T) {
+	tests := []struct {
+		input  string
+		factor float64
+		want   string
+		ok     bool
+	}{
+		{"3/4 oz", 2, "1 1/2 oz", true},
+		{"1 1/2 oz", 4, "6 oz", true},
+		{"2 dashes", 3, "6 dashes", true},
+		{"30 ml", 1.5, "45 ml", true},
+		{"1 egg white", 2, "2 egg white", true},
+		{"to taste", 4, "to taste", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ScaleString(tt.input, tt.factor)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ScaleString(%q, %v) = %q, %v, want %q, %v", tt.input, tt.factor, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// AIProfile is a saved AI provider. EncryptedAPIKey is sealed with the
+// server's encryption key and is never sent to clients.
+type AIProfile struct {
+	ID   int
+	Name string
+	// Provider is the protocol the profile speaks, "openai" or "ollama".
+	Provider        string
+	BaseURL         string
+	EncryptedAPIKey string
+	// DefaultModel is used when a request doesn't name a model, and always
+	// when the profile is a fallback.
+	DefaultModel *string
+	// TimeoutSeconds bounds each request to the provider before falling back
+	// to the next profile. Nil uses the server's default.
+	TimeoutSeconds *int
+	IsDefault      bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// AIProfileRequest creates or updates an AI profile. On update an empty
+// APIKey keeps the current key.
+type AIProfileRequest struct {
+	Name string `json:"name"`
+	// Provider is "openai" (the default) or "ollama".
+	Provider       string  `json:"provider,omitempty"`
+	BaseURL        string  `json:"base_url"`
+	APIKey         string  `json:"api_key"`
+	DefaultModel   *string `json:"default_model,omitempty"`
+	TimeoutSeconds *int    `json:"timeout_seconds,omitempty"`
+	IsDefault      bool    `json:"is_default"`
+}
+
+// AIProfileResponse describes an AI profile without giving away its API key.
+type AIProfileResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+	BaseURL  string `json:"base_url"`
+	// APIKey shows only the last four characters of the key, as in "****abcd".
+	APIKey         string  `json:"api_key"`
+	DefaultModel   *string `json:"default_model,omitempty"`
+	TimeoutSeconds *int    `json:"timeout_seconds,omitempty"`
+	IsDefault      bool    `json:"is_default"`
+	// Ready is false if the saved key can't be decrypted with the server's
+	// ENCRYPTION_KEY; the profile is skipped until its key is entered again.
+	Ready bool `json:"ready"`
+}
+
+// AIServiceStatus describes the default AI profile without giving away its
+// API key.
+type AIServiceStatus struct {
+	Initialized bool   `json:"initialized"`
+	Profile     string `json:"profile,omitempty"`
+	BaseURL     string `json:"base_url,omitempty"`
+	// APIKey shows only the last four characters of the key, as in "****abcd".
+	APIKey string `json:"api_key,omitempty"`
+	// Profiles counts every profile, including fallbacks.
+	Profiles int `json:"profiles"`
+	// Persisted is true if the settings are saved and survive a restart. It
+	// is false when the server has no ENCRYPTION_KEY to protect them with.
+	Persisted bool `json:"persisted"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Archive is a full export of the database. Each table is a list of rows,
+// keyed by column name, holding the values exactly as stored.
+type Archive struct {
+	// Format is always "liquor-locker-archive", to reject unrelated files.
+	Format string `json:"format"`
+	// Version is the version of the archive layout itself.
+	Version int `json:"version"`
+	// SchemaVersion is the database migration the tables were exported at.
+	// An archive can only be restored into a database at the same migration.
+	SchemaVersion uint                        `json:"schema_version"`
+	ExportedAt    time.Time                   `json:"exported_at"`
+	Tables        map[string][]map[string]any `json:"tables"`
+}
+
+// RestoreReport counts the rows restored into each table. Skipped rows were
+// left out of a merge because a row with the same ID already existed.
+type RestoreReport struct {
+	Mode          string                  `json:"mode"`
+	SchemaVersion uint                    `json:"schema_version"`
+	Tables        map[string]RestoreCount `json:"tables"`
+}
+
+type RestoreCount struct {
+	Restored int `json:"restored"`
+	Skipped  int `json:"skipped"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// Backup is a copy of the database in the backup directory.
+type Backup struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	SizeBytes int64     `json:"size_bytes"`
+}
@@ -0,0 +1,24 @@
+package models
+
+// BatchIngredient is a recipe ingredient scaled for a batch. Quantity is left
+// as written in the recipe, and Scaled is false, when it could not be parsed.
+type BatchIngredient struct {
+	Name     string `json:"name"`
+	Quantity string `json:"quantity"`
+	Original string `json:"original"`
+	Scaled   bool   `json:"scaled"`
+}
+
+// BatchResponse is a recipe scaled to a number of servings, with optional
+// water added for pre-batched dilution.
+type BatchResponse struct {
+	CocktailID  int               `json:"cocktail_id"`
+	Name        string            `json:"name"`
+	Servings    int               `json:"servings"`
+	Dilution    float64           `json:"dilution"`
+	Units       string            `json:"units,omitempty"`
+	Ingredients []BatchIngredient `json:"ingredients"`
+	Water       string            `json:"water,omitempty"`
+	TotalVolume string            `json:"total_volume"`
+	Steps       []Step            `json:"steps"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+type Bitters struct {
+	ID           int64      `json:"id"`
+	Name         string     `json:"name"`
+	Brand        *string    `json:"brand,omitempty"`
+	Opened       bool       `json:"opened"`
+	OpenDate     *time.Time `json:"open_date,omitempty"`
+	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
+	Price        *float64   `json:"price,omitempty"`
+	// DashesRemaining is roughly how many dashes are left in the bottle.
+	DashesRemaining *int      `json:"dashes_remaining,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type CreateBittersRequest struct {
+	Name            string     `json:"name"`
+	Brand           *string    `json:"brand,omitempty"`
+	Opened          bool       `json:"opened"`
+	OpenDate        *time.Time `json:"open_date,omitempty"`
+	PurchaseDate    *time.Time `json:"purchase_date,omitempty"`
+	Price           *float64   `json:"price,omitempty"`
+	DashesRemaining *int       `json:"dashes_remaining,omitempty"`
+}
+
+type UpdateBittersRequest struct {
+	Name            string     `json:"name"`
+	Brand           *string    `json:"brand,omitempty"`
+	Opened          bool       `json:"opened"`
+	OpenDate        *time.Time `json:"open_date,omitempty"`
+	PurchaseDate    *time.Time `json:"purchase_date,omitempty"`
+	Price           *float64   `json:"price,omitempty"`
+	DashesRemaining *int       `json:"dashes_remaining,omitempty"`
+}
+
+type BittersResponse struct {
+	ID              int64      `json:"id"`
+	Name            string     `json:"name"`
+	Brand           *string    `json:"brand,omitempty"`
+	Opened          bool       `json:"opened"`
+	OpenDate        *time.Time `json:"open_date,omitempty"`
+	PurchaseDate    *time.Time `json:"purchase_date,omitempty"`
+	Price           *float64   `json:"price,omitempty"`
+	DashesRemaining *int       `json:"dashes_remaining,omitempty"`
+}
@@ -11,6 +11,18 @@ type Bottle struct {
 	OpenDate     *time.Time `json:"open_date,omitempty"`
 	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
 	Price        *float64   `json:"price,omitempty"`
+	IngredientID *int64     `json:"ingredient_id,omitempty"`
+	SizeML       *float64   `json:"size_ml,omitempty"`
+	RemainingML  *float64   `json:"remaining_ml,omitempty"`
+	Finished     bool       `json:"finished"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	Category     *string    `json:"category,omitempty"`
+	Subcategory  *string    `json:"subcategory,omitempty"`
+	Brand        *string    `json:"brand,omitempty"`
+	ABV          *float64   `json:"abv,omitempty"`
+	Country      *string    `json:"country,omitempty"`
+	Region       *string    `json:"region,omitempty"`
+	AgeStatement *string    `json:"age_statement,omitempty"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
 }
@@ -21,14 +33,35 @@ type CreateBottleRequest struct {
 	OpenDate     *time.Time `json:"open_date,omitempty"`
 	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
 	Price        *float64   `json:"price,omitempty"`
+	SizeML       *float64   `json:"size_ml,omitempty"`
+	RemainingML  *float64   `json:"remaining_ml,omitempty"`
+	Category     *string    `json:"category,omitempty"`
+	Subcategory  *string    `json:"subcategory,omitempty"`
+	Brand        *string    `json:"brand,omitempty"`
+	ABV          *float64   `json:"abv,omitempty"`
+	Country      *string    `json:"country,omitempty"`
+	Region       *string    `json:"region,omitempty"`
+	AgeStatement *string    `json:"age_statement,omitempty"`
 }
 
+// UpdateBottleRequest replaces a bottle's fields. Volume and the descriptive
+// fields from Category to AgeStatement are only changed when present; send an
+// empty string (or an ABV of 0) to clear one.
 type UpdateBottleRequest struct {
 	Name         string     `json:"name"`
 	Opened       bool       `json:"opened"`
 	OpenDate     *time.Time `json:"open_date,omitempty"`
 	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
 	Price        *float64   `json:"price,omitempty"`
+	SizeML       *float64   `json:"size_ml,omitempty"`
+	RemainingML  *float64   `json:"remaining_ml,omitempty"`
+	Category     *string    `json:"category,omitempty"`
+	Subcategory  *string    `json:"subcategory,omitempty"`
+	Brand        *string    `json:"brand,omitempty"`
+	ABV          *float64   `json:"abv,omitempty"`
+	Country      *string    `json:"country,omitempty"`
+	Region       *string    `json:"region,omitempty"`
+	AgeStatement *string    `json:"age_statement,omitempty"`
 }
 
 type BottleResponse struct {
@@ -38,4 +71,18 @@ type BottleResponse struct {
 	OpenDate     *time.Time `json:"open_date,omitempty"`
 	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
 	Price        *float64   `json:"price,omitempty"`
+	IngredientID *int64     `json:"ingredient_id,omitempty"`
+	SizeML       *float64   `json:"size_ml,omitempty"`
+	RemainingML  *float64   `json:"remaining_ml,omitempty"`
+	Finished     bool       `json:"finished"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	Category     *string    `json:"category,omitempty"`
+	Subcategory  *string    `json:"subcategory,omitempty"`
+	Brand        *string    `json:"brand,omitempty"`
+	ABV          *float64   `json:"abv,omitempty"`
+	Country      *string    `json:"country,omitempty"`
+	Region       *string    `json:"region,omitempty"`
+	AgeStatement *string    `json:"age_statement,omitempty"`
+	// Proof is twice the ABV, in US proof.
+	Proof *float64 `json:"proof,omitempty"`
 }
@@ -1,11 +1,19 @@
 package models
 
+import "time"
+
 type Cocktail struct {
 	ID          int          `json:"id"`
 	Name        string       `json:"name"`
 	Description string       `json:"description"`
 	Ingredients []Ingredient `json:"ingredients"`
 	Steps       []Step       `json:"steps"`
+	// SourceModel and SourcePrompt are set when the recipe was saved from an
+	// AI recommendation.
+	SourceModel  *string   `json:"source_model,omitempty"`
+	SourcePrompt *string   `json:"source_prompt,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
 
 type Ingredient struct {
@@ -20,6 +28,52 @@ type Step struct {
 	Text  string `json:"text"`
 }
 
+type CreateCocktailRequest struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Ingredients []IngredientRequest `json:"ingredients"`
+	Steps       []StepRequest       `json:"steps"`
+}
+
+type UpdateCocktailRequest struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Ingredients []IngredientRequest `json:"ingredients"`
+	Steps       []StepRequest       `json:"steps"`
+}
+
+type IngredientRequest struct {
+	Name     string `json:"name"`
+	Quantity string `json:"quantity"`
+}
+
+type StepRequest struct {
+	Order int    `json:"order"`
+	Text  string `json:"text"`
+}
+
+// SavedCocktailResponse is a cocktail recipe stored in the recipe book.
+type SavedCocktailResponse struct {
+	ID           int          `json:"id"`
+	Name         string       `json:"name"`
+	Description  string       `json:"description"`
+	Ingredients  []Ingredient `json:"ingredients"`
+	Steps        []Step       `json:"steps"`
+	SourceModel  *string      `json:"source_model,omitempty"`
+	SourcePrompt *string      `json:"source_prompt,omitempty"`
+}
+
+// SaveRecommendationRequest saves one of the cocktails returned by the
+// recommendation endpoint into the recipe book.
+type SaveRecommendationRequest struct {
+	Cocktail CocktailResponse `json:"cocktail"`
+	Model    string           `json:"model"`
+	Prompt   string           `json:"prompt,omitempty"`
+	// Mode is the recommendation mode used, which picks the default prompt
+	// when Prompt is empty.
+	Mode string `json:"mode,omitempty"`
+}
+
 type CocktailRecommendationResponse struct {
 	Cocktails []CocktailResponse `json:"cocktails"`
 }
@@ -40,3 +94,15 @@ type StepResponse struct {
 	Order int    `json:"order" jsonschema_description:"Order of the step"`
 	Text  string `json:"text" jsonschema_description:"Text of the step"`
 }
+
+// MakeableResponse lists saved recipes that can be made from the current
+// inventory, plus recipes that are a single ingredient short.
+type MakeableResponse struct {
+	Makeable   []SavedCocktailResponse `json:"makeable"`
+	MissingOne []MissingOneResponse    `json:"missing_one"`
+}
+
+type MissingOneResponse struct {
+	Cocktail SavedCocktailResponse `json:"cocktail"`
+	Missing  Ingredient            `json:"missing"`
+}
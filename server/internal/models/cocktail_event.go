@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// CocktailEvent records a cocktail being made and the inventory it used.
+type CocktailEvent struct {
+	ID           int64     `json:"id"`
+	CocktailID   int       `json:"cocktail_id"`
+	CocktailName string    `json:"cocktail_name"`
+	Servings     int       `json:"servings"`
+	MadeAt       time.Time `json:"made_at"`
+	// Deductions are the pours taken from inventory for this event.
+	Deductions []*Pour `json:"deductions"`
+	// Skipped lists ingredients that could not be deducted. It is only
+	// reported when the event is created.
+	Skipped []SkippedIngredient `json:"skipped,omitempty"`
+}
+
+// SkippedIngredient is a recipe ingredient, or the part of one, that was not
+// deducted from inventory, with the reason why. Quantity is the recipe's
+// quantity, or the volume the inventory was short of.
+type SkippedIngredient struct {
+	Name     string `json:"name"`
+	Quantity string `json:"quantity"`
+	Reason   string `json:"reason"`
+}
+
+type MakeCocktailRequest struct {
+	Servings int `json:"servings"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Expiry describes how long an opened or prepared item keeps. ShelfLifeDays
+// is the item's own shelf life or the default for its kind of ingredient;
+// ExpiresAt is unset until the item has been opened or prepared.
+type Expiry struct {
+	ShelfLifeDays int        `json:"shelf_life_days"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	Status        string     `json:"status"`
+}
+
+// ExpiringItem is a fresh item, mixer or syrup that is about to go bad, or
+// has.
+type ExpiringItem struct {
+	Kind          string    `json:"kind"`
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	ShelfLifeDays int       `json:"shelf_life_days"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	Status        string    `json:"status"`
+}
@@ -8,22 +8,35 @@ type Fresh struct {
 	PreparedDate *time.Time `json:"prepared_date,omitempty"`
 	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
 	Price        *float64   `json:"price,omitempty"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
+	IngredientID *int64     `json:"ingredient_id,omitempty"`
+	SizeML       *float64   `json:"size_ml,omitempty"`
+	RemainingML  *float64   `json:"remaining_ml,omitempty"`
+	Finished     bool       `json:"finished"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	// ShelfLifeDays overrides the default shelf life for the item's kind.
+	ShelfLifeDays *int      `json:"shelf_life_days,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 type CreateFreshRequest struct {
-	Name         string     `json:"name"`
-	PreparedDate *time.Time `json:"prepared_date,omitempty"`
-	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
-	Price        *float64   `json:"price,omitempty"`
+	Name          string     `json:"name"`
+	PreparedDate  *time.Time `json:"prepared_date,omitempty"`
+	PurchaseDate  *time.Time `json:"purchase_date,omitempty"`
+	Price         *float64   `json:"price,omitempty"`
+	SizeML        *float64   `json:"size_ml,omitempty"`
+	RemainingML   *float64   `json:"remaining_ml,omitempty"`
+	ShelfLifeDays *int       `json:"shelf_life_days,omitempty"`
 }
 
 type UpdateFreshRequest struct {
-	Name         string     `json:"name"`
-	PreparedDate *time.Time `json:"prepared_date,omitempty"`
-	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
-	Price        *float64   `json:"price,omitempty"`
+	Name          string     `json:"name"`
+	PreparedDate  *time.Time `json:"prepared_date,omitempty"`
+	PurchaseDate  *time.Time `json:"purchase_date,omitempty"`
+	Price         *float64   `json:"price,omitempty"`
+	SizeML        *float64   `json:"size_ml,omitempty"`
+	RemainingML   *float64   `json:"remaining_ml,omitempty"`
+	ShelfLifeDays *int       `json:"shelf_life_days,omitempty"`
 }
 
 type FreshResponse struct {
@@ -32,4 +45,10 @@ type FreshResponse struct {
 	PreparedDate *time.Time `json:"prepared_date,omitempty"`
 	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
 	Price        *float64   `json:"price,omitempty"`
+	IngredientID *int64     `json:"ingredient_id,omitempty"`
+	SizeML       *float64   `json:"size_ml,omitempty"`
+	RemainingML  *float64   `json:"remaining_ml,omitempty"`
+	Finished     bool       `json:"finished"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	Expiry
 }
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+type Garnish struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Quantity is how many are on hand, such as cherries in a jar.
+	Quantity     *int       `json:"quantity,omitempty"`
+	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
+	Price        *float64   `json:"price,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type CreateGarnishRequest struct {
+	Name         string     `json:"name"`
+	Quantity     *int       `json:"quantity,omitempty"`
+	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
+	Price        *float64   `json:"price,omitempty"`
+}
+
+type UpdateGarnishRequest struct {
+	Name         string     `json:"name"`
+	Quantity     *int       `json:"quantity,omitempty"`
+	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
+	Price        *float64   `json:"price,omitempty"`
+}
+
+type GarnishResponse struct {
+	ID           int64      `json:"id"`
+	Name         string     `json:"name"`
+	Quantity     *int       `json:"quantity,omitempty"`
+	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
+	Price        *float64   `json:"price,omitempty"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// ImportReport sums up a bulk import. Nothing is saved on a dry run or when
+// any row has errors.
+type ImportReport struct {
+	Kind     string        `json:"kind"`
+	DryRun   bool          `json:"dry_run"`
+	Rows     int           `json:"rows"`
+	Imported int           `json:"imported"`
+	Errors   []ImportError `json:"errors"`
+}
+
+// ImportError is a problem with one row of an import, numbered from 1 not
+// counting the CSV header.
+type ImportError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// CatalogIngredient is a canonical ingredient in the catalog. Ingredients form
+// a hierarchy through ParentID, e.g. Bourbon -> Whiskey -> Spirit.
+type CatalogIngredient struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	ParentID  *int64    `json:"parent_id,omitempty"`
+	Aliases   []string  `json:"aliases"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateCatalogIngredientRequest struct {
+	Name     string   `json:"name"`
+	ParentID *int64   `json:"parent_id,omitempty"`
+	Aliases  []string `json:"aliases,omitempty"`
+}
+
+type CatalogIngredientResponse struct {
+	ID       int64    `json:"id"`
+	Name     string   `json:"name"`
+	ParentID *int64   `json:"parent_id,omitempty"`
+	Aliases  []string `json:"aliases"`
+}
+
+type AddAliasRequest struct {
+	Alias string `json:"alias"`
+}
+
+// LinkIngredientRequest links an inventory row to a catalog ingredient. A nil
+// IngredientID removes the link.
+type LinkIngredientRequest struct {
+	Kind         string `json:"kind"`
+	ItemID       int    `json:"item_id"`
+	IngredientID *int64 `json:"ingredient_id"`
+}
+
+// ResolveIngredientResponse is the catalog entry a free-text name resolved to,
+// along with its ancestors from most to least specific.
+type ResolveIngredientResponse struct {
+	Query      string                      `json:"query"`
+	Ingredient *CatalogIngredientResponse  `json:"ingredient"`
+	Lineage    []CatalogIngredientResponse `json:"lineage"`
+}
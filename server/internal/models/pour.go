@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Pour is an audit record of volume taken from a bottle, mixer or fresh item.
+type Pour struct {
+	ID          int64   `json:"id"`
+	ItemType    string  `json:"item_type"`
+	ItemID      int64   `json:"item_id"`
+	AmountML    float64 `json:"amount_ml"`
+	RemainingML float64 `json:"remaining_ml"`
+	// EventID links the pour to the cocktail event that caused it, if any.
+	EventID  *int64    `json:"event_id,omitempty"`
+	PouredAt time.Time `json:"poured_at"`
+}
+
+type PourRequest struct {
+	AmountML float64 `json:"amount_ml"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// SearchHit is an inventory item or saved cocktail recipe matching a search.
+// Kind is the item's kind, such as "bottle" or "cocktail". Higher scores are
+// better matches.
+type SearchHit struct {
+	Kind  string  `json:"kind"`
+	ID    int64   `json:"id"`
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
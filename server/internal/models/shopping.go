@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+type ShoppingList struct {
+	ID        int64              `json:"id"`
+	Name      string             `json:"name"`
+	Items     []ShoppingListItem `json:"items"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// ShoppingListItem is one ingredient to buy. Quantity is the combined amount
+// the target recipes call for, and NeededFor names those recipes.
+type ShoppingListItem struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Quantity  string     `json:"quantity"`
+	NeededFor string     `json:"needed_for"`
+	Checked   bool       `json:"checked"`
+	CheckedAt *time.Time `json:"checked_at,omitempty"`
+	// ConvertedKind and ConvertedID point at the inventory row the item was
+	// turned into, once it has been.
+	ConvertedKind *string `json:"converted_kind,omitempty"`
+	ConvertedID   *int64  `json:"converted_id,omitempty"`
+}
+
+// CreateShoppingListRequest selects the recipes to shop for: either explicit
+// cocktail IDs, every saved recipe missing exactly one ingredient, or both.
+type CreateShoppingListRequest struct {
+	Name        string `json:"name"`
+	CocktailIDs []int  `json:"cocktail_ids,omitempty"`
+	MissingOne  bool   `json:"missing_one,omitempty"`
+}
+
+type CheckShoppingItemRequest struct {
+	Checked bool `json:"checked"`
+}
+
+// ConvertShoppingItemRequest turns a bought item into an inventory item of
+// Kind: bottle, mixer, fresh, bitters, syrup or garnish.
+type ConvertShoppingItemRequest struct {
+	Kind         string     `json:"kind"`
+	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
+	Price        *float64   `json:"price,omitempty"`
+}
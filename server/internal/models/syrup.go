@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+type Syrup struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Ratio is sugar to water, as in "1:1" or "2:1".
+	Ratio        *string    `json:"ratio,omitempty"`
+	MadeDate     *time.Time `json:"made_date,omitempty"`
+	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
+	Price        *float64   `json:"price,omitempty"`
+	SizeML       *float64   `json:"size_ml,omitempty"`
+	RemainingML  *float64   `json:"remaining_ml,omitempty"`
+	Finished     bool       `json:"finished"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type CreateSyrupRequest struct {
+	Name         string     `json:"name"`
+	Ratio        *string    `json:"ratio,omitempty"`
+	MadeDate     *time.Time `json:"made_date,omitempty"`
+	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
+	Price        *float64   `json:"price,omitempty"`
+	SizeML       *float64   `json:"size_ml,omitempty"`
+	RemainingML  *float64   `json:"remaining_ml,omitempty"`
+}
+
+type UpdateSyrupRequest struct {
+	Name         string     `json:"name"`
+	Ratio        *string    `json:"ratio,omitempty"`
+	MadeDate     *time.Time `json:"made_date,omitempty"`
+	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
+	Price        *float64   `json:"price,omitempty"`
+	SizeML       *float64   `json:"size_ml,omitempty"`
+	RemainingML  *float64   `json:"remaining_ml,omitempty"`
+}
+
+type SyrupResponse struct {
+	ID           int64      `json:"id"`
+	Name         string     `json:"name"`
+	Ratio        *string    `json:"ratio,omitempty"`
+	MadeDate     *time.Time `json:"made_date,omitempty"`
+	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
+	Price        *float64   `json:"price,omitempty"`
+	SizeML       *float64   `json:"size_ml,omitempty"`
+	RemainingML  *float64   `json:"remaining_ml,omitempty"`
+	Finished     bool       `json:"finished"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+}
@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+var (
+	ErrNilAIProfile       = errors.New("AI profile cannot be nil")
+	ErrAIProfileNotFound  = errors.New("AI profile not found")
+	ErrDuplicateAIProfile = errors.New("an AI profile with that name already exists")
+)
+
+const aiProfileColumns = `id, name, provider, base_url, api_key_encrypted, default_model, timeout_seconds, is_default, created_at, updated_at`
+
+// aiProfileFields lists the destinations for aiProfileColumns.
+func aiProfileFields(p *models.AIProfile) []any {
+	return []any{&p.ID, &p.Name, &p.Provider, &p.BaseURL, &p.EncryptedAPIKey, &p.DefaultModel, &p.TimeoutSeconds, &p.IsDefault, &p.CreatedAt, &p.UpdatedAt}
+}
+
+// GetAIProfiles returns every AI profile in the order they are tried: the
+// default first, then the rest oldest first.
+func (r *Repository) GetAIProfiles(ctx context.Context) ([]*models.AIProfile, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+aiProfileColumns+` FROM ai_profiles ORDER BY is_default DESC, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AI profiles: %v", err)
+	}
+	defer rows.Close()
+
+	var profiles []*models.AIProfile
+	for rows.Next() {
+		var profile models.AIProfile
+		if err := rows.Scan(aiProfileFields(&profile)...); err != nil {
+			return nil, fmt.Errorf("failed to scan AI profile: %v", err)
+		}
+		profiles = append(profiles, &profile)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over AI profiles: %v", err)
+	}
+
+	return profiles, nil
+}
+
+func (r *Repository) GetAIProfileByID(ctx context.Context, id int) (*models.AIProfile, error) {
+	return getAIProfile(ctx, r.DB, id)
+}
+
+func getAIProfile(ctx context.Context, q queryRower, id int) (*models.AIProfile, error) {
+	var profile models.AIProfile
+	err := q.QueryRowContext(ctx, `SELECT `+aiProfileColumns+` FROM ai_profiles WHERE id = ?`, id).Scan(aiProfileFields(&profile)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAIProfileNotFound
+		}
+		return nil, fmt.Errorf("failed to get AI profile by ID: %v", err)
+	}
+	return &profile, nil
+}
+
+// CreateAIProfile saves a new AI profile. The API key must already be
+// encrypted. The first profile becomes the default whether asked to or not.
+func (r *Repository) CreateAIProfile(ctx context.Context, profile *models.AIProfile) (*models.AIProfile, error) {
+	if profile == nil {
+		return nil, ErrNilAIProfile
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := aiProfileNameFree(ctx, tx, profile.Name, 0); err != nil {
+		return nil, err
+	}
+
+	var count int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM ai_profiles`).Scan(&count); err != nil {
+		return nil, fmt.Errorf("failed to count AI profiles: %v", err)
+	}
+	isDefault := profile.IsDefault || count == 0
+	if isDefault {
+		if err := clearDefaultAIProfile(ctx, tx); err != nil {
+			return nil, err
+		}
+	}
+
+	var id int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO ai_profiles (name, provider, base_url, api_key_encrypted, default_model, timeout_seconds, is_default, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, datetime('now'), datetime('now'))
+		RETURNING id`,
+		profile.Name, aiProfileProvider(profile), profile.BaseURL, profile.EncryptedAPIKey, profile.DefaultModel, profile.TimeoutSeconds, isDefault).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AI profile: %v", err)
+	}
+
+	created, err := getAIProfile(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit AI profile: %v", err)
+	}
+
+	return created, nil
+}
+
+// UpdateAIProfile replaces a profile's settings. An empty EncryptedAPIKey
+// keeps the current key. A profile stops being the default only when another
+// becomes it, so there is always one while any profile exists.
+func (r *Repository) UpdateAIProfile(ctx context.Context, id int, updates *models.AIProfile) (*models.AIProfile, error) {
+	if updates == nil {
+		return nil, ErrNilAIProfile
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	current, err := getAIProfile(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := aiProfileNameFree(ctx, tx, updates.Name, id); err != nil {
+		return nil, err
+	}
+
+	isDefault := current.IsDefault || updates.IsDefault
+	if isDefault && !current.IsDefault {
+		if err := clearDefaultAIProfile(ctx, tx); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE ai_profiles
+		SET name = ?, provider = ?, base_url = ?, api_key_encrypted = COALESCE(NULLIF(?, ''), api_key_encrypted),
+			default_model = ?, timeout_seconds = ?, is_default = ?, updated_at = datetime('now')
+		WHERE id = ?`,
+		updates.Name, aiProfileProvider(updates), updates.BaseURL, updates.EncryptedAPIKey, updates.DefaultModel, updates.TimeoutSeconds, isDefault, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update AI profile: %v", err)
+	}
+
+	updated, err := getAIProfile(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit AI profile: %v", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteAIProfile removes a profile. If it was the default, the oldest
+// remaining profile takes its place.
+func (r *Repository) DeleteAIProfile(ctx context.Context, id int) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM ai_profiles WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete AI profile: %v", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to get rows affected: %v", err)
+	} else if n == 0 {
+		return ErrAIProfileNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE ai_profiles SET is_default = TRUE
+		WHERE id = (SELECT MIN(id) FROM ai_profiles)
+			AND NOT EXISTS (SELECT 1 FROM ai_profiles WHERE is_default)`)
+	if err != nil {
+		return fmt.Errorf("failed to choose a new default AI profile: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit AI profile deletion: %v", err)
+	}
+
+	return nil
+}
+
+// SaveDefaultAIProfile sets the base URL and key of the profile with the
+// given name, creating it if needed, and makes it the default. It is for
+// clients that only know about a single provider, so an existing profile
+// keeps its provider, default model and timeout.
+func (r *Repository) SaveDefaultAIProfile(ctx context.Context, profile *models.AIProfile) (*models.AIProfile, error) {
+	if profile == nil {
+		return nil, ErrNilAIProfile
+	}
+
+	var id int
+	err := r.DB.QueryRowContext(ctx, `SELECT id FROM ai_profiles WHERE name = ?`, profile.Name).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		profile.IsDefault = true
+		return r.CreateAIProfile(ctx, profile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AI profile by name: %v", err)
+	}
+
+	current, err := r.GetAIProfileByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	profile.Provider = current.Provider
+	profile.DefaultModel = current.DefaultModel
+	profile.TimeoutSeconds = current.TimeoutSeconds
+	profile.IsDefault = true
+	return r.UpdateAIProfile(ctx, id, profile)
+}
+
+// aiProfileProvider returns the profile's provider, where empty means openai.
+func aiProfileProvider(p *models.AIProfile) string {
+	if p.Provider == "" {
+		return "openai"
+	}
+	return p.Provider
+}
+
+func aiProfileNameFree(ctx context.Context, tx *sql.Tx, name string, exceptID int) error {
+	var exists bool
+	err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM ai_profiles WHERE name = ? AND id != ?)`, name, exceptID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check AI profile name: %v", err)
+	}
+	if exists {
+		return ErrDuplicateAIProfile
+	}
+	return nil
+}
+
+func clearDefaultAIProfile(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE ai_profiles SET is_default = FALSE WHERE is_default`); err != nil {
+		return fmt.Errorf("failed to clear default AI profile: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+func createAIProfile(t *testing.T, repo *Repository, name string, isDefault bool) *models.AIProfile {
+	t.Helper()
+
+	profile, err := repo.CreateAIProfile(context.Background(), &models.AIProfile{
+		Name:            name,
+		BaseURL:         "https://" + name + ".example/v1",
+		EncryptedAPIKey: "sealed-" + name,
+		IsDefault:       isDefault,
+	})
+	if err != nil {
+		t.Fatalf("CreateAIProfile(%s) error = %v", name, err)
+	}
+	return profile
+}
+
+func aiProfileNames(t *testing.T, repo *Repository) []string {
+	t.Helper()
+
+	profiles, err := repo.GetAIProfiles(context.Background())
+	if err != nil {
+		t.Fatalf("GetAIProfiles() error = %v", err)
+	}
+	var names []string
+	for _, profile := range profiles {
+		name := profile.Name
+		if profile.IsDefault {
+			name += "*"
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestAIProfiles_DefaultAndOrder(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	first := createAIProfile(t, repo, "local", false)
+	if !first.IsDefault {
+		t.Error("CreateAIProfile() didn't make the first profile the default")
+	}
+	createAIProfile(t, repo, "hosted", false)
+	third := createAIProfile(t, repo, "backup", true)
+
+	if got, want := aiProfileNames(t, repo), []string{"backup*", "local", "hosted"}; !slices.Equal(got, want) {
+		t.Errorf("profiles = %v, want %v", got, want)
+	}
+
+	// Clearing is_default doesn't leave the profiles without a default.
+	updates := *third
+	updates.IsDefault = false
+	updates.EncryptedAPIKey = ""
+	updated, err := repo.UpdateAIProfile(context.Background(), third.ID, &updates)
+	if err != nil {
+		t.Fatalf("UpdateAIProfile() error = %v", err)
+	}
+	if !updated.IsDefault || updated.EncryptedAPIKey != "sealed-backup" {
+		t.Errorf("UpdateAIProfile() = default %v, key %q; want still default with the old key", updated.IsDefault, updated.EncryptedAPIKey)
+	}
+
+	if err := repo.DeleteAIProfile(context.Background(), third.ID); err != nil {
+		t.Fatalf("DeleteAIProfile() error = %v", err)
+	}
+	if got, want := aiProfileNames(t, repo), []string{"local*", "hosted"}; !slices.Equal(got, want) {
+		t.Errorf("profiles after deleting the default = %v, want %v", got, want)
+	}
+
+	if err := repo.DeleteAIProfile(context.Background(), third.ID); err != ErrAIProfileNotFound {
+		t.Errorf("DeleteAIProfile() twice: err = %v, want ErrAIProfileNotFound", err)
+	}
+}
+
+func TestAIProfiles_DuplicateName(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	createAIProfile(t, repo, "local", false)
+	hosted := createAIProfile(t, repo, "hosted", false)
+
+	if _, err := repo.CreateAIProfile(context.Background(), &models.AIProfile{Name: "LOCAL", BaseURL: "x", EncryptedAPIKey: "y"}); err != ErrDuplicateAIProfile {
+		t.Errorf("CreateAIProfile() with a taken name: err = %v, want ErrDuplicateAIProfile", err)
+	}
+
+	updates := *hosted
+	updates.Name = "Local"
+	if _, err := repo.UpdateAIProfile(context.Background(), hosted.ID, &updates); err != ErrDuplicateAIProfile {
+		t.Errorf("UpdateAIProfile() to a taken name: err = %v, want ErrDuplicateAIProfile", err)
+	}
+}
+
+func TestSaveDefaultAIProfile(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+	ctx := context.Background()
+
+	createAIProfile(t, repo, "local", false)
+
+	model := "gpt-4o-mini"
+	saved, err := repo.SaveDefaultAIProfile(ctx, &models.AIProfile{Name: "default", BaseURL: "https://one.example/v1", EncryptedAPIKey: "one"})
+	if err != nil {
+		t.Fatalf("SaveDefaultAIProfile() error = %v", err)
+	}
+	saved.DefaultModel = &model
+	if _, err := repo.UpdateAIProfile(ctx, saved.ID, saved); err != nil {
+		t.Fatalf("UpdateAIProfile() error = %v", err)
+	}
+
+	again, err := repo.SaveDefaultAIProfile(ctx, &models.AIProfile{Name: "default", BaseURL: "https://two.example/v1", EncryptedAPIKey: "two"})
+	if err != nil {
+		t.Fatalf("SaveDefaultAIProfile() again error = %v", err)
+	}
+	if again.ID != saved.ID || again.BaseURL != "https://two.example/v1" || again.EncryptedAPIKey != "two" {
+		t.Errorf("SaveDefaultAIProfile() again = %+v, want the same profile with the new URL and key", again)
+	}
+	if again.DefaultModel == nil || *again.DefaultModel != model {
+		t.Errorf("SaveDefaultAIProfile() again dropped the default model")
+	}
+
+	if got, want := aiProfileNames(t, repo), []string{"default*", "local"}; !slices.Equal(got, want) {
+		t.Errorf("profiles = %v, want %v", got, want)
+	}
+}
+
+func TestAIProfiles_Provider(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	hosted := createAIProfile(t, repo, "hosted", false)
+	if hosted.Provider != "openai" {
+		t.Errorf("CreateAIProfile() provider = %q, want openai by default", hosted.Provider)
+	}
+
+	local, err := repo.CreateAIProfile(context.Background(), &models.AIProfile{
+		Name:            "local",
+		Provider:        "ollama",
+		BaseURL:         "http://localhost:11434",
+		EncryptedAPIKey: "sealed-local",
+	})
+	if err != nil {
+		t.Fatalf("CreateAIProfile() error = %v", err)
+	}
+	if local.Provider != "ollama" {
+		t.Errorf("CreateAIProfile() provider = %q, want ollama", local.Provider)
+	}
+
+	// Configuring through the single-provider settings keeps the provider.
+	saved, err := repo.SaveDefaultAIProfile(context.Background(), &models.AIProfile{
+		Name:            "local",
+		BaseURL:         "http://ollama:11434",
+		EncryptedAPIKey: "sealed-new",
+	})
+	if err != nil {
+		t.Fatalf("SaveDefaultAIProfile() error = %v", err)
+	}
+	if saved.Provider != "ollama" {
+		t.Errorf("SaveDefaultAIProfile() provider = %q, want ollama kept", saved.Provider)
+	}
+}
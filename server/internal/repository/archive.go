@@ -0,0 +1,571 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+const (
+	ArchiveFormat  = "liquor-locker-archive"
+	ArchiveVersion = 1
+)
+
+// RestoreMode selects what Restore does with the rows already in the
+// database.
+type RestoreMode string
+
+const (
+	// RestoreReplace empties every table before restoring the archive.
+	RestoreReplace RestoreMode = "replace"
+	// RestoreMerge keeps existing rows and adds the archive's rows under new
+	// IDs, rewriting the references between them. Archived rows equal to one
+	// already in the database, such as those exported from this same
+	// database, are skipped.
+	RestoreMerge RestoreMode = "merge"
+)
+
+var (
+	ErrInvalidArchive      = errors.New("not a liquor locker archive")
+	ErrArchiveVersion      = errors.New("unsupported archive version")
+	ErrSchemaMismatch      = errors.New("archive schema version doesn't match the database")
+	ErrDirtySchema         = errors.New("database has a failed migration")
+	ErrUnknownTable        = errors.New("unknown table")
+	ErrUnknownColumn       = errors.New("unknown column")
+	ErrInvalidArchiveValue = errors.New("invalid value")
+	ErrInvalidRestoreMode  = errors.New("mode must be replace or merge")
+)
+
+// schemaVersion reads the migration golang-migrate last applied.
+func schemaVersion(ctx context.Context, q queryRower) (uint, error) {
+	var version uint
+	var dirty bool
+	if err := q.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty); err != nil {
+		return 0, fmt.Errorf("failed to get schema version: %v", err)
+	}
+	if dirty {
+		return 0, ErrDirtySchema
+	}
+	return version, nil
+}
+
+// archiveTables lists the tables an archive holds, oldest first so parents
+// come before the rows that refer to them. The search index is left out as
+// its triggers rebuild it from the other tables, and so are the AI profiles,
+// whose keys can only be read with this server's encryption key.
+func archiveTables(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT name FROM sqlite_master
+		WHERE type = 'table'
+			AND name NOT LIKE 'sqlite\_%' ESCAPE '\'
+			AND name NOT LIKE 'search\_index%' ESCAPE '\'
+			AND name NOT IN ('schema_migrations', 'ai_profiles')
+		ORDER BY rowid`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %v", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan table name: %v", err)
+		}
+		tables = append(tables, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over tables: %v", err)
+	}
+	return tables, nil
+}
+
+func tableColumns(ctx context.Context, tx *sql.Tx, table string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT name FROM pragma_table_info(?) ORDER BY cid`, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list columns of %s: %v", table, err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan column name: %v", err)
+		}
+		columns = append(columns, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over columns of %s: %v", table, err)
+	}
+	return columns, nil
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// Export reads every table into an archive, as of a single moment.
+func (r *Repository) Export(ctx context.Context) (*models.Archive, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	version, err := schemaVersion(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := archiveTables(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &models.Archive{
+		Format:        ArchiveFormat,
+		Version:       ArchiveVersion,
+		SchemaVersion: version,
+		ExportedAt:    time.Now().UTC(),
+		Tables:        make(map[string][]map[string]any, len(tables)),
+	}
+	for _, table := range tables {
+		if archive.Tables[table], err = exportTable(ctx, tx, table); err != nil {
+			return nil, err
+		}
+	}
+
+	return archive, nil
+}
+
+func exportTable(ctx context.Context, tx *sql.Tx, table string) ([]map[string]any, error) {
+	columns, err := tableColumns(ctx, tx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	// A unary + hides the column's declared type from the driver, so dates
+	// come back as the text SQLite stored rather than parsed times, and are
+	// restored byte for byte.
+	selects := make([]string, len(columns))
+	for i, column := range columns {
+		selects[i] = "+" + quoteIdent(column)
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT `+strings.Join(selects, ", ")+` FROM `+quoteIdent(table)+` ORDER BY rowid`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export %s: %v", table, err)
+	}
+	defer rows.Close()
+
+	exported := []map[string]any{}
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("failed to scan %s row: %v", table, err)
+		}
+
+		row := make(map[string]any, len(columns))
+		for i, column := range columns {
+			row[column] = values[i]
+		}
+		exported = append(exported, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over %s: %v", table, err)
+	}
+
+	return exported, nil
+}
+
+// Restore writes an archive's rows back into the database in one
+// transaction. The archive must come from a database at the same migration.
+// Replacing keeps the rows' IDs; merging gives them new ones.
+func (r *Repository) Restore(ctx context.Context, archive *models.Archive, mode RestoreMode) (*models.RestoreReport, error) {
+	if mode != RestoreReplace && mode != RestoreMerge {
+		return nil, ErrInvalidRestoreMode
+	}
+	if archive == nil || archive.Format != ArchiveFormat {
+		return nil, ErrInvalidArchive
+	}
+	if archive.Version != ArchiveVersion {
+		return nil, fmt.Errorf("%w %d", ErrArchiveVersion, archive.Version)
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	version, err := schemaVersion(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if archive.SchemaVersion != version {
+		return nil, fmt.Errorf("%w: the archive is at %d and the database at %d", ErrSchemaMismatch, archive.SchemaVersion, version)
+	}
+
+	tables, err := archiveTables(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	for table := range archive.Tables {
+		if !slices.Contains(tables, table) {
+			return nil, fmt.Errorf("%w %q", ErrUnknownTable, table)
+		}
+	}
+
+	if mode == RestoreReplace {
+		for _, table := range slices.Backward(tables) {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM `+quoteIdent(table)); err != nil {
+				return nil, fmt.Errorf("failed to empty %s: %v", table, err)
+			}
+		}
+	}
+
+	report := &models.RestoreReport{Mode: string(mode), SchemaVersion: version, Tables: make(map[string]models.RestoreCount)}
+	ids := make(map[string]map[int64]int64)
+	for _, table := range mergeOrder(tables) {
+		rows, ok := archive.Tables[table]
+		if !ok {
+			continue
+		}
+		if mode == RestoreMerge {
+			report.Tables[table], err = mergeTable(ctx, tx, table, rows, ids)
+		} else {
+			report.Tables[table], err = restoreTable(ctx, tx, table, rows)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit restore: %v", err)
+	}
+
+	return report, nil
+}
+
+func restoreTable(ctx context.Context, tx *sql.Tx, table string, rows []map[string]any) (models.RestoreCount, error) {
+	var count models.RestoreCount
+
+	columns, err := tableColumns(ctx, tx, table)
+	if err != nil {
+		return count, err
+	}
+
+	for i, row := range rows {
+		values, err := archiveRow(table, columns, row, i)
+		if err != nil {
+			return count, err
+		}
+		names, args := rowColumns(values)
+
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO `+quoteIdent(table)+` (`+strings.Join(names, ", ")+`)
+			VALUES (`+placeholders(len(names))+`)
+			ON CONFLICT DO NOTHING`, args...)
+		if err != nil {
+			return count, fmt.Errorf("failed to restore %s row %d: %v", table, i+1, err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return count, fmt.Errorf("failed to get rows affected: %v", err)
+		}
+		if rowsAffected == 0 {
+			count.Skipped++
+		} else {
+			count.Restored++
+		}
+	}
+
+	return count, nil
+}
+
+// archiveRef is a column holding the ID of a row in table, or for pours and
+// shopping list items, in the inventory table kindColumn names.
+type archiveRef struct {
+	column     string
+	table      string
+	kindColumn string
+	// required columns can't be NULL, so a reference to a row the archive
+	// doesn't hold, such as a deleted recipe's, becomes 0, which no row has.
+	required bool
+}
+
+// archiveRefs are the references a merge rewrites to the new IDs of the rows
+// they point at.
+var archiveRefs = map[string][]archiveRef{
+	"bottles":              {{column: "ingredient_id", table: "catalog_ingredients"}},
+	"mixers":               {{column: "ingredient_id", table: "catalog_ingredients"}},
+	"fresh":                {{column: "ingredient_id", table: "catalog_ingredients"}},
+	"cocktail_ingredients": {{column: "cocktail_id", table: "cocktails", required: true}},
+	"cocktail_steps":       {{column: "cocktail_id", table: "cocktails", required: true}},
+	"catalog_ingredients":  {{column: "parent_id", table: "catalog_ingredients"}},
+	"ingredient_aliases":   {{column: "ingredient_id", table: "catalog_ingredients", required: true}},
+	"cocktail_events":      {{column: "cocktail_id", table: "cocktails", required: true}},
+	"pours": {
+		{column: "item_id", kindColumn: "item_type", required: true},
+		{column: "event_id", table: "cocktail_events"},
+	},
+	"shopping_list_items": {
+		{column: "list_id", table: "shopping_lists", required: true},
+		{column: "converted_id", kindColumn: "converted_kind"},
+	},
+}
+
+// archiveKeys are the unique columns a merge matches existing rows of a
+// table by. Rows of other tables match an existing row with the same value in
+// every column but the ID.
+var archiveKeys = map[string][]string{
+	"catalog_ingredients": {"name"},
+	"ingredient_aliases":  {"alias"},
+}
+
+// kindTable returns the inventory table of an item type, such as "bottle".
+func kindTable(kind string) string {
+	for _, stock := range []stockTable{bottleStock, mixerStock, freshStock, bittersStock, syrupStock, garnishStock} {
+		if stock.itemType == kind {
+			return stock.table
+		}
+	}
+	return ""
+}
+
+// refTables returns the tables a reference can point at.
+func (ref archiveRef) refTables() []string {
+	if ref.kindColumn == "" {
+		return []string{ref.table}
+	}
+	return []string{bottleStock.table, mixerStock.table, freshStock.table, bittersStock.table, syrupStock.table, garnishStock.table}
+}
+
+// mergeOrder orders tables so the rows a table refers to are merged, and
+// have their new IDs, before it.
+func mergeOrder(tables []string) []string {
+	ordered := make([]string, 0, len(tables))
+	seen := make(map[string]bool, len(tables))
+	var visit func(table string)
+	visit = func(table string) {
+		if seen[table] {
+			return
+		}
+		seen[table] = true
+		for _, ref := range archiveRefs[table] {
+			for _, parent := range ref.refTables() {
+				if slices.Contains(tables, parent) {
+					visit(parent)
+				}
+			}
+		}
+		ordered = append(ordered, table)
+	}
+	for _, table := range tables {
+		visit(table)
+	}
+	return ordered
+}
+
+// mergeTable adds an archived table's rows under new IDs and records them in
+// ids, by table and archived ID, for the tables merged after it.
+func mergeTable(ctx context.Context, tx *sql.Tx, table string, rows []map[string]any, ids map[string]map[int64]int64) (models.RestoreCount, error) {
+	var count models.RestoreCount
+
+	columns, err := tableColumns(ctx, tx, table)
+	if err != nil {
+		return count, err
+	}
+
+	// Only rows that were here before the merge are matched, so archived rows
+	// that are equal to each other are all added.
+	var existing int64
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM `+quoteIdent(table)).Scan(&existing); err != nil {
+		return count, fmt.Errorf("failed to get last ID of %s: %v", table, err)
+	}
+
+	mapped := make(map[int64]int64, len(rows))
+	ids[table] = mapped
+	// Rows can refer to rows of the same table that come later in the
+	// archive; those references are set once every row has its ID.
+	type laterRef struct {
+		id, ref int64
+		column  string
+	}
+	var later []laterRef
+
+	for i, row := range rows {
+		values, err := archiveRow(table, columns, row, i)
+		if err != nil {
+			return count, err
+		}
+		archivedID, _ := values["id"].(int64)
+		delete(values, "id")
+
+		var selfRefs []laterRef
+		for _, ref := range archiveRefs[table] {
+			old, ok := values[ref.column].(int64)
+			if !ok {
+				continue
+			}
+			target := ref.table
+			if ref.kindColumn != "" {
+				kind, _ := values[ref.kindColumn].(string)
+				target = kindTable(kind)
+			}
+			if id, ok := ids[target][old]; ok {
+				values[ref.column] = id
+				continue
+			}
+			if target == table {
+				selfRefs = append(selfRefs, laterRef{ref: old, column: ref.column})
+			}
+			if ref.required {
+				values[ref.column] = int64(0)
+			} else {
+				values[ref.column] = nil
+			}
+		}
+
+		if id, ok, err := matchRow(ctx, tx, table, values, existing); err != nil {
+			return count, fmt.Errorf("failed to match %s row %d: %v", table, i+1, err)
+		} else if ok {
+			mapped[archivedID] = id
+			count.Skipped++
+			continue
+		}
+
+		names, args := rowColumns(values)
+		var id int64
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO `+quoteIdent(table)+` (`+strings.Join(names, ", ")+`)
+			VALUES (`+placeholders(len(names))+`)
+			ON CONFLICT DO NOTHING
+			RETURNING id`, args...).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			count.Skipped++
+			continue
+		}
+		if err != nil {
+			return count, fmt.Errorf("failed to restore %s row %d: %v", table, i+1, err)
+		}
+		mapped[archivedID] = id
+		count.Restored++
+		for _, ref := range selfRefs {
+			ref.id = id
+			later = append(later, ref)
+		}
+	}
+
+	for _, ref := range later {
+		if id, ok := mapped[ref.ref]; ok {
+			if _, err := tx.ExecContext(ctx, `UPDATE `+quoteIdent(table)+` SET `+quoteIdent(ref.column)+` = ? WHERE id = ?`, id, ref.id); err != nil {
+				return count, fmt.Errorf("failed to restore %s reference: %v", table, err)
+			}
+		}
+	}
+
+	return count, nil
+}
+
+// matchRow finds a row that was in table before the merge and matches
+// values, by the table's archiveKeys or else by every column.
+func matchRow(ctx context.Context, tx *sql.Tx, table string, values map[string]any, existing int64) (int64, bool, error) {
+	var conditions []string
+	var args []any
+	if keys, ok := archiveKeys[table]; ok {
+		for _, key := range keys {
+			conditions = append(conditions, quoteIdent(key)+" = ?")
+			args = append(args, values[key])
+		}
+	} else {
+		names, values := rowColumns(values)
+		for i, name := range names {
+			conditions = append(conditions, name+" IS ?")
+			args = append(args, values[i])
+		}
+		conditions = append(conditions, "id <= ?")
+		args = append(args, existing)
+	}
+
+	var id int64
+	err := tx.QueryRowContext(ctx, `SELECT id FROM `+quoteIdent(table)+` WHERE `+strings.Join(conditions, " AND ")+` LIMIT 1`, args...).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return id, true, nil
+}
+
+// archiveRow checks an archived row's columns against the table's and
+// converts its values into ones to store.
+func archiveRow(table string, columns []string, row map[string]any, i int) (map[string]any, error) {
+	values := make(map[string]any, len(row))
+	for name, v := range row {
+		if !slices.Contains(columns, name) {
+			return nil, fmt.Errorf("%w %q in %s", ErrUnknownColumn, name, table)
+		}
+		value, err := archiveValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w in %s row %d, column %s", err, table, i+1, name)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// rowColumns returns a row's quoted column names, in order, and their values.
+func rowColumns(values map[string]any) ([]string, []any) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	args := make([]any, len(names))
+	for i, name := range names {
+		args[i] = values[name]
+		names[i] = quoteIdent(name)
+	}
+	return names, args
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// archiveValue converts a value decoded from an archive's JSON into one to
+// store. Numbers may be json.Number, to keep large integers exact.
+func archiveValue(v any) (any, error) {
+	switch v := v.(type) {
+	case nil, bool, string, int64, float64:
+		return v, nil
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n, nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, ErrInvalidArchiveValue
+		}
+		return f, nil
+	default:
+		return nil, ErrInvalidArchiveValue
+	}
+}
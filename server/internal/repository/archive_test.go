@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+// setupMigratedRepository is setupTestRepository with the schema_migrations
+// table golang-migrate would have left, at an arbitrary version.
+func setupMigratedRepository(t *testing.T) *Repository {
+	t.Helper()
+
+	repo := setupTestRepository(t)
+	if _, err := repo.DB.Exec(`CREATE TABLE schema_migrations (version uint64, dirty bool); INSERT INTO schema_migrations VALUES (17, FALSE)`); err != nil {
+		t.Fatalf("Failed to create schema_migrations: %v", err)
+	}
+	return repo
+}
+
+// roundTrip sends an archive through JSON, as a download and upload would.
+func roundTrip(t *testing.T, archive *models.Archive) *models.Archive {
+	t.Helper()
+
+	data, err := json.Marshal(archive)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var decoded models.Archive
+	if err := dec.Decode(&decoded); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return &decoded
+}
+
+func TestExportRestore_Replace(t *testing.T) {
+	repo := setupMigratedRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	bought := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	rye, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Rittenhouse Rye", PurchaseDate: &bought, Price: float(29.99), SizeML: float(750), ABV: float(50)})
+	if err != nil {
+		t.Fatalf("CreateBottle() error = %v", err)
+	}
+	if _, err := repo.PourBottle(ctx, int(rye.ID), 60); err != nil {
+		t.Fatalf("PourBottle() error = %v", err)
+	}
+	if _, err := repo.Syrups().Create(ctx, &models.Syrup{Name: "Demerara Syrup", Ratio: str("2:1")}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	archive, err := repo.Export(ctx)
+	if err != nil {
+		t.Fatalf("Export() error = %v, want nil", err)
+	}
+	if archive.SchemaVersion != 17 || len(archive.Tables["bottles"]) != 1 || len(archive.Tables["pours"]) != 1 {
+		t.Fatalf("Export() = version %d with %d bottles and %d pours, want 17, 1 and 1", archive.SchemaVersion, len(archive.Tables["bottles"]), len(archive.Tables["pours"]))
+	}
+	if _, ok := archive.Tables["search_index"]; ok {
+		t.Error("Export() included the search index")
+	}
+	if _, ok := archive.Tables["ai_profiles"]; ok {
+		t.Error("Export() included the AI profiles")
+	}
+
+	if _, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Campari"}); err != nil {
+		t.Fatalf("CreateBottle() error = %v", err)
+	}
+	if err := repo.DeleteBottleByID(ctx, int(rye.ID)); err != nil {
+		t.Fatalf("DeleteBottleByID() error = %v", err)
+	}
+
+	report, err := repo.Restore(ctx, roundTrip(t, archive), RestoreReplace)
+	if err != nil {
+		t.Fatalf("Restore() error = %v, want nil", err)
+	}
+	if got := report.Tables["bottles"]; got.Restored != 1 || got.Skipped != 0 {
+		t.Errorf("Restore() bottles = %+v, want 1 restored", got)
+	}
+
+	restored, err := repo.Export(ctx)
+	if err != nil {
+		t.Fatalf("Export() error = %v, want nil", err)
+	}
+	if !reflect.DeepEqual(restored.Tables, archive.Tables) {
+		t.Errorf("Export() after Restore() = %v, want %v", restored.Tables, archive.Tables)
+	}
+
+	got, err := repo.GetBottleByID(ctx, int(rye.ID))
+	if err != nil {
+		t.Fatalf("GetBottleByID() error = %v", err)
+	}
+	if !got.PurchaseDate.Equal(bought) || *got.RemainingML != 690 {
+		t.Errorf("GetBottleByID() = %+v, want the bottle as exported", got)
+	}
+
+	hits, err := repo.Search(ctx, "rittenhouse", 10)
+	if err != nil || len(hits) != 1 {
+		t.Errorf("Search() = %v, %v, want the restored bottle indexed", hits, err)
+	}
+}
+
+func TestRestore_Merge(t *testing.T) {
+	repo := setupMigratedRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	if _, err := repo.CreateMixer(ctx, &models.Mixer{Name: "Tonic Water"}); err != nil {
+		t.Fatalf("CreateMixer() error = %v", err)
+	}
+
+	archive, err := repo.Export(ctx)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	archive.Tables["mixers"] = append(archive.Tables["mixers"], map[string]any{
+		"id": json.Number("7"), "name": "Ginger Beer", "opened": false, "finished": false,
+		"created_at": "2025-01-01 00:00:00", "updated_at": "2025-01-01 00:00:00",
+	})
+
+	report, err := repo.Restore(ctx, roundTrip(t, archive), RestoreMerge)
+	if err != nil {
+		t.Fatalf("Restore() error = %v, want nil", err)
+	}
+	if got := report.Tables["mixers"]; got.Restored != 1 || got.Skipped != 1 {
+		t.Errorf("Restore() mixers = %+v, want 1 restored and 1 skipped", got)
+	}
+
+	mixers, err := repo.GetAllMixers(ctx)
+	if err != nil {
+		t.Fatalf("GetAllMixers() error = %v", err)
+	}
+	if len(mixers) != 2 {
+		t.Errorf("GetAllMixers() returned %d mixers, want 2", len(mixers))
+	}
+}
+
+// createTestBar adds a recipe, the bottles for its first ingredient and a
+// shopping list to repo.
+func createTestBar(t *testing.T, repo *Repository, recipe *models.Cocktail, bottle string) {
+	t.Helper()
+
+	ctx := context.Background()
+	if _, err := repo.CreateBottle(ctx, &models.Bottle{Name: bottle, SizeML: float(700)}); err != nil {
+		t.Fatalf("CreateBottle() error = %v", err)
+	}
+	if _, err := repo.CreateCocktail(ctx, recipe); err != nil {
+		t.Fatalf("CreateCocktail() error = %v", err)
+	}
+	if _, err := repo.MakeCocktail(ctx, recipe.ID, 1); err != nil {
+		t.Fatalf("MakeCocktail() error = %v", err)
+	}
+	list, err := repo.CreateShoppingList(ctx, &models.ShoppingList{Name: recipe.Name, Items: []models.ShoppingListItem{{Name: bottle}}})
+	if err != nil {
+		t.Fatalf("CreateShoppingList() error = %v", err)
+	}
+	if _, err := repo.ConvertShoppingItem(ctx, int(list.ID), int(list.Items[0].ID), models.ConvertShoppingItemRequest{Kind: "bottle"}); err != nil {
+		t.Fatalf("ConvertShoppingItem() error = %v", err)
+	}
+}
+
+func TestRestore_MergeAnotherDatabase(t *testing.T) {
+	ctx := context.Background()
+	a := setupMigratedRepository(t)
+	defer a.CloseDB()
+	createTestBar(t, a, &models.Cocktail{Name: "Negroni", Ingredients: []models.Ingredient{
+		{Name: "Gin", Quantity: "1 oz"}, {Name: "Campari", Quantity: "1 oz"}, {Name: "Sweet Vermouth", Quantity: "1 oz"},
+	}, Steps: []models.Step{{Order: 1, Text: "Stir with ice."}}}, "Tanqueray Gin")
+
+	b := setupMigratedRepository(t)
+	defer b.CloseDB()
+	createTestBar(t, b, &models.Cocktail{Name: "Daiquiri", Ingredients: []models.Ingredient{
+		{Name: "Rum", Quantity: "2 oz"}, {Name: "Lime Juice", Quantity: "1 oz"},
+	}}, "Plantation Rum")
+
+	archive, err := a.Export(ctx)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	report, err := b.Restore(ctx, roundTrip(t, archive), RestoreMerge)
+	if err != nil {
+		t.Fatalf("Restore() error = %v, want nil", err)
+	}
+	if got := report.Tables["cocktails"]; got.Restored != 1 || got.Skipped != 0 {
+		t.Errorf("Restore() cocktails = %+v, want the Negroni restored", got)
+	}
+	if got := report.Tables["catalog_ingredients"]; got.Restored != 0 {
+		t.Errorf("Restore() catalog ingredients = %+v, want the shared catalog skipped", got)
+	}
+
+	// Both databases numbered their rows from 1, so every reference of the
+	// merged rows must follow them to their new IDs.
+	recipes := make(map[string]*models.Cocktail)
+	cocktails, err := b.GetAllCocktails(ctx)
+	if err != nil {
+		t.Fatalf("GetAllCocktails() error = %v", err)
+	}
+	for _, cocktail := range cocktails {
+		recipes[cocktail.Name] = cocktail
+	}
+	negroni, daiquiri := recipes["Negroni"], recipes["Daiquiri"]
+	if negroni == nil || len(negroni.Ingredients) != 3 || len(negroni.Steps) != 1 {
+		t.Fatalf("merged Negroni = %+v, want its three ingredients and step", negroni)
+	}
+	if daiquiri == nil || len(daiquiri.Ingredients) != 2 || len(daiquiri.Steps) != 0 {
+		t.Fatalf("Daiquiri = %+v, want only its own two ingredients", daiquiri)
+	}
+
+	bottles := make(map[int64]string)
+	all, err := b.GetAllBottles(ctx)
+	if err != nil {
+		t.Fatalf("GetAllBottles() error = %v", err)
+	}
+	for _, bottle := range all {
+		bottles[bottle.ID] = bottle.Name
+	}
+
+	events, err := b.GetCocktailEvents(ctx, negroni.ID)
+	if err != nil {
+		t.Fatalf("GetCocktailEvents() error = %v", err)
+	}
+	if len(events) != 1 || len(events[0].Deductions) != 1 || bottles[events[0].Deductions[0].ItemID] != "Tanqueray Gin" {
+		t.Errorf("merged Negroni events = %+v, want one pour from the Tanqueray Gin", events)
+	}
+
+	lists, err := b.GetAllShoppingLists(ctx)
+	if err != nil {
+		t.Fatalf("GetAllShoppingLists() error = %v", err)
+	}
+	for _, list := range lists {
+		item := list.Items[0]
+		if len(list.Items) != 1 || item.ConvertedID == nil || bottles[*item.ConvertedID] != item.Name {
+			t.Errorf("shopping list %s items = %+v, want its item converted to its own bottle", list.Name, list.Items)
+		}
+	}
+	if len(lists) != 2 {
+		t.Errorf("GetAllShoppingLists() returned %d lists, want 2", len(lists))
+	}
+
+	// Merging the same archive again finds every row already there.
+	report, err = b.Restore(ctx, roundTrip(t, archive), RestoreMerge)
+	if err != nil {
+		t.Fatalf("Restore() again error = %v, want nil", err)
+	}
+	for table, got := range report.Tables {
+		if got.Restored != 0 {
+			t.Errorf("Restore() again restored %d rows of %s, want none", got.Restored, table)
+		}
+	}
+}
+
+func TestRestore_Rejects(t *testing.T) {
+	repo := setupMigratedRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	if _, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Campari"}); err != nil {
+		t.Fatalf("CreateBottle() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*models.Archive)
+		mode   RestoreMode
+		want   error
+	}{
+		{"bad mode", func(*models.Archive) {}, "append", ErrInvalidRestoreMode},
+		{"other format", func(a *models.Archive) { a.Format = "something-else" }, RestoreReplace, ErrInvalidArchive},
+		{"newer archive", func(a *models.Archive) { a.Version = ArchiveVersion + 1 }, RestoreReplace, ErrArchiveVersion},
+		{"other schema", func(a *models.Archive) { a.SchemaVersion = 16 }, RestoreReplace, ErrSchemaMismatch},
+		{"unknown table", func(a *models.Archive) { a.Tables["wines"] = nil }, RestoreReplace, ErrUnknownTable},
+		{"unknown column", func(a *models.Archive) { a.Tables["bottles"][0]["colour"] = "red" }, RestoreReplace, ErrUnknownColumn},
+		{"nested value", func(a *models.Archive) { a.Tables["bottles"][0]["name"] = []any{"x"} }, RestoreReplace, ErrInvalidArchiveValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive, err := repo.Export(ctx)
+			if err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+			tt.mutate(archive)
+
+			if _, err := repo.Restore(ctx, archive, tt.mode); !errors.Is(err, tt.want) {
+				t.Errorf("Restore() error = %v, want %v", err, tt.want)
+			}
+
+			bottles, err := repo.GetAllBottles(ctx)
+			if err != nil || len(bottles) != 1 {
+				t.Errorf("GetAllBottles() = %d bottles, %v, want the database untouched", len(bottles), err)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+var (
+	ErrNilCatalogIngredient      = errors.New("catalog ingredient cannot be nil")
+	ErrCatalogIngredientNotFound = errors.New("catalog ingredient not found")
+)
+
+func (r *Repository) CreateCatalogIngredient(ctx context.Context, ingredient *models.CatalogIngredient) (*models.CatalogIngredient, error) {
+	if ingredient == nil {
+		return nil, ErrNilCatalogIngredient
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if ingredient.ParentID != nil {
+		if err := catalogIngredientExists(ctx, tx, *ingredient.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	query := `
+		INSERT INTO catalog_ingredients (name, parent_id, created_at, updated_at)
+		VALUES (?, ?, datetime('now'), datetime('now'))
+		RETURNING id, created_at, updated_at`
+
+	err = tx.QueryRowContext(ctx, query, ingredient.Name, ingredient.ParentID).Scan(&ingredient.ID, &ingredient.CreatedAt, &ingredient.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create catalog ingredient: %v", err)
+	}
+
+	for _, alias := range ingredient.Aliases {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO ingredient_aliases (ingredient_id, alias) VALUES (?, ?)`, ingredient.ID, alias); err != nil {
+			return nil, fmt.Errorf("failed to create ingredient alias: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit catalog ingredient: %v", err)
+	}
+
+	if ingredient.Aliases == nil {
+		ingredient.Aliases = []string{}
+	}
+
+	return ingredient, nil
+}
+
+func (r *Repository) GetCatalogIngredientByID(ctx context.Context, id int64) (*models.CatalogIngredient, error) {
+	query := `
+		SELECT id, name, parent_id, created_at, updated_at
+		FROM catalog_ingredients
+		WHERE id = ?`
+
+	var ingredient models.CatalogIngredient
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(&ingredient.ID, &ingredient.Name, &ingredient.ParentID, &ingredient.CreatedAt, &ingredient.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCatalogIngredientNotFound
+		}
+		return nil, fmt.Errorf("failed to get catalog ingredient by ID: %v", err)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `SELECT alias FROM ingredient_aliases WHERE ingredient_id = ? ORDER BY alias`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingredient aliases: %v", err)
+	}
+	defer rows.Close()
+
+	ingredient.Aliases = []string{}
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			return nil, fmt.Errorf("failed to scan ingredient alias: %v", err)
+		}
+		ingredient.Aliases = append(ingredient.Aliases, alias)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over ingredient aliases: %v", err)
+	}
+
+	return &ingredient, nil
+}
+
+// GetAllCatalogIngredients returns the whole catalog with aliases, ordered by
+// name.
+func (r *Repository) GetAllCatalogIngredients(ctx context.Context) ([]*models.CatalogIngredient, error) {
+	return getAllCatalogIngredients(ctx, r.DB)
+}
+
+func getAllCatalogIngredients(ctx context.Context, q querier) ([]*models.CatalogIngredient, error) {
+	query := `
+		SELECT id, name, parent_id, created_at, updated_at
+		FROM catalog_ingredients
+		ORDER BY name`
+
+	rows, err := q.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalog ingredients: %v", err)
+	}
+	defer rows.Close()
+
+	var ingredients []*models.CatalogIngredient
+	byID := make(map[int64]*models.CatalogIngredient)
+	for rows.Next() {
+		var ingredient models.CatalogIngredient
+		err := rows.Scan(&ingredient.ID, &ingredient.Name, &ingredient.ParentID, &ingredient.CreatedAt, &ingredient.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan catalog ingredient: %v", err)
+		}
+
+		ingredient.Aliases = []string{}
+		ingredients = append(ingredients, &ingredient)
+		byID[ingredient.ID] = &ingredient
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over catalog ingredients: %v", err)
+	}
+
+	aliasRows, err := q.QueryContext(ctx, `SELECT ingredient_id, alias FROM ingredient_aliases ORDER BY alias`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingredient aliases: %v", err)
+	}
+	defer aliasRows.Close()
+
+	for aliasRows.Next() {
+		var ingredientID int64
+		var alias string
+		if err := aliasRows.Scan(&ingredientID, &alias); err != nil {
+			return nil, fmt.Errorf("failed to scan ingredient alias: %v", err)
+		}
+		if ingredient, ok := byID[ingredientID]; ok {
+			ingredient.Aliases = append(ingredient.Aliases, alias)
+		}
+	}
+	if err := aliasRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over ingredient aliases: %v", err)
+	}
+
+	return ingredients, nil
+}
+
+// DeleteCatalogIngredientByID removes an ingredient and its aliases. Children
+// are moved up to the deleted ingredient's parent and inventory links to it
+// are cleared.
+func (r *Repository) DeleteCatalogIngredientByID(ctx context.Context, id int64) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var parentID *int64
+	err = tx.QueryRowContext(ctx, `SELECT parent_id FROM catalog_ingredients WHERE id = ?`, id).Scan(&parentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCatalogIngredientNotFound
+		}
+		return fmt.Errorf("failed to get catalog ingredient: %v", err)
+	}
+
+	statements := []struct {
+		query string
+		args  []any
+	}{
+		{`UPDATE catalog_ingredients SET parent_id = ? WHERE parent_id = ?`, []any{parentID, id}},
+		{`DELETE FROM ingredient_aliases WHERE ingredient_id = ?`, []any{id}},
+		{`UPDATE bottles SET ingredient_id = NULL WHERE ingredient_id = ?`, []any{id}},
+		{`UPDATE mixers SET ingredient_id = NULL WHERE ingredient_id = ?`, []any{id}},
+		{`UPDATE fresh SET ingredient_id = NULL WHERE ingredient_id = ?`, []any{id}},
+		{`DELETE FROM catalog_ingredients WHERE id = ?`, []any{id}},
+	}
+	for _, stmt := range statements {
+		if _, err := tx.ExecContext(ctx, stmt.query, stmt.args...); err != nil {
+			return fmt.Errorf("failed to delete catalog ingredient: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit catalog ingredient deletion: %v", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) AddIngredientAlias(ctx context.Context, ingredientID int64, alias string) error {
+	if err := catalogIngredientExists(ctx, r.DB, ingredientID); err != nil {
+		return err
+	}
+
+	if _, err := r.DB.ExecContext(ctx, `INSERT INTO ingredient_aliases (ingredient_id, alias) VALUES (?, ?)`, ingredientID, alias); err != nil {
+		return fmt.Errorf("failed to create ingredient alias: %v", err)
+	}
+
+	return nil
+}
+
+// LinkBottleIngredient links a bottle to a catalog ingredient. Passing a nil
+// ingredientID clears the link.
+func (r *Repository) LinkBottleIngredient(ctx context.Context, bottleID int, ingredientID *int64) error {
+	return r.linkIngredient(ctx, "bottles", bottleID, ingredientID, ErrBottleNotFound)
+}
+
+// LinkMixerIngredient links a mixer to a catalog ingredient. Passing a nil
+// ingredientID clears the link.
+func (r *Repository) LinkMixerIngredient(ctx context.Context, mixerID int, ingredientID *int64) error {
+	return r.linkIngredient(ctx, "mixers", mixerID, ingredientID, ErrMixerNotFound)
+}
+
+// LinkFreshIngredient links a fresh item to a catalog ingredient. Passing a nil
+// ingredientID clears the link.
+func (r *Repository) LinkFreshIngredient(ctx context.Context, freshID int, ingredientID *int64) error {
+	return r.linkIngredient(ctx, "fresh", freshID, ingredientID, ErrFreshNotFound)
+}
+
+// linkIngredient sets ingredient_id on a row of one of the fixed inventory
+// tables. table is never user input.
+func (r *Repository) linkIngredient(ctx context.Context, table string, id int, ingredientID *int64, notFound error) error {
+	if ingredientID != nil {
+		if err := catalogIngredientExists(ctx, r.DB, *ingredientID); err != nil {
+			return err
+		}
+	}
+
+	query := `UPDATE ` + table + ` SET ingredient_id = ?, updated_at = datetime('now') WHERE id = ?`
+	result, err := r.DB.ExecContext(ctx, query, ingredientID, id)
+	if err != nil {
+		return fmt.Errorf("failed to link ingredient: %v", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return notFound
+	}
+
+	return nil
+}
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// querier is a *sql.DB or *sql.Tx to read through.
+type querier interface {
+	queryRower
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func catalogIngredientExists(ctx context.Context, q queryRower, id int64) error {
+	var exists bool
+	err := q.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM catalog_ingredients WHERE id = ?)`, id).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check catalog ingredient: %v", err)
+	}
+	if !exists {
+		return ErrCatalogIngredientNotFound
+	}
+	return nil
+}
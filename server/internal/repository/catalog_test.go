@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+func TestGetAllCatalogIngredients_Seeded(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ingredients, err := repo.GetAllCatalogIngredients(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllCatalogIngredients() error = %v, want nil", err)
+	}
+
+	var bourbon *models.CatalogIngredient
+	for _, ingredient := range ingredients {
+		if ingredient.Name == "Bourbon" {
+			bourbon = ingredient
+		}
+	}
+
+	if bourbon == nil {
+		t.Fatal("GetAllCatalogIngredients() did not include seeded Bourbon")
+	}
+
+	if bourbon.ParentID == nil {
+		t.Error("Seeded Bourbon has no parent")
+	}
+
+	found := false
+	for _, alias := range bourbon.Aliases {
+		if alias == "buffalo trace" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Seeded Bourbon aliases = %v, want to include buffalo trace", bourbon.Aliases)
+	}
+}
+
+func TestCreateCatalogIngredient(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	parent, err := repo.CreateCatalogIngredient(ctx, &models.CatalogIngredient{Name: "Amaro"})
+	if err != nil {
+		t.Fatalf("CreateCatalogIngredient() error = %v, want nil", err)
+	}
+
+	child, err := repo.CreateCatalogIngredient(ctx, &models.CatalogIngredient{
+		Name:     "Amaro Montenegro",
+		ParentID: &parent.ID,
+		Aliases:  []string{"montenegro"},
+	})
+	if err != nil {
+		t.Fatalf("CreateCatalogIngredient() error = %v, want nil", err)
+	}
+
+	result, err := repo.GetCatalogIngredientByID(ctx, child.ID)
+	if err != nil {
+		t.Fatalf("GetCatalogIngredientByID() error = %v, want nil", err)
+	}
+
+	if result.ParentID == nil || *result.ParentID != parent.ID {
+		t.Errorf("GetCatalogIngredientByID() parent = %v, want %d", result.ParentID, parent.ID)
+	}
+
+	if len(result.Aliases) != 1 || result.Aliases[0] != "montenegro" {
+		t.Errorf("GetCatalogIngredientByID() aliases = %v, want [montenegro]", result.Aliases)
+	}
+
+	missing := int64(99999)
+	_, err = repo.CreateCatalogIngredient(ctx, &models.CatalogIngredient{Name: "Orphan", ParentID: &missing})
+	if err != ErrCatalogIngredientNotFound {
+		t.Errorf("CreateCatalogIngredient() error = %v, want %v", err, ErrCatalogIngredientNotFound)
+	}
+}
+
+func TestLinkBottleIngredient(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	bottle, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Buffalo Trace"})
+	if err != nil {
+		t.Fatalf("CreateBottle() error = %v, want nil", err)
+	}
+
+	ingredient, err := repo.CreateCatalogIngredient(ctx, &models.CatalogIngredient{Name: "Kentucky Straight Bourbon"})
+	if err != nil {
+		t.Fatalf("CreateCatalogIngredient() error = %v, want nil", err)
+	}
+
+	if err := repo.LinkBottleIngredient(ctx, int(bottle.ID), &ingredient.ID); err != nil {
+		t.Fatalf("LinkBottleIngredient() error = %v, want nil", err)
+	}
+
+	linked, err := repo.GetBottleByID(ctx, int(bottle.ID))
+	if err != nil {
+		t.Fatalf("GetBottleByID() error = %v, want nil", err)
+	}
+
+	if linked.IngredientID == nil || *linked.IngredientID != ingredient.ID {
+		t.Errorf("GetBottleByID() ingredient = %v, want %d", linked.IngredientID, ingredient.ID)
+	}
+
+	if err := repo.DeleteCatalogIngredientByID(ctx, ingredient.ID); err != nil {
+		t.Fatalf("DeleteCatalogIngredientByID() error = %v, want nil", err)
+	}
+
+	unlinked, err := repo.GetBottleByID(ctx, int(bottle.ID))
+	if err != nil {
+		t.Fatalf("GetBottleByID() error = %v, want nil", err)
+	}
+
+	if unlinked.IngredientID != nil {
+		t.Errorf("GetBottleByID() ingredient = %v, want nil after deleting the ingredient", *unlinked.IngredientID)
+	}
+
+	if err := repo.LinkBottleIngredient(ctx, 99999, nil); err != ErrBottleNotFound {
+		t.Errorf("LinkBottleIngredient() error = %v, want %v", err, ErrBottleNotFound)
+	}
+}
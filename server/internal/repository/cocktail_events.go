@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nguyenjessev/liquor-locker/internal/catalog"
+	"github.com/nguyenjessev/liquor-locker/internal/matcher"
+	"github.com/nguyenjessev/liquor-locker/internal/measure"
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+var ErrInvalidServings = errors.New("servings must be greater than zero")
+
+// MakeCocktail records that a cocktail was made and deducts each ingredient's
+// quantity, scaled by servings, from the matching inventory items with a
+// tracked volume, dashes of bitters or count of garnishes, moving on to the
+// next match when one runs out. Only volumes are recorded as pours. The event
+// and every deduction are written in one transaction. Ingredients that cannot
+// be measured or matched, and the part of an ingredient the inventory was
+// short of, are reported in the event's Skipped list rather than failing the
+// whole event.
+func (r *Repository) MakeCocktail(ctx context.Context, cocktailID int, servings int) (*models.CocktailEvent, error) {
+	if servings <= 0 {
+		return nil, ErrInvalidServings
+	}
+
+	cocktail, err := r.GetCocktailByID(ctx, cocktailID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	event := &models.CocktailEvent{
+		CocktailID:   cocktail.ID,
+		CocktailName: cocktail.Name,
+		Servings:     servings,
+	}
+	query := `
+		INSERT INTO cocktail_events (cocktail_id, cocktail_name, servings, made_at)
+		VALUES (?, ?, ?, datetime('now'))
+		RETURNING id, made_at`
+	if err := tx.QueryRowContext(ctx, query, event.CocktailID, event.CocktailName, event.Servings).Scan(&event.ID, &event.MadeAt); err != nil {
+		return nil, fmt.Errorf("failed to create cocktail event: %v", err)
+	}
+
+	// The stock is read after the insert, which takes the write lock, so a
+	// cocktail made at the same time waits and sees these deductions.
+	m, err := stockMatcher(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	skipped := []models.SkippedIngredient{}
+	skip := func(ingredient models.Ingredient, reason string) {
+		skipped = append(skipped, models.SkippedIngredient{Name: ingredient.Name, Quantity: ingredient.Quantity, Reason: reason})
+	}
+	for _, ingredient := range cocktail.Ingredients {
+		if matcher.IsStaple(ingredient.Name) {
+			continue
+		}
+
+		quantity, err := measure.Parse(ingredient.Quantity)
+		if err != nil {
+			skip(ingredient, "quantity could not be parsed")
+			continue
+		}
+
+		items := m.FindAll(ingredient.Name)
+		if len(items) == 0 {
+			skip(ingredient, "no inventory item with a tracked amount matches")
+			continue
+		}
+
+		// The best match decides whether the ingredient is poured, dashed or
+		// counted out; only matches kept the same way can make up for it.
+		stock := stockTables[items[0].Kind]
+		want, reason := stockAmount(stock, quantity, servings)
+		if reason != "" {
+			skip(ingredient, reason)
+			continue
+		}
+
+		short := want.Amount
+		for _, item := range items {
+			itemStock := stockTables[item.Kind]
+			if itemStock.unit != stock.unit {
+				continue
+			}
+
+			var used float64
+			if itemStock.unit == measure.Milliliter {
+				used, err = pourTx(ctx, tx, itemStock, int(item.ID), short, &event.ID)
+			} else {
+				used, err = takeTx(ctx, tx, itemStock, int(item.ID), short)
+			}
+			if errors.Is(err, ErrItemFinished) {
+				// An earlier ingredient of this cocktail used the item up.
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if short -= used; short <= 0 {
+				break
+			}
+		}
+		switch {
+		case short == want.Amount:
+			skip(ingredient, "the matching inventory items ran out")
+		case short > 0:
+			left, used := want, want
+			left.Amount, used.Amount = short, want.Amount-short
+			skipped = append(skipped, models.SkippedIngredient{
+				Name:     ingredient.Name,
+				Quantity: left.String(),
+				Reason:   fmt.Sprintf("only %s of %s was in stock", used, want),
+			})
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit cocktail event: %v", err)
+	}
+
+	event.Deductions, err = r.getEventPours(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	event.Skipped = skipped
+
+	return event, nil
+}
+
+var stockTables = map[string]stockTable{
+	bottleStock.itemType:  bottleStock,
+	mixerStock.itemType:   mixerStock,
+	freshStock.itemType:   freshStock,
+	syrupStock.itemType:   syrupStock,
+	bittersStock.itemType: bittersStock,
+	garnishStock.itemType: garnishStock,
+}
+
+// stockAmount converts a recipe quantity, scaled by servings, into the unit
+// stock is kept in. If it can't be, it returns why instead.
+func stockAmount(stock stockTable, quantity measure.Quantity, servings int) (measure.Quantity, string) {
+	if stock.unit == measure.Count {
+		if quantity.Unit != measure.Count {
+			return measure.Quantity{}, "quantity is not a number of pieces"
+		}
+		return measure.Scale(quantity, float64(servings)), ""
+	}
+
+	converted, err := measure.Convert(quantity, stock.unit)
+	if err != nil {
+		return measure.Quantity{}, "quantity is not a measurable volume"
+	}
+	converted.Item = ""
+	return measure.Scale(converted, float64(servings)), ""
+}
+
+// stockMatcher builds a matcher over inventory items that have something left
+// to deduct from: a volume, or a count of dashes or pieces. Opened bottles and
+// mixers come first so they are used up before new ones are started.
+func stockMatcher(ctx context.Context, q querier) (*matcher.Matcher, error) {
+	bottles, err := (&Inventory[models.Bottle]{table: &bottleTable}).all(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	mixers, err := (&Inventory[models.Mixer]{table: &mixerTable}).all(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	fresh, err := (&Inventory[models.Fresh]{table: &freshTable}).all(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	syrups, err := (&Inventory[models.Syrup]{table: &syrupTable}).all(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	bitters, err := (&Inventory[models.Bitters]{table: &bittersTable}).all(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	garnishes, err := (&Inventory[models.Garnish]{table: &garnishTable}).all(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := getAllCatalogIngredients(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var openBottles, sealedBottles []*models.Bottle
+	for _, bottle := range bottles {
+		if bottle.Finished || (bottle.SizeML == nil && bottle.RemainingML == nil) {
+			continue
+		}
+		if bottle.Opened {
+			openBottles = append(openBottles, bottle)
+		} else {
+			sealedBottles = append(sealedBottles, bottle)
+		}
+	}
+	var openMixers, sealedMixers []*models.Mixer
+	for _, mixer := range mixers {
+		if mixer.Finished || (mixer.SizeML == nil && mixer.RemainingML == nil) {
+			continue
+		}
+		if mixer.Opened {
+			openMixers = append(openMixers, mixer)
+		} else {
+			sealedMixers = append(sealedMixers, mixer)
+		}
+	}
+	var trackedFresh []*models.Fresh
+	for _, item := range fresh {
+		if !item.Finished && (item.SizeML != nil || item.RemainingML != nil) {
+			trackedFresh = append(trackedFresh, item)
+		}
+	}
+
+	var trackedSyrups []*models.Syrup
+	for _, syrup := range syrups {
+		if !syrup.Finished && (syrup.SizeML != nil || syrup.RemainingML != nil) {
+			trackedSyrups = append(trackedSyrups, syrup)
+		}
+	}
+
+	var trackedBitters []*models.Bitters
+	for _, item := range bitters {
+		if item.DashesRemaining != nil {
+			trackedBitters = append(trackedBitters, item)
+		}
+	}
+	var trackedGarnishes []*models.Garnish
+	for _, garnish := range garnishes {
+		if garnish.Quantity != nil {
+			trackedGarnishes = append(trackedGarnishes, garnish)
+		}
+	}
+
+	stock := append(
+		matcher.Inventory(matcher.Stock{
+			Bottles:   openBottles,
+			Mixers:    openMixers,
+			Fresh:     trackedFresh,
+			Bitters:   trackedBitters,
+			Syrups:    trackedSyrups,
+			Garnishes: trackedGarnishes,
+		}),
+		matcher.Inventory(matcher.Stock{Bottles: sealedBottles, Mixers: sealedMixers})...,
+	)
+	return matcher.New(stock).WithResolver(catalog.New(entries)), nil
+}
+
+// GetCocktailEvents returns the cocktails that have been made, newest first,
+// with the pours each one took. A cocktailID of zero returns every event.
+func (r *Repository) GetCocktailEvents(ctx context.Context, cocktailID int) ([]*models.CocktailEvent, error) {
+	query := `
+		SELECT id, cocktail_id, cocktail_name, servings, made_at
+		FROM cocktail_events
+		WHERE ? = 0 OR cocktail_id = ?
+		ORDER BY made_at DESC, id DESC`
+
+	rows, err := r.DB.QueryContext(ctx, query, cocktailID, cocktailID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cocktail events: %v", err)
+	}
+	defer rows.Close()
+
+	events := []*models.CocktailEvent{}
+	byID := make(map[int64]*models.CocktailEvent)
+	for rows.Next() {
+		var event models.CocktailEvent
+		if err := rows.Scan(&event.ID, &event.CocktailID, &event.CocktailName, &event.Servings, &event.MadeAt); err != nil {
+			return nil, fmt.Errorf("failed to scan cocktail event: %v", err)
+		}
+		event.Deductions = []*models.Pour{}
+		events = append(events, &event)
+		byID[event.ID] = &event
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over cocktail events: %v", err)
+	}
+	rows.Close()
+
+	pours, err := r.queryPours(ctx, `
+		WHERE event_id IN (SELECT id FROM cocktail_events WHERE ? = 0 OR cocktail_id = ?)
+		ORDER BY id`, cocktailID, cocktailID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pour := range pours {
+		if event, ok := byID[*pour.EventID]; ok {
+			event.Deductions = append(event.Deductions, pour)
+		}
+	}
+
+	return events, nil
+}
+
+func (r *Repository) getEventPours(ctx context.Context, eventID int64) ([]*models.Pour, error) {
+	return r.queryPours(ctx, `WHERE event_id = ? ORDER BY id`, eventID)
+}
@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+func TestMakeCocktail_DeductsInventory(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	gin, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Beefeater Gin", SizeML: float(700)})
+	if err != nil {
+		t.Fatalf("CreateBottle() error = %v, want nil", err)
+	}
+	lime, err := repo.CreateFresh(ctx, &models.Fresh{Name: "Lime Juice", SizeML: float(250)})
+	if err != nil {
+		t.Fatalf("CreateFresh() error = %v, want nil", err)
+	}
+	syrup, err := repo.Syrups().Create(ctx, &models.Syrup{Name: "Simple Syrup", SizeML: float(500)})
+	if err != nil {
+		t.Fatalf("Syrups().Create() error = %v, want nil", err)
+	}
+	if _, err := repo.Garnishes().Create(ctx, &models.Garnish{Name: "Lime Wheel"}); err != nil {
+		t.Fatalf("Garnishes().Create() error = %v, want nil", err)
+	}
+	cocktail, err := repo.CreateCocktail(ctx, &models.Cocktail{
+		Name: "Gimlet",
+		Ingredients: []models.Ingredient{
+			{Name: "Gin", Quantity: "60 ml"},
+			{Name: "Lime Juice", Quantity: "3/4 oz"},
+			{Name: "Simple Syrup", Quantity: "15 ml"},
+			{Name: "Lime Wheel", Quantity: "1"},
+			{Name: "Ice", Quantity: "1 cup"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateCocktail() error = %v, want nil", err)
+	}
+
+	event, err := repo.MakeCocktail(ctx, cocktail.ID, 2)
+	if err != nil {
+		t.Fatalf("MakeCocktail() error = %v, want nil", err)
+	}
+
+	if event.Servings != 2 || event.CocktailName != "Gimlet" {
+		t.Errorf("MakeCocktail() event = %+v, want 2 servings of Gimlet", event)
+	}
+	if len(event.Deductions) != 3 {
+		t.Fatalf("MakeCocktail() deductions = %d, want 3", len(event.Deductions))
+	}
+	if len(event.Skipped) != 1 || event.Skipped[0].Name != "Lime Wheel" {
+		t.Errorf("MakeCocktail() skipped = %v, want the lime wheel, which isn't counted by volume", event.Skipped)
+	}
+
+	bottle, err := repo.GetBottleByID(ctx, int(gin.ID))
+	if err != nil {
+		t.Fatalf("GetBottleByID() error = %v, want nil", err)
+	}
+	if *bottle.RemainingML != 580 || !bottle.Opened {
+		t.Errorf("gin remaining = %v, opened = %v, want 580 and opened", *bottle.RemainingML, bottle.Opened)
+	}
+
+	fresh, err := repo.GetFreshByID(ctx, int(lime.ID))
+	if err != nil {
+		t.Fatalf("GetFreshByID() error = %v, want nil", err)
+	}
+	if want := 250 - 2*0.75*29.5735; math.Abs(*fresh.RemainingML-want) > 0.001 {
+		t.Errorf("lime juice remaining = %v, want %v", *fresh.RemainingML, want)
+	}
+
+	syrup, err = repo.Syrups().Get(ctx, int(syrup.ID))
+	if err != nil {
+		t.Fatalf("Syrups().Get() error = %v, want nil", err)
+	}
+	if *syrup.RemainingML != 470 {
+		t.Errorf("simple syrup remaining = %v, want 470", *syrup.RemainingML)
+	}
+
+	events, err := repo.GetCocktailEvents(ctx, 0)
+	if err != nil {
+		t.Fatalf("GetCocktailEvents() error = %v, want nil", err)
+	}
+	if len(events) != 1 || len(events[0].Deductions) != 3 {
+		t.Errorf("GetCocktailEvents() = %v, want one event with three deductions", events)
+	}
+}
+
+func TestMakeCocktail_PrefersOpenedBottle(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	if _, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Rum", SizeML: float(700)}); err != nil {
+		t.Fatalf("CreateBottle() error = %v, want nil", err)
+	}
+	opened, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Rum", Opened: true, SizeML: float(700), RemainingML: float(100)})
+	if err != nil {
+		t.Fatalf("CreateBottle() error = %v, want nil", err)
+	}
+	cocktail, err := repo.CreateCocktail(ctx, &models.Cocktail{
+		Name:        "Rum Shot",
+		Ingredients: []models.Ingredient{{Name: "Rum", Quantity: "1 oz"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateCocktail() error = %v, want nil", err)
+	}
+
+	event, err := repo.MakeCocktail(ctx, cocktail.ID, 1)
+	if err != nil {
+		t.Fatalf("MakeCocktail() error = %v, want nil", err)
+	}
+
+	if len(event.Deductions) != 1 || event.Deductions[0].ItemID != opened.ID {
+		t.Errorf("MakeCocktail() deductions = %v, want a pour from the opened bottle", event.Deductions)
+	}
+}
+
+func TestMakeCocktail_PartialPour(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	opened, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Rum", Opened: true, SizeML: float(700), RemainingML: float(10)})
+	if err != nil {
+		t.Fatalf("CreateBottle() error = %v, want nil", err)
+	}
+	sealed, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Rum", SizeML: float(30)})
+	if err != nil {
+		t.Fatalf("CreateBottle() error = %v, want nil", err)
+	}
+	if _, err := repo.CreateFresh(ctx, &models.Fresh{Name: "Lime Juice", SizeML: float(250), RemainingML: float(10)}); err != nil {
+		t.Fatalf("CreateFresh() error = %v, want nil", err)
+	}
+	cocktail, err := repo.CreateCocktail(ctx, &models.Cocktail{
+		Name:        "Daiquiri",
+		Ingredients: []models.Ingredient{{Name: "Rum", Quantity: "60 ml"}, {Name: "Lime Juice", Quantity: "30 ml"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateCocktail() error = %v, want nil", err)
+	}
+
+	event, err := repo.MakeCocktail(ctx, cocktail.ID, 1)
+	if err != nil {
+		t.Fatalf("MakeCocktail() error = %v, want nil", err)
+	}
+
+	if len(event.Deductions) != 3 || event.Deductions[0].ItemID != opened.ID || event.Deductions[1].ItemID != sealed.ID {
+		t.Fatalf("MakeCocktail() deductions = %v, want the opened rum, then the sealed one, then the lime juice", event.Deductions)
+	}
+	if event.Deductions[0].AmountML != 10 || event.Deductions[1].AmountML != 30 || event.Deductions[2].AmountML != 10 {
+		t.Errorf("MakeCocktail() poured %v, %v and %v ml; want 10, 30 and 10", event.Deductions[0].AmountML, event.Deductions[1].AmountML, event.Deductions[2].AmountML)
+	}
+
+	want := []models.SkippedIngredient{
+		{Name: "Rum", Quantity: "20 ml", Reason: "only 40 ml of 60 ml was in stock"},
+		{Name: "Lime Juice", Quantity: "20 ml", Reason: "only 10 ml of 30 ml was in stock"},
+	}
+	if len(event.Skipped) != len(want) {
+		t.Fatalf("MakeCocktail() skipped = %v, want %v", event.Skipped, want)
+	}
+	for i := range want {
+		if event.Skipped[i] != want[i] {
+			t.Errorf("MakeCocktail() skipped[%d] = %v, want %v", i, event.Skipped[i], want[i])
+		}
+	}
+}
+
+func TestMakeCocktail_CountsDashesAndPieces(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	dashes, cherries := 100, 3
+	bitters, err := repo.Bitters().Create(ctx, &models.Bitters{Name: "Angostura Bitters", DashesRemaining: &dashes})
+	if err != nil {
+		t.Fatalf("Bitters().Create() error = %v, want nil", err)
+	}
+	cherry, err := repo.Garnishes().Create(ctx, &models.Garnish{Name: "Cocktail Cherry", Quantity: &cherries})
+	if err != nil {
+		t.Fatalf("Garnishes().Create() error = %v, want nil", err)
+	}
+	if _, err := repo.Garnishes().Create(ctx, &models.Garnish{Name: "Orange Peel"}); err != nil {
+		t.Fatalf("Garnishes().Create() error = %v, want nil", err)
+	}
+	cocktail, err := repo.CreateCocktail(ctx, &models.Cocktail{
+		Name: "Manhattan",
+		Ingredients: []models.Ingredient{
+			{Name: "Angostura Bitters", Quantity: "2 dashes"},
+			{Name: "Cherry", Quantity: "1 cherry"},
+			{Name: "Orange Peel", Quantity: "1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateCocktail() error = %v, want nil", err)
+	}
+
+	event, err := repo.MakeCocktail(ctx, cocktail.ID, 2)
+	if err != nil {
+		t.Fatalf("MakeCocktail() error = %v, want nil", err)
+	}
+	if len(event.Deductions) != 0 {
+		t.Errorf("MakeCocktail() deductions = %v, want no pours for dashes and pieces", event.Deductions)
+	}
+	if len(event.Skipped) != 1 || event.Skipped[0].Name != "Orange Peel" {
+		t.Errorf("MakeCocktail() skipped = %v, want only the orange peel, whose count isn't tracked", event.Skipped)
+	}
+
+	bitters, err = repo.Bitters().Get(ctx, int(bitters.ID))
+	if err != nil {
+		t.Fatalf("Bitters().Get() error = %v, want nil", err)
+	}
+	if *bitters.DashesRemaining != 96 || !bitters.Opened {
+		t.Errorf("bitters dashes remaining = %d, opened = %v; want 96 and opened", *bitters.DashesRemaining, bitters.Opened)
+	}
+	cherry, err = repo.Garnishes().Get(ctx, int(cherry.ID))
+	if err != nil {
+		t.Fatalf("Garnishes().Get() error = %v, want nil", err)
+	}
+	if *cherry.Quantity != 1 {
+		t.Errorf("cherries left = %d, want 1", *cherry.Quantity)
+	}
+
+	event, err = repo.MakeCocktail(ctx, cocktail.ID, 2)
+	if err != nil {
+		t.Fatalf("MakeCocktail() error = %v, want nil", err)
+	}
+	want := models.SkippedIngredient{Name: "Cherry", Quantity: "1 cherry", Reason: "only 1 cherry of 2 cherry was in stock"}
+	if len(event.Skipped) != 2 || event.Skipped[0] != want {
+		t.Errorf("MakeCocktail() skipped = %v, want the missing cherry and the orange peel", event.Skipped)
+	}
+}
+
+func TestMakeCocktail_Errors(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	if _, err := repo.MakeCocktail(ctx, 999, 1); err != ErrCocktailNotFound {
+		t.Errorf("MakeCocktail() error = %v, want %v", err, ErrCocktailNotFound)
+	}
+	if _, err := repo.MakeCocktail(ctx, 1, 0); err != ErrInvalidServings {
+		t.Errorf("MakeCocktail() error = %v, want %v", err, ErrInvalidServings)
+	}
+}
@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+var (
+	ErrNilCocktail      = errors.New("cocktail cannot be nil")
+	ErrCocktailNotFound = errors.New("cocktail not found")
+)
+
+func (r *Repository) CreateCocktail(ctx context.Context, cocktail *models.Cocktail) (*models.Cocktail, error) {
+	if cocktail == nil {
+		return nil, ErrNilCocktail
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO cocktails (name, description, source_model, source_prompt, created_at, updated_at)
+		VALUES (?, ?, ?, ?, datetime('now'), datetime('now'))
+		RETURNING id, created_at, updated_at`
+
+	err = tx.QueryRowContext(ctx, query, cocktail.Name, cocktail.Description, cocktail.SourceModel, cocktail.SourcePrompt).Scan(&cocktail.ID, &cocktail.CreatedAt, &cocktail.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cocktail: %v", err)
+	}
+
+	if err := insertCocktailChildren(ctx, tx, cocktail); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit cocktail: %v", err)
+	}
+
+	return cocktail, nil
+}
+
+func (r *Repository) GetCocktailByID(ctx context.Context, id int) (*models.Cocktail, error) {
+	query := `
+		SELECT id, name, description, source_model, source_prompt, created_at, updated_at
+		FROM cocktails
+		WHERE id = ?`
+
+	var cocktail models.Cocktail
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(&cocktail.ID, &cocktail.Name, &cocktail.Description, &cocktail.SourceModel, &cocktail.SourcePrompt, &cocktail.CreatedAt, &cocktail.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCocktailNotFound
+		}
+		return nil, fmt.Errorf("failed to get cocktail by ID: %v", err)
+	}
+
+	byID := map[int]*models.Cocktail{cocktail.ID: &cocktail}
+	if err := r.loadCocktailChildren(ctx, byID, "WHERE cocktail_id = ?", id); err != nil {
+		return nil, err
+	}
+
+	return &cocktail, nil
+}
+
+func (r *Repository) GetAllCocktails(ctx context.Context) ([]*models.Cocktail, error) {
+	query := `
+		SELECT id, name, description, source_model, source_prompt, created_at, updated_at
+		FROM cocktails
+		ORDER BY name COLLATE NOCASE, id`
+
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cocktails: %v", err)
+	}
+	defer rows.Close()
+
+	var cocktails []*models.Cocktail
+	byID := make(map[int]*models.Cocktail)
+	for rows.Next() {
+		var cocktail models.Cocktail
+		err := rows.Scan(&cocktail.ID, &cocktail.Name, &cocktail.Description, &cocktail.SourceModel, &cocktail.SourcePrompt, &cocktail.CreatedAt, &cocktail.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cocktail: %v", err)
+		}
+
+		cocktails = append(cocktails, &cocktail)
+		byID[cocktail.ID] = &cocktail
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over cocktails: %v", err)
+	}
+
+	if len(cocktails) == 0 {
+		return cocktails, nil
+	}
+
+	if err := r.loadCocktailChildren(ctx, byID, ""); err != nil {
+		return nil, err
+	}
+
+	return cocktails, nil
+}
+
+// UpdateCocktail replaces a cocktail's fields, ingredients and steps. The
+// recommendation source of a saved recipe is left untouched.
+func (r *Repository) UpdateCocktail(ctx context.Context, id int, updates *models.Cocktail) (*models.Cocktail, error) {
+	if updates == nil {
+		return nil, ErrNilCocktail
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE cocktails
+		SET name = ?, description = ?, updated_at = datetime('now')
+		WHERE id = ?
+		RETURNING id, name, description, source_model, source_prompt, created_at, updated_at`
+
+	cocktail := &models.Cocktail{
+		Ingredients: updates.Ingredients,
+		Steps:       updates.Steps,
+	}
+	err = tx.QueryRowContext(ctx, query, updates.Name, updates.Description, id).Scan(
+		&cocktail.ID,
+		&cocktail.Name,
+		&cocktail.Description,
+		&cocktail.SourceModel,
+		&cocktail.SourcePrompt,
+		&cocktail.CreatedAt,
+		&cocktail.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCocktailNotFound
+		}
+		return nil, fmt.Errorf("failed to update cocktail: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM cocktail_ingredients WHERE cocktail_id = ?`, id); err != nil {
+		return nil, fmt.Errorf("failed to clear cocktail ingredients: %v", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM cocktail_steps WHERE cocktail_id = ?`, id); err != nil {
+		return nil, fmt.Errorf("failed to clear cocktail steps: %v", err)
+	}
+
+	if err := insertCocktailChildren(ctx, tx, cocktail); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit cocktail: %v", err)
+	}
+
+	return cocktail, nil
+}
+
+func (r *Repository) DeleteCocktailByID(ctx context.Context, id int) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// SQLite only enforces ON DELETE CASCADE with foreign_keys enabled, so
+	// remove child rows explicitly.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM cocktail_ingredients WHERE cocktail_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete cocktail ingredients: %v", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM cocktail_steps WHERE cocktail_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete cocktail steps: %v", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM cocktails WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete cocktail: %v", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return ErrCocktailNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit cocktail deletion: %v", err)
+	}
+
+	return nil
+}
+
+// insertCocktailChildren writes the ingredients and steps of a cocktail that
+// already has an ID. Steps without an explicit order are numbered by position.
+func insertCocktailChildren(ctx context.Context, tx *sql.Tx, cocktail *models.Cocktail) error {
+	for i := range cocktail.Ingredients {
+		ingredient := &cocktail.Ingredients[i]
+		query := `
+			INSERT INTO cocktail_ingredients (cocktail_id, position, name, quantity)
+			VALUES (?, ?, ?, ?)
+			RETURNING id`
+		if err := tx.QueryRowContext(ctx, query, cocktail.ID, i, ingredient.Name, ingredient.Quantity).Scan(&ingredient.ID); err != nil {
+			return fmt.Errorf("failed to create cocktail ingredient: %v", err)
+		}
+	}
+
+	for i := range cocktail.Steps {
+		step := &cocktail.Steps[i]
+		if step.Order == 0 {
+			step.Order = i + 1
+		}
+		query := `
+			INSERT INTO cocktail_steps (cocktail_id, step_order, text)
+			VALUES (?, ?, ?)
+			RETURNING id`
+		if err := tx.QueryRowContext(ctx, query, cocktail.ID, step.Order, step.Text).Scan(&step.ID); err != nil {
+			return fmt.Errorf("failed to create cocktail step: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// loadCocktailChildren fills in ingredients and steps for the given cocktails.
+// where is an optional filter on cocktail_id shared by both child queries.
+func (r *Repository) loadCocktailChildren(ctx context.Context, byID map[int]*models.Cocktail, where string, args ...any) error {
+	for _, cocktail := range byID {
+		cocktail.Ingredients = []models.Ingredient{}
+		cocktail.Steps = []models.Step{}
+	}
+
+	ingredientRows, err := r.DB.QueryContext(ctx, `
+		SELECT id, cocktail_id, name, quantity
+		FROM cocktail_ingredients `+where+`
+		ORDER BY cocktail_id, position, id`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to get cocktail ingredients: %v", err)
+	}
+	defer ingredientRows.Close()
+
+	for ingredientRows.Next() {
+		var cocktailID int
+		var ingredient models.Ingredient
+		if err := ingredientRows.Scan(&ingredient.ID, &cocktailID, &ingredient.Name, &ingredient.Quantity); err != nil {
+			return fmt.Errorf("failed to scan cocktail ingredient: %v", err)
+		}
+		if cocktail, ok := byID[cocktailID]; ok {
+			cocktail.Ingredients = append(cocktail.Ingredients, ingredient)
+		}
+	}
+	if err := ingredientRows.Err(); err != nil {
+		return fmt.Errorf("error iterating over cocktail ingredients: %v", err)
+	}
+
+	stepRows, err := r.DB.QueryContext(ctx, `
+		SELECT id, cocktail_id, step_order, text
+		FROM cocktail_steps `+where+`
+		ORDER BY cocktail_id, step_order, id`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to get cocktail steps: %v", err)
+	}
+	defer stepRows.Close()
+
+	for stepRows.Next() {
+		var cocktailID int
+		var step models.Step
+		if err := stepRows.Scan(&step.ID, &cocktailID, &step.Order, &step.Text); err != nil {
+			return fmt.Errorf("failed to scan cocktail step: %v", err)
+		}
+		if cocktail, ok := byID[cocktailID]; ok {
+			cocktail.Steps = append(cocktail.Steps, step)
+		}
+	}
+	if err := stepRows.Err(); err != nil {
+		return fmt.Errorf("error iterating over cocktail steps: %v", err)
+	}
+
+	return nil
+}
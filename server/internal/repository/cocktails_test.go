@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+func newTestCocktail() *models.Cocktail {
+	return &models.Cocktail{
+		Name:        "Negroni",
+		Description: "Equal parts, stirred",
+		Ingredients: []models.Ingredient{
+			{Name: "Gin", Quantity: "1 oz"},
+			{Name: "Campari", Quantity: "1 oz"},
+			{Name: "Sweet Vermouth", Quantity: "1 oz"},
+		},
+		Steps: []models.Step{
+			{Text: "Stir with ice"},
+			{Text: "Strain over a large cube"},
+		},
+	}
+}
+
+func TestCreateCocktail_Success(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	result, err := repo.CreateCocktail(ctx, newTestCocktail())
+	if err != nil {
+		t.Fatalf("CreateCocktail() error = %v, want nil", err)
+	}
+
+	if result.ID == 0 {
+		t.Error("CreateCocktail() did not set cocktail ID")
+	}
+
+	if result.CreatedAt.IsZero() {
+		t.Error("CreateCocktail() did not set CreatedAt")
+	}
+
+	for _, ingredient := range result.Ingredients {
+		if ingredient.ID == 0 {
+			t.Errorf("CreateCocktail() did not set ID for ingredient %q", ingredient.Name)
+		}
+	}
+
+	for i, step := range result.Steps {
+		if step.Order != i+1 {
+			t.Errorf("CreateCocktail() step %d order = %d, want %d", i, step.Order, i+1)
+		}
+	}
+}
+
+func TestCreateCocktail_NilCocktail(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	result, err := repo.CreateCocktail(context.Background(), nil)
+	if err != ErrNilCocktail {
+		t.Errorf("CreateCocktail() error = %v, want %v", err, ErrNilCocktail)
+	}
+
+	if result != nil {
+		t.Errorf("CreateCocktail() result = %v, want nil", result)
+	}
+}
+
+func TestGetCocktailByID(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	created, err := repo.CreateCocktail(ctx, newTestCocktail())
+	if err != nil {
+		t.Fatalf("CreateCocktail() error = %v, want nil", err)
+	}
+
+	result, err := repo.GetCocktailByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetCocktailByID() error = %v, want nil", err)
+	}
+
+	if result.Name != "Negroni" {
+		t.Errorf("GetCocktailByID() name = %v, want %v", result.Name, "Negroni")
+	}
+
+	if len(result.Ingredients) != 3 {
+		t.Fatalf("GetCocktailByID() ingredients = %d, want 3", len(result.Ingredients))
+	}
+
+	if result.Ingredients[1].Name != "Campari" {
+		t.Errorf("GetCocktailByID() second ingredient = %v, want Campari", result.Ingredients[1].Name)
+	}
+
+	if len(result.Steps) != 2 || result.Steps[0].Text != "Stir with ice" {
+		t.Errorf("GetCocktailByID() steps = %+v, want steps in saved order", result.Steps)
+	}
+
+	_, err = repo.GetCocktailByID(ctx, 99999)
+	if err != ErrCocktailNotFound {
+		t.Errorf("GetCocktailByID() error = %v, want %v", err, ErrCocktailNotFound)
+	}
+}
+
+func TestGetAllCocktails(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	if _, err := repo.CreateCocktail(ctx, newTestCocktail()); err != nil {
+		t.Fatalf("CreateCocktail() error = %v, want nil", err)
+	}
+	daiquiri := &models.Cocktail{
+		Name: "Daiquiri",
+		Ingredients: []models.Ingredient{
+			{Name: "White Rum", Quantity: "2 oz"},
+			{Name: "Lime Juice", Quantity: "1 oz"},
+		},
+	}
+	if _, err := repo.CreateCocktail(ctx, daiquiri); err != nil {
+		t.Fatalf("CreateCocktail() error = %v, want nil", err)
+	}
+
+	cocktails, err := repo.GetAllCocktails(ctx)
+	if err != nil {
+		t.Fatalf("GetAllCocktails() error = %v, want nil", err)
+	}
+
+	if len(cocktails) != 2 {
+		t.Fatalf("GetAllCocktails() returned %d cocktails, want 2", len(cocktails))
+	}
+
+	if cocktails[0].Name != "Daiquiri" || len(cocktails[0].Ingredients) != 2 {
+		t.Errorf("GetAllCocktails() first cocktail = %+v, want Daiquiri with 2 ingredients", cocktails[0])
+	}
+
+	if len(cocktails[0].Steps) != 0 {
+		t.Errorf("GetAllCocktails() Daiquiri steps = %d, want 0", len(cocktails[0].Steps))
+	}
+
+	if len(cocktails[1].Ingredients) != 3 || len(cocktails[1].Steps) != 2 {
+		t.Errorf("GetAllCocktails() Negroni = %+v, want 3 ingredients and 2 steps", cocktails[1])
+	}
+}
+
+func TestUpdateCocktail_ReplacesChildren(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	created, err := repo.CreateCocktail(ctx, newTestCocktail())
+	if err != nil {
+		t.Fatalf("CreateCocktail() error = %v, want nil", err)
+	}
+
+	updates := &models.Cocktail{
+		Name: "Boulevardier",
+		Ingredients: []models.Ingredient{
+			{Name: "Bourbon", Quantity: "1 1/4 oz"},
+			{Name: "Campari", Quantity: "1 oz"},
+		},
+		Steps: []models.Step{{Order: 1, Text: "Stir"}},
+	}
+	if _, err := repo.UpdateCocktail(ctx, created.ID, updates); err != nil {
+		t.Fatalf("UpdateCocktail() error = %v, want nil", err)
+	}
+
+	result, err := repo.GetCocktailByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetCocktailByID() error = %v, want nil", err)
+	}
+
+	if result.Name != "Boulevardier" {
+		t.Errorf("UpdateCocktail() name = %v, want Boulevardier", result.Name)
+	}
+
+	if len(result.Ingredients) != 2 || result.Ingredients[0].Name != "Bourbon" {
+		t.Errorf("UpdateCocktail() ingredients = %+v, want replaced ingredients", result.Ingredients)
+	}
+
+	if len(result.Steps) != 1 {
+		t.Errorf("UpdateCocktail() steps = %d, want 1", len(result.Steps))
+	}
+
+	_, err = repo.UpdateCocktail(ctx, 99999, updates)
+	if err != ErrCocktailNotFound {
+		t.Errorf("UpdateCocktail() error = %v, want %v", err, ErrCocktailNotFound)
+	}
+}
+
+func TestDeleteCocktailByID(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	created, err := repo.CreateCocktail(ctx, newTestCocktail())
+	if err != nil {
+		t.Fatalf("CreateCocktail() error = %v, want nil", err)
+	}
+
+	if err := repo.DeleteCocktailByID(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteCocktailByID() error = %v, want nil", err)
+	}
+
+	var count int
+	err = repo.DB.QueryRow("SELECT COUNT(*) FROM cocktail_ingredients WHERE cocktail_id = ?", created.ID).Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to query database: %v", err)
+	}
+
+	if count != 0 {
+		t.Errorf("Expected ingredients to be deleted, found %d", count)
+	}
+
+	if err := repo.DeleteCocktailByID(ctx, created.ID); err != ErrCocktailNotFound {
+		t.Errorf("DeleteCocktailByID() error = %v, want %v", err, ErrCocktailNotFound)
+	}
+}
+
+func TestCreateCocktail_WithSource(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	model := "gpt-4o-mini"
+	prompt := "Recommend a cocktail"
+	cocktail := newTestCocktail()
+	cocktail.SourceModel = &model
+	cocktail.SourcePrompt = &prompt
+
+	created, err := repo.CreateCocktail(ctx, cocktail)
+	if err != nil {
+		t.Fatalf("CreateCocktail() error = %v, want nil", err)
+	}
+
+	result, err := repo.GetCocktailByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetCocktailByID() error = %v, want nil", err)
+	}
+
+	if result.SourceModel == nil || *result.SourceModel != model {
+		t.Errorf("GetCocktailByID() source model = %v, want %v", result.SourceModel, model)
+	}
+
+	if result.SourcePrompt == nil || *result.SourcePrompt != prompt {
+		t.Errorf("GetCocktailByID() source prompt = %v, want %v", result.SourcePrompt, prompt)
+	}
+
+	updated, err := repo.UpdateCocktail(ctx, created.ID, &models.Cocktail{Name: "Negroni Sbagliato"})
+	if err != nil {
+		t.Fatalf("UpdateCocktail() error = %v, want nil", err)
+	}
+
+	if updated.SourceModel == nil || *updated.SourceModel != model {
+		t.Errorf("UpdateCocktail() source model = %v, want it preserved", updated.SourceModel)
+	}
+}
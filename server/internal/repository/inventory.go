@@ -0,0 +1,485 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+// column is a column of an inventory table besides id, created_at and
+// updated_at. insert and update are the SQL written to it; they may use the
+// new value of any column as :name, and default to :name, replacing the old
+// value. Read-only columns are set elsewhere and never written here.
+type column struct {
+	name     string
+	insert   string
+	update   string
+	readOnly bool
+}
+
+func (c column) insertExpr() string {
+	if c.insert != "" {
+		return c.insert
+	}
+	return ":" + c.name
+}
+
+func (c column) updateExpr() string {
+	if c.update != "" {
+		return c.update
+	}
+	return ":" + c.name
+}
+
+// optional is a column that an update leaves alone when its value is nil, and
+// clears when its value is empty, such as an empty string or zero.
+func optional(name, empty string) column {
+	return column{
+		name:   name,
+		insert: "NULLIF(:" + name + ", " + empty + ")",
+		update: "NULLIF(COALESCE(:" + name + ", " + name + "), " + empty + ")",
+	}
+}
+
+// volumeColumns track an item's volume. The remaining volume starts full,
+// size and remaining are only changed when provided so clients that don't
+// track volume can't wipe them, and the item is finished once nothing
+// remains.
+var volumeColumns = []column{
+	{name: "size_ml", update: "COALESCE(:size_ml, size_ml)"},
+	{name: "remaining_ml", insert: "COALESCE(:remaining_ml, :size_ml)", update: "COALESCE(:remaining_ml, remaining_ml, :size_ml)"},
+	{name: "finished", insert: "COALESCE(COALESCE(:remaining_ml, :size_ml) <= 0, FALSE)", update: "COALESCE(COALESCE(:remaining_ml, remaining_ml, :size_ml) <= 0, FALSE)"},
+	{name: "finished_at", insert: "CASE WHEN COALESCE(:remaining_ml, :size_ml) <= 0 THEN datetime('now') END", update: "CASE WHEN COALESCE(:remaining_ml, remaining_ml, :size_ml) <= 0 THEN COALESCE(finished_at, datetime('now')) END"},
+}
+
+// stockColumns track an item's catalog link and volume.
+var stockColumns = columns([]column{{name: "ingredient_id", readOnly: true}}, volumeColumns)
+
+func columns(groups ...[]column) []column {
+	var all []column
+	for _, group := range groups {
+		all = append(all, group...)
+	}
+	return all
+}
+
+// inventoryTable declares a kind of inventory item: the table it lives in,
+// its columns and how they map onto T. Adding a kind takes a migration, a
+// model and one of these.
+type inventoryTable[T any] struct {
+	stockTable
+	list listTable
+	// poured reports whether the kind has volumeColumns to pour from.
+	poured bool
+	// noun and plural name the item in error messages, e.g. "fresh item".
+	noun    string
+	plural  string
+	errNil  error
+	columns []column
+	// fields returns pointers to T's id, each column in order, created_at
+	// and updated_at, to scan rows into and bind values from.
+	fields func(*T) []any
+}
+
+func (t *inventoryTable[T]) selectColumns() string {
+	names := []string{"id"}
+	for _, c := range t.columns {
+		names = append(names, c.name)
+	}
+	return strings.Join(append(names, "created_at", "updated_at"), ", ")
+}
+
+// args binds the value of every column of item by name.
+func (t *inventoryTable[T]) args(item *T) []any {
+	fields := t.fields(item)[1:]
+	args := make([]any, 0, len(t.columns))
+	for i, c := range t.columns {
+		args = append(args, sql.Named(c.name, fields[i]))
+	}
+	return args
+}
+
+// Inventory stores one kind of inventory item.
+type Inventory[T any] struct {
+	repo  *Repository
+	table *inventoryTable[T]
+}
+
+func (r *Repository) Bottles() *Inventory[models.Bottle] {
+	return &Inventory[models.Bottle]{repo: r, table: &bottleTable}
+}
+
+func (r *Repository) Mixers() *Inventory[models.Mixer] {
+	return &Inventory[models.Mixer]{repo: r, table: &mixerTable}
+}
+
+func (r *Repository) Fresh() *Inventory[models.Fresh] {
+	return &Inventory[models.Fresh]{repo: r, table: &freshTable}
+}
+
+func (r *Repository) Bitters() *Inventory[models.Bitters] {
+	return &Inventory[models.Bitters]{repo: r, table: &bittersTable}
+}
+
+func (r *Repository) Syrups() *Inventory[models.Syrup] {
+	return &Inventory[models.Syrup]{repo: r, table: &syrupTable}
+}
+
+func (r *Repository) Garnishes() *Inventory[models.Garnish] {
+	return &Inventory[models.Garnish]{repo: r, table: &garnishTable}
+}
+
+// Kind is the item type the inventory records pours and links under, such as
+// "bottle".
+func (inv *Inventory[T]) Kind() string {
+	return inv.table.itemType
+}
+
+func (inv *Inventory[T]) Create(ctx context.Context, item *T) (*T, error) {
+	return inv.create(ctx, inv.repo.DB, item)
+}
+
+// CreateAll creates every item in one transaction, so either all of them are
+// saved or none are. The error of a failed item names its position in items,
+// counting from 1.
+func (inv *Inventory[T]) CreateAll(ctx context.Context, items []*T) ([]*T, error) {
+	tx, err := inv.repo.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	created := make([]*T, 0, len(items))
+	for i, item := range items {
+		c, err := inv.create(ctx, tx, item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i+1, err)
+		}
+		created = append(created, c)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit %s: %v", inv.table.plural, err)
+	}
+
+	return created, nil
+}
+
+func (inv *Inventory[T]) create(ctx context.Context, q queryRower, item *T) (*T, error) {
+	if item == nil {
+		return nil, inv.table.errNil
+	}
+
+	var names, values []string
+	for _, c := range inv.table.columns {
+		if !c.readOnly {
+			names = append(names, c.name)
+			values = append(values, c.insertExpr())
+		}
+	}
+
+	query := `
+		INSERT INTO ` + inv.table.table + ` (` + strings.Join(names, ", ") + `, created_at, updated_at)
+		VALUES (` + strings.Join(values, ", ") + `, datetime('now'), datetime('now'))
+		RETURNING ` + inv.table.selectColumns()
+
+	var created T
+	if err := q.QueryRowContext(ctx, query, inv.table.args(item)...).Scan(inv.table.fields(&created)...); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %v", inv.table.noun, err)
+	}
+
+	return &created, nil
+}
+
+func (inv *Inventory[T]) Get(ctx context.Context, id int) (*T, error) {
+	query := `
+		SELECT ` + inv.table.selectColumns() + `
+		FROM ` + inv.table.table + `
+		WHERE id = ?`
+
+	var item T
+	if err := inv.repo.DB.QueryRowContext(ctx, query, id).Scan(inv.table.fields(&item)...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, inv.table.notFound
+		}
+		return nil, fmt.Errorf("failed to get %s by ID: %v", inv.table.noun, err)
+	}
+
+	return &item, nil
+}
+
+// Update replaces an item's fields, apart from those its columns keep when
+// left out, such as the volume.
+func (inv *Inventory[T]) Update(ctx context.Context, id int, updates *T) (*T, error) {
+	if updates == nil {
+		return nil, inv.table.errNil
+	}
+
+	var assignments []string
+	for _, c := range inv.table.columns {
+		if !c.readOnly {
+			assignments = append(assignments, c.name+" = "+c.updateExpr())
+		}
+	}
+
+	query := `
+		UPDATE ` + inv.table.table + `
+		SET ` + strings.Join(assignments, ",\n\t\t\t") + `,
+			updated_at = datetime('now')
+		WHERE id = :id
+		RETURNING ` + inv.table.selectColumns()
+
+	args := append(inv.table.args(updates), sql.Named("id", id))
+
+	var item T
+	if err := inv.repo.DB.QueryRowContext(ctx, query, args...).Scan(inv.table.fields(&item)...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, inv.table.notFound
+		}
+		return nil, fmt.Errorf("failed to update %s: %v", inv.table.noun, err)
+	}
+
+	return &item, nil
+}
+
+func (inv *Inventory[T]) Delete(ctx context.Context, id int) error {
+	result, err := inv.repo.DB.ExecContext(ctx, `DELETE FROM `+inv.table.table+` WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %v", inv.table.noun, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return inv.table.notFound
+	}
+
+	return nil
+}
+
+// Filter selects and orders the items Find returns. ListOptions is the
+// filter every inventory supports; some kinds add their own, such as
+// BottleFilter.
+type Filter interface {
+	listOptions() ListOptions
+	conditions() ([]string, []any)
+}
+
+func (o ListOptions) listOptions() ListOptions {
+	return o
+}
+
+func (o ListOptions) conditions() ([]string, []any) {
+	return nil, nil
+}
+
+// All returns every item, newest first.
+func (inv *Inventory[T]) All(ctx context.Context) ([]*T, error) {
+	return inv.all(ctx, inv.repo.DB)
+}
+
+func (inv *Inventory[T]) all(ctx context.Context, q querier) ([]*T, error) {
+	items, _, err := inv.find(ctx, q, ListOptions{})
+	return items, err
+}
+
+// Find returns one page of the items matching filter, and the cursor of the
+// next page or "" if there are no more.
+func (inv *Inventory[T]) Find(ctx context.Context, filter Filter) ([]*T, string, error) {
+	return inv.find(ctx, inv.repo.DB, filter)
+}
+
+func (inv *Inventory[T]) find(ctx context.Context, q querier, filter Filter) ([]*T, string, error) {
+	conditions, args := filter.conditions()
+	list, err := filter.listOptions().build(inv.table.list, conditions, args)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := `
+		SELECT ` + inv.table.selectColumns() + `, ` + list.keyColumn + `
+		FROM ` + inv.table.table + `
+		` + list.tail
+
+	rows, err := q.QueryContext(ctx, query, list.args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get %s: %v", inv.table.plural, err)
+	}
+	defer rows.Close()
+
+	var items []*T
+	var keys []cursor
+	for rows.Next() {
+		var item T
+		var key cursor
+		if err := rows.Scan(append(inv.table.fields(&item), &key.Value, &key.ID)...); err != nil {
+			return nil, "", fmt.Errorf("failed to scan %s: %v", inv.table.noun, err)
+		}
+		items = append(items, &item)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating over %s: %v", inv.table.plural, err)
+	}
+
+	items, next := page(items, keys, list)
+	return items, next, nil
+}
+
+// Pour takes amountML from an item's remaining volume and records the pour.
+// Items that can be opened are marked opened, and every item is finished
+// once it reaches zero. Kinds without volume columns, such as garnishes,
+// return ErrVolumeNotTracked.
+func (inv *Inventory[T]) Pour(ctx context.Context, id int, amountML float64) (*T, error) {
+	if !inv.table.poured {
+		return nil, ErrVolumeNotTracked
+	}
+	if err := inv.repo.pour(ctx, inv.table.stockTable, id, amountML); err != nil {
+		return nil, err
+	}
+	return inv.Get(ctx, id)
+}
+
+var bottleTable = inventoryTable[models.Bottle]{
+	stockTable: bottleStock,
+	poured:     true,
+	list:       bottleList,
+	noun:       "bottle",
+	plural:     "bottles",
+	errNil:     ErrNilBottle,
+	columns: columns(
+		[]column{{name: "name"}, {name: "opened"}, {name: "open_date"}, {name: "purchase_date"}, {name: "price"}},
+		stockColumns,
+		[]column{
+			optional("category", "''"),
+			optional("subcategory", "''"),
+			optional("brand", "''"),
+			optional("abv", "0"),
+			optional("country", "''"),
+			optional("region", "''"),
+			optional("age_statement", "''"),
+		},
+	),
+	fields: func(b *models.Bottle) []any {
+		return []any{
+			&b.ID, &b.Name, &b.Opened, &b.OpenDate, &b.PurchaseDate, &b.Price,
+			&b.IngredientID, &b.SizeML, &b.RemainingML, &b.Finished, &b.FinishedAt,
+			&b.Category, &b.Subcategory, &b.Brand, &b.ABV, &b.Country, &b.Region, &b.AgeStatement,
+			&b.CreatedAt, &b.UpdatedAt,
+		}
+	},
+}
+
+var mixerTable = inventoryTable[models.Mixer]{
+	stockTable: mixerStock,
+	poured:     true,
+	list:       mixerList,
+	noun:       "mixer",
+	plural:     "mixers",
+	errNil:     ErrNilMixer,
+	columns: columns(
+		[]column{{name: "name"}, {name: "opened"}, {name: "open_date"}, {name: "purchase_date"}, {name: "price"}},
+		stockColumns,
+		[]column{{name: "shelf_life_days"}},
+	),
+	fields: func(m *models.Mixer) []any {
+		return []any{
+			&m.ID, &m.Name, &m.Opened, &m.OpenDate, &m.PurchaseDate, &m.Price,
+			&m.IngredientID, &m.SizeML, &m.RemainingML, &m.Finished, &m.FinishedAt,
+			&m.ShelfLifeDays,
+			&m.CreatedAt, &m.UpdatedAt,
+		}
+	},
+}
+
+var freshTable = inventoryTable[models.Fresh]{
+	stockTable: freshStock,
+	poured:     true,
+	list:       freshList,
+	noun:       "fresh item",
+	plural:     "fresh items",
+	errNil:     ErrNilFresh,
+	columns: columns(
+		[]column{{name: "name"}, {name: "prepared_date"}, {name: "purchase_date"}, {name: "price"}},
+		stockColumns,
+		[]column{{name: "shelf_life_days"}},
+	),
+	fields: func(f *models.Fresh) []any {
+		return []any{
+			&f.ID, &f.Name, &f.PreparedDate, &f.PurchaseDate, &f.Price,
+			&f.IngredientID, &f.SizeML, &f.RemainingML, &f.Finished, &f.FinishedAt,
+			&f.ShelfLifeDays,
+			&f.CreatedAt, &f.UpdatedAt,
+		}
+	},
+}
+
+var bittersTable = inventoryTable[models.Bitters]{
+	stockTable: bittersStock,
+	list:       bittersList,
+	noun:       "bitters",
+	plural:     "bitters",
+	errNil:     ErrNilBitters,
+	columns: []column{
+		{name: "name"},
+		optional("brand", "''"),
+		{name: "opened"},
+		{name: "open_date"},
+		{name: "purchase_date"},
+		{name: "price"},
+		{name: "dashes_remaining", update: "COALESCE(:dashes_remaining, dashes_remaining)"},
+	},
+	fields: func(b *models.Bitters) []any {
+		return []any{
+			&b.ID, &b.Name, &b.Brand, &b.Opened, &b.OpenDate, &b.PurchaseDate, &b.Price,
+			&b.DashesRemaining,
+			&b.CreatedAt, &b.UpdatedAt,
+		}
+	},
+}
+
+var syrupTable = inventoryTable[models.Syrup]{
+	stockTable: syrupStock,
+	list:       syrupList,
+	poured:     true,
+	noun:       "syrup",
+	plural:     "syrups",
+	errNil:     ErrNilSyrup,
+	columns: columns(
+		[]column{{name: "name"}, optional("ratio", "''"), {name: "made_date"}, {name: "purchase_date"}, {name: "price"}},
+		volumeColumns,
+	),
+	fields: func(s *models.Syrup) []any {
+		return []any{
+			&s.ID, &s.Name, &s.Ratio, &s.MadeDate, &s.PurchaseDate, &s.Price,
+			&s.SizeML, &s.RemainingML, &s.Finished, &s.FinishedAt,
+			&s.CreatedAt, &s.UpdatedAt,
+		}
+	},
+}
+
+var garnishTable = inventoryTable[models.Garnish]{
+	stockTable: garnishStock,
+	list:       garnishList,
+	noun:       "garnish",
+	plural:     "garnishes",
+	errNil:     ErrNilGarnish,
+	columns: []column{
+		{name: "name"},
+		{name: "quantity", update: "COALESCE(:quantity, quantity)"},
+		{name: "purchase_date"},
+		{name: "price"},
+	},
+	fields: func(g *models.Garnish) []any {
+		return []any{
+			&g.ID, &g.Name, &g.Quantity, &g.PurchaseDate, &g.Price,
+			&g.CreatedAt, &g.UpdatedAt,
+		}
+	},
+}
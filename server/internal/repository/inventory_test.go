@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+func integer(v int) *int {
+	return &v
+}
+
+func checkFields[T any](t *testing.T, table *inventoryTable[T]) {
+	t.Helper()
+
+	var item T
+	if got, want := len(table.fields(&item)), len(table.columns)+3; got != want {
+		t.Errorf("%s table has %d fields for %d columns, want %d", table.noun, got, len(table.columns), want)
+	}
+}
+
+func TestInventoryTables_FieldsMatchColumns(t *testing.T) {
+	checkFields(t, &bottleTable)
+	checkFields(t, &mixerTable)
+	checkFields(t, &freshTable)
+	checkFields(t, &bittersTable)
+	checkFields(t, &syrupTable)
+	checkFields(t, &garnishTable)
+}
+
+func TestInventory_CreateFillsLevel(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	full, err := repo.Fresh().Create(ctx, &models.Fresh{Name: "Lime Juice", SizeML: float(250)})
+	if err != nil {
+		t.Fatalf("Create() error = %v, want nil", err)
+	}
+
+	if full.RemainingML == nil || *full.RemainingML != 250 || full.Finished {
+		t.Errorf("Create() remaining/finished = %v/%v, want 250/false", full.RemainingML, full.Finished)
+	}
+
+	empty, err := repo.Fresh().Create(ctx, &models.Fresh{Name: "Lemon Juice", SizeML: float(250), RemainingML: float(0)})
+	if err != nil {
+		t.Fatalf("Create() error = %v, want nil", err)
+	}
+
+	if !empty.Finished || empty.FinishedAt == nil {
+		t.Errorf("Create() finished/finished_at = %v/%v, want an empty item finished", empty.Finished, empty.FinishedAt)
+	}
+}
+
+func TestInventory_Bitters(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	created, err := repo.Bitters().Create(ctx, &models.Bitters{Name: "Angostura", Brand: str("House of Angostura"), DashesRemaining: integer(400)})
+	if err != nil {
+		t.Fatalf("Create() error = %v, want nil", err)
+	}
+
+	updated, err := repo.Bitters().Update(ctx, int(created.ID), &models.Bitters{Name: "Angostura Aromatic", Opened: true})
+	if err != nil {
+		t.Fatalf("Update() error = %v, want nil", err)
+	}
+	if updated.Brand == nil || *updated.Brand != "House of Angostura" {
+		t.Errorf("Update() brand = %v, want it kept when left out", updated.Brand)
+	}
+	if !updated.Opened || updated.DashesRemaining == nil || *updated.DashesRemaining != 400 {
+		t.Errorf("Update() opened/dashes = %v/%v, want true and the dashes kept", updated.Opened, updated.DashesRemaining)
+	}
+
+	if _, err := repo.Bitters().Pour(ctx, int(created.ID), 5); err != ErrVolumeNotTracked {
+		t.Errorf("Pour() error = %v, want %v", err, ErrVolumeNotTracked)
+	}
+
+	if err := repo.Bitters().Delete(ctx, int(created.ID)); err != nil {
+		t.Fatalf("Delete() error = %v, want nil", err)
+	}
+	if _, err := repo.Bitters().Get(ctx, int(created.ID)); err != ErrBittersNotFound {
+		t.Errorf("Get() error = %v, want %v", err, ErrBittersNotFound)
+	}
+}
+
+func TestInventory_SyrupPour(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	syrup, err := repo.Syrups().Create(ctx, &models.Syrup{Name: "Rich Simple Syrup", Ratio: str("2:1"), SizeML: float(500)})
+	if err != nil {
+		t.Fatalf("Create() error = %v, want nil", err)
+	}
+
+	poured, err := repo.Syrups().Pour(ctx, int(syrup.ID), 15)
+	if err != nil {
+		t.Fatalf("Pour() error = %v, want nil", err)
+	}
+	if poured.RemainingML == nil || *poured.RemainingML != 485 {
+		t.Errorf("Pour() remaining = %v, want 485", poured.RemainingML)
+	}
+
+	pours, err := repo.GetPours(ctx, repo.Syrups().Kind(), int(syrup.ID))
+	if err != nil {
+		t.Fatalf("GetPours() error = %v, want nil", err)
+	}
+	if len(pours) != 1 || pours[0].ItemType != "syrup" {
+		t.Errorf("GetPours() = %+v, want one syrup pour", pours)
+	}
+}
+
+func TestInventory_GarnishList(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	for _, name := range []string{"Luxardo Cherries", "Orange Peel"} {
+		if _, err := repo.Garnishes().Create(ctx, &models.Garnish{Name: name, Quantity: integer(12)}); err != nil {
+			t.Fatalf("Create() error = %v, want nil", err)
+		}
+	}
+
+	garnishes, _, err := repo.Garnishes().Find(ctx, ListOptions{Sort: "name"})
+	if err != nil {
+		t.Fatalf("Find() error = %v, want nil", err)
+	}
+	if len(garnishes) != 2 || garnishes[0].Name != "Luxardo Cherries" {
+		t.Errorf("Find() = %+v, want both garnishes by name", garnishes)
+	}
+
+	if _, _, err := repo.Garnishes().Find(ctx, ListOptions{Sort: "open_date"}); err != ErrInvalidSort {
+		t.Errorf("Find(open_date) error = %v, want %v", err, ErrInvalidSort)
+	}
+	opened := true
+	if _, _, err := repo.Garnishes().Find(ctx, ListOptions{Opened: &opened}); err != ErrOpenedNotSupported {
+		t.Errorf("Find(opened) error = %v, want %v", err, ErrOpenedNotSupported)
+	}
+}
+
+func TestInventory_CreateAll(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	created, err := repo.Mixers().CreateAll(ctx, []*models.Mixer{{Name: "Tonic Water"}, {Name: "Soda Water", SizeML: float(1000)}})
+	if err != nil {
+		t.Fatalf("CreateAll() error = %v, want nil", err)
+	}
+	if len(created) != 2 || created[0].ID == 0 || created[1].RemainingML == nil || *created[1].RemainingML != 1000 {
+		t.Errorf("CreateAll() = %+v, want both mixers created", created)
+	}
+
+	_, err = repo.Mixers().CreateAll(ctx, []*models.Mixer{{Name: "Cola"}, nil})
+	if !errors.Is(err, ErrNilMixer) || !strings.Contains(err.Error(), "item 2") {
+		t.Errorf("CreateAll() error = %v, want %v on item 2", err, ErrNilMixer)
+	}
+
+	mixers, err := repo.GetAllMixers(ctx)
+	if err != nil {
+		t.Fatalf("GetAllMixers() error = %v, want nil", err)
+	}
+	if len(mixers) != 2 {
+		t.Errorf("GetAllMixers() returned %d mixers, want 2 with the failed import rolled back", len(mixers))
+	}
+}
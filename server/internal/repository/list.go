@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSort        = errors.New("sort must be name, price or open_date, optionally prefixed with -")
+	ErrInvalidCursor      = errors.New("invalid cursor")
+	ErrInvalidLimit       = errors.New("limit cannot be negative")
+	ErrOpenedNotSupported = errors.New("opened filter is not supported for this list")
+)
+
+// ListOptions filters, sorts and pages the bottle, mixer and fresh lists.
+// The zero value lists everything, newest first.
+type ListOptions struct {
+	Opened         *bool
+	NameContains   string
+	PurchasedAfter *time.Time
+	MinPrice       *float64
+	MaxPrice       *float64
+	// Sort is name, price or open_date, prefixed with - for descending
+	// order. Items without a price or open date sort last either way.
+	Sort string
+	// Limit caps the number of items returned; 0 means no limit.
+	Limit int
+	// Cursor continues a previous list with the same Sort after the last
+	// item of its page, so items added or removed meanwhile don't shift it.
+	Cursor string
+}
+
+// listTable names the columns a list's options apply to. Fresh items and
+// syrups have no opened flag and sort by the date they were made in place of
+// an open date; garnishes have neither.
+type listTable struct {
+	openedColumn   string
+	openDateColumn string
+}
+
+var (
+	bottleList  = listTable{openedColumn: "opened", openDateColumn: "open_date"}
+	mixerList   = listTable{openedColumn: "opened", openDateColumn: "open_date"}
+	freshList   = listTable{openDateColumn: "prepared_date"}
+	bittersList = listTable{openedColumn: "opened", openDateColumn: "open_date"}
+	syrupList   = listTable{openDateColumn: "made_date"}
+	garnishList = listTable{}
+)
+
+// listQuery is the tail of a list query, from WHERE to LIMIT, and the
+// columns to select after an item's for the cursor of the next page.
+type listQuery struct {
+	tail      string
+	args      []any
+	limit     int
+	sort      string
+	keyColumn string
+}
+
+// sortKey is a column a list is ordered and paged by, before id.
+type sortKey struct {
+	// column is the expression ordered by, with any collation.
+	column string
+	// value selects the column for a cursor. Dates are selected as the text
+	// they are stored as, so they compare the same way when sent back.
+	value    string
+	nullable bool
+	desc     bool
+}
+
+// build turns the options into a listQuery on t. conditions and args hold any
+// filters the caller adds on top, such as BottleFilter's.
+func (o ListOptions) build(t listTable, conditions []string, args []any) (listQuery, error) {
+	if o.Opened != nil {
+		if t.openedColumn == "" {
+			return listQuery{}, ErrOpenedNotSupported
+		}
+		conditions = append(conditions, t.openedColumn+" = ?")
+		args = append(args, *o.Opened)
+	}
+	if o.NameContains != "" {
+		conditions = append(conditions, `name LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(o.NameContains)+"%")
+	}
+	if o.PurchasedAfter != nil {
+		conditions = append(conditions, "purchase_date > ?")
+		args = append(args, *o.PurchasedAfter)
+	}
+	if o.MinPrice != nil {
+		conditions = append(conditions, "price >= ?")
+		args = append(args, *o.MinPrice)
+	}
+	if o.MaxPrice != nil {
+		conditions = append(conditions, "price <= ?")
+		args = append(args, *o.MaxPrice)
+	}
+
+	key, err := o.sortKey(t)
+	if err != nil {
+		return listQuery{}, err
+	}
+	if o.Cursor != "" {
+		c, err := decodeCursor(o.Cursor)
+		if err != nil || c.Sort != o.Sort {
+			return listQuery{}, ErrInvalidCursor
+		}
+		condition, after := key.after(c)
+		conditions = append(conditions, condition)
+		args = append(args, after...)
+	}
+	if o.Limit < 0 {
+		return listQuery{}, ErrInvalidLimit
+	}
+
+	var tail strings.Builder
+	if len(conditions) > 0 {
+		tail.WriteString("WHERE " + strings.Join(conditions, " AND ") + "\n")
+	}
+	tail.WriteString("ORDER BY " + key.orderBy() + "\n")
+
+	// Fetch one extra row to tell whether there is another page.
+	limit := -1
+	if o.Limit > 0 {
+		limit = o.Limit + 1
+	}
+	tail.WriteString("LIMIT ?")
+	args = append(args, limit)
+
+	return listQuery{tail: tail.String(), args: args, limit: o.Limit, sort: o.Sort, keyColumn: key.value + ", id"}, nil
+}
+
+func (o ListOptions) sortKey(t listTable) (sortKey, error) {
+	column, desc := strings.CutPrefix(o.Sort, "-")
+
+	switch column {
+	case "":
+		if desc {
+			return sortKey{}, ErrInvalidSort
+		}
+		return sortKey{column: "created_at", value: "CAST(created_at AS TEXT)", desc: true}, nil
+	case "name":
+		return sortKey{column: "name COLLATE NOCASE", value: "name", desc: desc}, nil
+	case "price":
+		return sortKey{column: "price", value: "price", nullable: true, desc: desc}, nil
+	case "open_date":
+		if t.openDateColumn == "" {
+			return sortKey{}, ErrInvalidSort
+		}
+		return sortKey{column: t.openDateColumn, value: "CAST(" + t.openDateColumn + " AS TEXT)", nullable: true, desc: desc}, nil
+	default:
+		return sortKey{}, ErrInvalidSort
+	}
+}
+
+func (k sortKey) orderBy() string {
+	direction := "ASC"
+	if k.desc {
+		direction = "DESC"
+	}
+	order := k.column + " " + direction + ", id " + direction
+	if k.nullable {
+		order = k.column + " IS NULL, " + order
+	}
+	return order
+}
+
+// after returns the condition that keeps the rows ordered after the cursor's
+// row, and its arguments. Rows without a value come last either way.
+func (k sortKey) after(c cursor) (string, []any) {
+	op := ">"
+	if k.desc {
+		op = "<"
+	}
+	switch {
+	case !k.nullable:
+		return "(" + k.column + ", id) " + op + " (?, ?)", []any{c.Value, c.ID}
+	case c.Value == nil:
+		return "(" + k.column + " IS NULL AND id " + op + " ?)", []any{c.ID}
+	default:
+		return "(" + k.column + " IS NULL OR (" + k.column + ", id) " + op + " (?, ?))", []any{c.Value, c.ID}
+	}
+}
+
+// page trims the extra row build asked for and returns the cursor of the
+// next page, or "" on the last one. keys are the rows' keyColumn values.
+func page[T any](items []T, keys []cursor, q listQuery) ([]T, string) {
+	if q.limit == 0 || len(items) <= q.limit {
+		return items, ""
+	}
+	last := keys[q.limit-1]
+	last.Sort = q.sort
+	return items[:q.limit], encodeCursor(last)
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// cursor is the sort value and id of the last row of a page; the next page
+// starts after it. Cursors are opaque to clients.
+type cursor struct {
+	Sort  string `json:"s"`
+	Value any    `json:"v"`
+	ID    int64  `json:"id"`
+}
+
+func encodeCursor(c cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID <= 0 {
+		return cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+func mixerNames(mixers []*models.Mixer) []string {
+	names := make([]string, 0, len(mixers))
+	for _, mixer := range mixers {
+		names = append(names, mixer.Name)
+	}
+	return names
+}
+
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFindMixers_Options(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	jan := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	jun := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	for _, mixer := range []*models.Mixer{
+		{Name: "Tonic Water", Opened: true, OpenDate: &jun, PurchaseDate: &jan, Price: float(4)},
+		{Name: "soda water", PurchaseDate: &jun, Price: float(2)},
+		{Name: "Ginger Beer", Opened: true, OpenDate: &jan, PurchaseDate: &jun},
+		{Name: "100% Cranberry", Price: float(6)},
+	} {
+		if _, err := repo.CreateMixer(ctx, mixer); err != nil {
+			t.Fatalf("CreateMixer() error = %v, want nil", err)
+		}
+	}
+
+	opened := true
+	after := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		opts ListOptions
+		want []string
+	}{
+		{"opened", ListOptions{Opened: &opened, Sort: "name"}, []string{"Ginger Beer", "Tonic Water"}},
+		{"name contains", ListOptions{NameContains: "WATER", Sort: "name"}, []string{"soda water", "Tonic Water"}},
+		{"name contains is literal", ListOptions{NameContains: "0%"}, []string{"100% Cranberry"}},
+		{"purchased after", ListOptions{PurchasedAfter: &after, Sort: "name"}, []string{"Ginger Beer", "soda water"}},
+		{"price range", ListOptions{MinPrice: float(3), MaxPrice: float(5)}, []string{"Tonic Water"}},
+		{"sort name ignores case", ListOptions{Sort: "name"}, []string{"100% Cranberry", "Ginger Beer", "soda water", "Tonic Water"}},
+		{"sort price puts unpriced last", ListOptions{Sort: "price"}, []string{"soda water", "Tonic Water", "100% Cranberry", "Ginger Beer"}},
+		{"sort price descending", ListOptions{Sort: "-price"}, []string{"100% Cranberry", "Tonic Water", "soda water", "Ginger Beer"}},
+		{"sort open date", ListOptions{Sort: "open_date", Limit: 2}, []string{"Ginger Beer", "Tonic Water"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mixers, _, err := repo.FindMixers(ctx, tt.opts)
+			if err != nil {
+				t.Fatalf("FindMixers() error = %v, want nil", err)
+			}
+
+			if got := mixerNames(mixers); !equalNames(got, tt.want) {
+				t.Errorf("FindMixers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindMixers_Pages(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	for _, name := range []string{"A", "B", "C", "D", "E"} {
+		if _, err := repo.CreateMixer(ctx, &models.Mixer{Name: name}); err != nil {
+			t.Fatalf("CreateMixer() error = %v, want nil", err)
+		}
+	}
+
+	var got []string
+	opts := ListOptions{Sort: "name", Limit: 2}
+	for pages := 1; ; pages++ {
+		mixers, next, err := repo.FindMixers(ctx, opts)
+		if err != nil {
+			t.Fatalf("FindMixers() error = %v, want nil", err)
+		}
+		got = append(got, mixerNames(mixers)...)
+
+		if next == "" {
+			if pages != 3 {
+				t.Errorf("FindMixers() returned %d pages, want 3", pages)
+			}
+			break
+		}
+		opts.Cursor = next
+	}
+
+	if want := []string{"A", "B", "C", "D", "E"}; !equalNames(got, want) {
+		t.Errorf("FindMixers() pages = %v, want %v", got, want)
+	}
+}
+
+// findAllMixers pages through every mixer matching opts, calling between
+// after each page.
+func findAllMixers(t *testing.T, repo *Repository, opts ListOptions, between func()) []string {
+	t.Helper()
+
+	var got []string
+	for {
+		mixers, next, err := repo.FindMixers(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("FindMixers() error = %v, want nil", err)
+		}
+		got = append(got, mixerNames(mixers)...)
+		if next == "" {
+			return got
+		}
+		between()
+		opts.Cursor = next
+	}
+}
+
+func TestFindMixers_PagesAfterInsert(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   ListOptions
+		insert *models.Mixer
+		want   []string
+	}{
+		{"name", ListOptions{Sort: "name", Limit: 2}, &models.Mixer{Name: "AA"}, []string{"A", "B", "C", "D", "E"}},
+		{"newest", ListOptions{Limit: 2}, &models.Mixer{Name: "F"}, []string{"E", "D", "C", "B", "A"}},
+		{"price", ListOptions{Sort: "price", Limit: 2}, &models.Mixer{Name: "G", Price: float(0.5)}, []string{"C", "A", "D", "B", "E"}},
+		{"price descending", ListOptions{Sort: "-price", Limit: 2}, &models.Mixer{Name: "H", Price: float(9)}, []string{"D", "A", "C", "E", "B"}},
+		{"unpriced", ListOptions{Sort: "price", Limit: 4}, &models.Mixer{Name: "I"}, []string{"C", "A", "D", "B", "E", "I"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := setupTestRepository(t)
+			defer repo.CloseDB()
+
+			ctx := context.Background()
+			for _, mixer := range []*models.Mixer{
+				{Name: "A", Price: float(3)},
+				{Name: "B"},
+				{Name: "C", Price: float(1)},
+				{Name: "D", Price: float(3)},
+				{Name: "E"},
+			} {
+				if _, err := repo.CreateMixer(ctx, mixer); err != nil {
+					t.Fatalf("CreateMixer() error = %v, want nil", err)
+				}
+			}
+
+			// A row added before the cursor neither repeats nor shifts the
+			// rows of the pages that follow.
+			inserted := false
+			got := findAllMixers(t, repo, tt.opts, func() {
+				if inserted {
+					return
+				}
+				inserted = true
+				if _, err := repo.CreateMixer(ctx, tt.insert); err != nil {
+					t.Fatalf("CreateMixer() error = %v, want nil", err)
+				}
+			})
+			if !equalNames(got, tt.want) {
+				t.Errorf("FindMixers() pages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindMixers_InvalidOptions(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		opts ListOptions
+		want error
+	}{
+		{"unknown sort", ListOptions{Sort: "created_at"}, ErrInvalidSort},
+		{"bare minus", ListOptions{Sort: "-"}, ErrInvalidSort},
+		{"bad cursor", ListOptions{Cursor: "not a cursor"}, ErrInvalidCursor},
+		{"cursor without an ID", ListOptions{Cursor: encodeCursor(cursor{Value: "A"})}, ErrInvalidCursor},
+		{"cursor from another sort", ListOptions{Sort: "price", Cursor: encodeCursor(cursor{Sort: "name", Value: "A", ID: 1})}, ErrInvalidCursor},
+		{"negative limit", ListOptions{Limit: -1}, ErrInvalidLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := repo.FindMixers(ctx, tt.opts); err != tt.want {
+				t.Errorf("FindMixers() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindFresh_Options(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	opened := true
+	if _, _, err := repo.FindFresh(ctx, ListOptions{Opened: &opened}); err != ErrOpenedNotSupported {
+		t.Errorf("FindFresh() error = %v, want %v", err, ErrOpenedNotSupported)
+	}
+
+	early := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	for _, fresh := range []*models.Fresh{
+		{Name: "Lime Juice", PreparedDate: &late},
+		{Name: "Mint"},
+		{Name: "Lemon Juice", PreparedDate: &early},
+	} {
+		if _, err := repo.CreateFresh(ctx, fresh); err != nil {
+			t.Fatalf("CreateFresh() error = %v, want nil", err)
+		}
+	}
+
+	freshItems, _, err := repo.FindFresh(ctx, ListOptions{Sort: "-open_date"})
+	if err != nil {
+		t.Fatalf("FindFresh() error = %v, want nil", err)
+	}
+
+	var got []string
+	for _, fresh := range freshItems {
+		got = append(got, fresh.Name)
+	}
+	if want := []string{"Lime Juice", "Lemon Juice", "Mint"}; !equalNames(got, want) {
+		t.Errorf("FindFresh() = %v, want %v", got, want)
+	}
+
+	got = nil
+	opts := ListOptions{Sort: "-open_date", Limit: 1}
+	for {
+		freshItems, next, err := repo.FindFresh(ctx, opts)
+		if err != nil {
+			t.Fatalf("FindFresh() error = %v, want nil", err)
+		}
+		for _, fresh := range freshItems {
+			got = append(got, fresh.Name)
+		}
+		if next == "" {
+			break
+		}
+		opts.Cursor = next
+	}
+	if want := []string{"Lime Juice", "Lemon Juice", "Mint"}; !equalNames(got, want) {
+		t.Errorf("FindFresh() pages = %v, want %v", got, want)
+	}
+}
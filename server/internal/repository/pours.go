@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/nguyenjessev/liquor-locker/internal/measure"
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+var (
+	ErrInvalidPourAmount = errors.New("pour amount must be greater than zero")
+	ErrVolumeNotTracked  = errors.New("item has no size or remaining volume")
+	ErrItemFinished      = errors.New("item is finished")
+)
+
+// stockTable names an inventory table and how pouring from its volume, if it
+// has any, is recorded.
+type stockTable struct {
+	table    string
+	itemType string
+	// opens reports whether the table has opened/open_date columns to set on
+	// the first pour.
+	opens bool
+	// unit is what the table's stock is counted in: millilitres of
+	// remaining_ml, or dashes or pieces of countColumn.
+	unit        measure.Unit
+	countColumn string
+	notFound    error
+}
+
+var (
+	bottleStock = stockTable{table: "bottles", itemType: "bottle", opens: true, unit: measure.Milliliter, notFound: ErrBottleNotFound}
+	mixerStock  = stockTable{table: "mixers", itemType: "mixer", opens: true, unit: measure.Milliliter, notFound: ErrMixerNotFound}
+	freshStock  = stockTable{table: "fresh", itemType: "fresh", unit: measure.Milliliter, notFound: ErrFreshNotFound}
+	syrupStock  = stockTable{table: "syrups", itemType: "syrup", unit: measure.Milliliter, notFound: ErrSyrupNotFound}
+	// Bitters and garnishes have no volume to pour; they are counted in
+	// dashes and pieces instead.
+	bittersStock = stockTable{table: "bitters", itemType: "bitters", opens: true, unit: measure.Dash, countColumn: "dashes_remaining", notFound: ErrBittersNotFound}
+	garnishStock = stockTable{table: "garnishes", itemType: "garnish", unit: measure.Count, countColumn: "quantity", notFound: ErrGarnishNotFound}
+)
+
+// PourBottle takes amountML from a bottle's remaining volume and records the
+// pour. The bottle is marked opened, and finished once it reaches zero.
+func (r *Repository) PourBottle(ctx context.Context, id int, amountML float64) (*models.Bottle, error) {
+	return r.Bottles().Pour(ctx, id, amountML)
+}
+
+// PourMixer takes amountML from a mixer's remaining volume and records the
+// pour. The mixer is marked opened, and finished once it reaches zero.
+func (r *Repository) PourMixer(ctx context.Context, id int, amountML float64) (*models.Mixer, error) {
+	return r.Mixers().Pour(ctx, id, amountML)
+}
+
+func (r *Repository) pour(ctx context.Context, stock stockTable, id int, amountML float64) error {
+	if amountML <= 0 {
+		return ErrInvalidPourAmount
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := pourTx(ctx, tx, stock, id, amountML, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit pour: %v", err)
+	}
+
+	return nil
+}
+
+// pourTx deducts amountML from a row of stock.table inside tx and writes the
+// audit row, linked to eventID when the pour is part of a made cocktail. A
+// pour larger than what is left takes, and records, only what is left; an
+// item that is finished or empty returns ErrItemFinished. It returns the
+// volume poured.
+func pourTx(ctx context.Context, tx *sql.Tx, stock stockTable, id int, amountML float64, eventID *int64) (float64, error) {
+	var size, remaining *float64
+	var finished bool
+	err := tx.QueryRowContext(ctx, `SELECT size_ml, remaining_ml, finished FROM `+stock.table+` WHERE id = ?`, id).Scan(&size, &remaining, &finished)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, stock.notFound
+		}
+		return 0, fmt.Errorf("failed to get %s volume: %v", stock.itemType, err)
+	}
+
+	if remaining == nil {
+		remaining = size
+	}
+	if remaining == nil {
+		return 0, ErrVolumeNotTracked
+	}
+
+	if finished || *remaining <= 0 {
+		return 0, ErrItemFinished
+	}
+
+	poured := min(amountML, *remaining)
+	left := *remaining - poured
+
+	opened := ""
+	if stock.opens {
+		opened = `
+			opened = TRUE,
+			open_date = COALESCE(open_date, datetime('now')),`
+	}
+	query := `
+		UPDATE ` + stock.table + `
+		SET remaining_ml = ?,` + opened + `
+			finished = ?,
+			finished_at = CASE WHEN ? THEN COALESCE(finished_at, datetime('now')) END,
+			updated_at = datetime('now')
+		WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, query, left, left <= 0, left <= 0, id); err != nil {
+		return 0, fmt.Errorf("failed to update %s volume: %v", stock.itemType, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO pours (item_type, item_id, amount_ml, remaining_ml, event_id, poured_at)
+		VALUES (?, ?, ?, ?, ?, datetime('now'))`, stock.itemType, id, poured, left, eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record pour: %v", err)
+	}
+
+	return poured, nil
+}
+
+// takeTx takes amount, rounded to a whole number of at least one, from the
+// dashes or pieces left in a row of stock.table inside tx, and returns how
+// many were taken. Like pourTx it takes only what is left, and returns
+// ErrItemFinished when nothing is. Counts aren't volumes, so no pour is
+// recorded.
+func takeTx(ctx context.Context, tx *sql.Tx, stock stockTable, id int, amount float64) (float64, error) {
+	var left *int
+	err := tx.QueryRowContext(ctx, `SELECT `+stock.countColumn+` FROM `+stock.table+` WHERE id = ?`, id).Scan(&left)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, stock.notFound
+		}
+		return 0, fmt.Errorf("failed to get %s count: %v", stock.itemType, err)
+	}
+	if left == nil {
+		return 0, ErrVolumeNotTracked
+	}
+	if *left <= 0 {
+		return 0, ErrItemFinished
+	}
+
+	taken := min(max(int(math.Round(amount)), 1), *left)
+	opened := ""
+	if stock.opens {
+		opened = `
+			opened = TRUE,
+			open_date = COALESCE(open_date, datetime('now')),`
+	}
+	query := `
+		UPDATE ` + stock.table + `
+		SET ` + stock.countColumn + ` = ?,` + opened + `
+			updated_at = datetime('now')
+		WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, query, *left-taken, id); err != nil {
+		return 0, fmt.Errorf("failed to update %s count: %v", stock.itemType, err)
+	}
+
+	return float64(taken), nil
+}
+
+// GetPours returns the pour history of a bottle, mixer or fresh item, newest
+// first.
+func (r *Repository) GetPours(ctx context.Context, itemType string, itemID int) ([]*models.Pour, error) {
+	return r.queryPours(ctx, `WHERE item_type = ? AND item_id = ? ORDER BY poured_at DESC, id DESC`, itemType, itemID)
+}
+
+// queryPours selects pours matching clause, which holds the WHERE and ORDER BY
+// parts of the query.
+func (r *Repository) queryPours(ctx context.Context, clause string, args ...any) ([]*models.Pour, error) {
+	query := `
+		SELECT id, item_type, item_id, amount_ml, remaining_ml, event_id, poured_at
+		FROM pours
+		` + clause
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pours: %v", err)
+	}
+	defer rows.Close()
+
+	pours := []*models.Pour{}
+	for rows.Next() {
+		var pour models.Pour
+		err := rows.Scan(&pour.ID, &pour.ItemType, &pour.ItemID, &pour.AmountML, &pour.RemainingML, &pour.EventID, &pour.PouredAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pour: %v", err)
+		}
+		pours = append(pours, &pour)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over pours: %v", err)
+	}
+
+	return pours, nil
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestCreateBottle_DefaultsRemainingToSize(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	bottle, err := repo.CreateBottle(context.Background(), &models.Bottle{Name: "Rye", SizeML: float(750)})
+	if err != nil {
+		t.Fatalf("CreateBottle() error = %v, want nil", err)
+	}
+
+	if bottle.RemainingML == nil || *bottle.RemainingML != 750 {
+		t.Errorf("CreateBottle() remaining = %v, want 750", bottle.RemainingML)
+	}
+
+	if bottle.Finished {
+		t.Error("CreateBottle() marked a full bottle as finished")
+	}
+}
+
+func TestPourBottle(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	bottle, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Gin", SizeML: float(100)})
+	if err != nil {
+		t.Fatalf("CreateBottle() error = %v, want nil", err)
+	}
+
+	poured, err := repo.PourBottle(ctx, int(bottle.ID), 60)
+	if err != nil {
+		t.Fatalf("PourBottle() error = %v, want nil", err)
+	}
+
+	if poured.RemainingML == nil || *poured.RemainingML != 40 {
+		t.Errorf("PourBottle() remaining = %v, want 40", poured.RemainingML)
+	}
+
+	if !poured.Opened || poured.OpenDate == nil {
+		t.Error("PourBottle() did not mark the bottle opened")
+	}
+
+	if poured.Finished {
+		t.Error("PourBottle() finished the bottle early")
+	}
+
+	finished, err := repo.PourBottle(ctx, int(bottle.ID), 60)
+	if err != nil {
+		t.Fatalf("PourBottle() error = %v, want nil", err)
+	}
+
+	if finished.RemainingML == nil || *finished.RemainingML != 0 {
+		t.Errorf("PourBottle() remaining = %v, want 0", finished.RemainingML)
+	}
+
+	if !finished.Finished || finished.FinishedAt == nil {
+		t.Error("PourBottle() did not mark the empty bottle finished")
+	}
+
+	pours, err := repo.GetPours(ctx, "bottle", int(bottle.ID))
+	if err != nil {
+		t.Fatalf("GetPours() error = %v, want nil", err)
+	}
+
+	if len(pours) != 2 {
+		t.Fatalf("GetPours() returned %d pours, want 2", len(pours))
+	}
+
+	if pours[0].RemainingML != 0 || pours[1].RemainingML != 40 {
+		t.Errorf("GetPours() = %+v, %+v, want newest first", pours[0], pours[1])
+	}
+
+	if pours[0].AmountML != 40 {
+		t.Errorf("GetPours() over-pour amount = %v, want the 40 that was left", pours[0].AmountML)
+	}
+
+	if _, err := repo.PourBottle(ctx, int(bottle.ID), 10); err != ErrItemFinished {
+		t.Errorf("PourBottle() from a finished bottle error = %v, want %v", err, ErrItemFinished)
+	}
+}
+
+func TestPourBottle_Errors(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	untracked, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Mystery"})
+	if err != nil {
+		t.Fatalf("CreateBottle() error = %v, want nil", err)
+	}
+
+	if _, err := repo.PourBottle(ctx, int(untracked.ID), 30); err != ErrVolumeNotTracked {
+		t.Errorf("PourBottle() error = %v, want %v", err, ErrVolumeNotTracked)
+	}
+
+	if _, err := repo.PourBottle(ctx, int(untracked.ID), 0); err != ErrInvalidPourAmount {
+		t.Errorf("PourBottle() error = %v, want %v", err, ErrInvalidPourAmount)
+	}
+
+	if _, err := repo.PourBottle(ctx, 99999, 30); err != ErrBottleNotFound {
+		t.Errorf("PourBottle() error = %v, want %v", err, ErrBottleNotFound)
+	}
+}
+
+func TestPourMixer(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	mixer, err := repo.CreateMixer(ctx, &models.Mixer{Name: "Tonic", SizeML: float(200)})
+	if err != nil {
+		t.Fatalf("CreateMixer() error = %v, want nil", err)
+	}
+
+	poured, err := repo.PourMixer(ctx, int(mixer.ID), 120)
+	if err != nil {
+		t.Fatalf("PourMixer() error = %v, want nil", err)
+	}
+
+	if poured.RemainingML == nil || *poured.RemainingML != 80 {
+		t.Errorf("PourMixer() remaining = %v, want 80", poured.RemainingML)
+	}
+}
+
+func TestUpdateBottle_KeepsVolumeWhenOmitted(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	bottle, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Rum", SizeML: float(700), RemainingML: float(350)})
+	if err != nil {
+		t.Fatalf("CreateBottle() error = %v, want nil", err)
+	}
+
+	updated, err := repo.UpdateBottle(ctx, int(bottle.ID), &models.Bottle{Name: "Dark Rum"})
+	if err != nil {
+		t.Fatalf("UpdateBottle() error = %v, want nil", err)
+	}
+
+	if updated.SizeML == nil || *updated.SizeML != 700 || updated.RemainingML == nil || *updated.RemainingML != 350 {
+		t.Errorf("UpdateBottle() size/remaining = %v/%v, want 700/350", updated.SizeML, updated.RemainingML)
+	}
+
+	emptied, err := repo.UpdateBottle(ctx, int(bottle.ID), &models.Bottle{Name: "Dark Rum", RemainingML: float(0)})
+	if err != nil {
+		t.Fatalf("UpdateBottle() error = %v, want nil", err)
+	}
+
+	if !emptied.Finished || emptied.FinishedAt == nil {
+		t.Error("UpdateBottle() did not mark an emptied bottle finished")
+	}
+}
@@ -5,39 +5,171 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/nguyenjessev/liquor-locker/internal/database"
 	"github.com/nguyenjessev/liquor-locker/internal/models"
 )
 
 type Repository struct {
 	DB *sql.DB
+	// migrationsPath is a directory of migrations to run in place of the
+	// embedded ones, if set.
+	migrationsPath string
 }
 
-func New() *Repository {
-	db, err := sql.Open("sqlite3", "./internal/database/data/app.db")
+// Config says where the database lives and how to open it.
+type Config struct {
+	// Path is the database file, optionally with driver options after a "?",
+	// or a file: URI.
+	Path string
+	// MigrationsPath is a directory of migrations to run in place of the ones
+	// built into the binary.
+	MigrationsPath string
+	// JournalMode is SQLite's journal_mode, such as WAL or DELETE. WAL lets
+	// readers carry on while a write is in progress.
+	JournalMode string
+	// BusyTimeout is how long a write waits for another to finish before
+	// failing with "database is locked".
+	BusyTimeout time.Duration
+}
+
+var DefaultConfig = Config{
+	Path:        "./internal/database/data/app.db",
+	JournalMode: "WAL",
+	BusyTimeout: 5 * time.Second,
+}
+
+// ConfigFromEnv reads DB_PATH, MIGRATIONS_PATH, DB_JOURNAL_MODE and
+// DB_BUSY_TIMEOUT over DefaultConfig. The timeout uses Go syntax, such as
+// "10s".
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig
+	if path := os.Getenv("DB_PATH"); path != "" {
+		cfg.Path = path
+	}
+	cfg.MigrationsPath = os.Getenv("MIGRATIONS_PATH")
+	if mode := os.Getenv("DB_JOURNAL_MODE"); mode != "" {
+		cfg.JournalMode = strings.ToUpper(mode)
+	}
+	if raw := os.Getenv("DB_BUSY_TIMEOUT"); raw != "" {
+		timeout, err := time.ParseDuration(raw)
+		if err != nil || timeout < 0 {
+			return cfg, fmt.Errorf("DB_BUSY_TIMEOUT must be a duration such as 5s, got %q", raw)
+		}
+		cfg.BusyTimeout = timeout
+	}
+	return cfg, nil
+}
+
+// New opens the database, creating its file and directory if needed.
+func New(cfg Config) (*Repository, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("database path is required")
+	}
+	source, file, err := dsn(cfg)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+	if file != "" {
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create database directory: %v", err)
+		}
+	}
+
+	db, err := sql.Open("sqlite3", source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open database %s: %v", cfg.Path, err)
 	}
 
-	return &Repository{DB: db}
+	return &Repository{DB: db, migrationsPath: cfg.MigrationsPath}, nil
+}
+
+// dsn builds the driver's data source name from cfg, adding the journal mode
+// and busy timeout to any options already in cfg.Path. Options in the path
+// win. It also returns the file the database lives in, or "" if it is in
+// memory.
+func dsn(cfg Config) (string, string, error) {
+	path, rawQuery, _ := strings.Cut(cfg.Path, "?")
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid database options in %q: %v", cfg.Path, err)
+	}
+	if cfg.JournalMode != "" && !params.Has("_journal_mode") && !params.Has("_journal") {
+		params.Set("_journal_mode", cfg.JournalMode)
+	}
+	if !params.Has("_busy_timeout") && !params.Has("_timeout") {
+		params.Set("_busy_timeout", strconv.FormatInt(cfg.BusyTimeout.Milliseconds(), 10))
+	}
+
+	file := path
+	if strings.HasPrefix(path, "file:") {
+		u, err := url.Parse(path)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid database URI %q: %v", cfg.Path, err)
+		}
+		file = u.Opaque
+		if file == "" {
+			file = u.Path
+		}
+	}
+	if file == ":memory:" || params.Get("mode") == "memory" {
+		file = ""
+	}
+
+	return path + "?" + params.Encode(), file, nil
 }
 
 func (r *Repository) CloseDB() {
 	r.DB.Close()
 }
 
+// ErrNoFTS5 is returned by RunMigrations when the SQLite driver was built
+// without FTS5, which the search index needs.
+var ErrNoFTS5 = errors.New("SQLite was built without FTS5; build the server with -tags sqlite_fts5")
+
+// RunMigrations brings the schema up to date, from MigrationsPath if it was
+// set and otherwise from the migrations built into the binary.
 func (r *Repository) RunMigrations() error {
+	var fts5 bool
+	if err := r.DB.QueryRow(`SELECT sqlite_compileoption_used('ENABLE_FTS5')`).Scan(&fts5); err != nil {
+		return fmt.Errorf("failed to check SQLite compile options: %v", err)
+	}
+	if !fts5 {
+		return ErrNoFTS5
+	}
+
 	driver, err := sqlite3.WithInstance(r.DB, &sqlite3.Config{})
 	if err != nil {
 		return fmt.Errorf("failed to create migrate driver: %v", err)
 	}
 
-	m, err := migrate.NewWithDatabaseInstance("file://./internal/database/migrations", "sqlite3", driver)
+	var m *migrate.Migrate
+	if r.migrationsPath != "" {
+		m, err = migrate.NewWithDatabaseInstance("file://"+r.migrationsPath, "sqlite3", driver)
+	} else {
+		var src source.Driver
+		if src, err = iofs.New(database.Migrations, "migrations"); err != nil {
+			return fmt.Errorf("failed to read embedded migrations: %v", err)
+		}
+		m, err = migrate.NewWithInstance("iofs", src, "sqlite3", driver)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create migrate instance: %v", err)
 	}
@@ -50,345 +182,155 @@ func (r *Repository) RunMigrations() error {
 }
 
 var (
-	ErrNilBottle     = errors.New("bottle cannot be nil")
-	ErrNilMixer      = errors.New("mixer cannot be nil")
-	ErrNilFresh      = errors.New("fresh item cannot be nil")
-	ErrFreshNotFound = errors.New("fresh item not found")
+	ErrNilBottle       = errors.New("bottle cannot be nil")
+	ErrNilMixer        = errors.New("mixer cannot be nil")
+	ErrNilFresh        = errors.New("fresh item cannot be nil")
+	ErrBottleNotFound  = errors.New("bottle not found")
+	ErrMixerNotFound   = errors.New("mixer not found")
+	ErrFreshNotFound   = errors.New("fresh item not found")
+	ErrNilBitters      = errors.New("bitters cannot be nil")
+	ErrNilSyrup        = errors.New("syrup cannot be nil")
+	ErrNilGarnish      = errors.New("garnish cannot be nil")
+	ErrBittersNotFound = errors.New("bitters not found")
+	ErrSyrupNotFound   = errors.New("syrup not found")
+	ErrGarnishNotFound = errors.New("garnish not found")
 )
 
 func (r *Repository) CreateBottle(ctx context.Context, bottle *models.Bottle) (*models.Bottle, error) {
-	if bottle == nil {
-		return nil, ErrNilBottle
-	}
-	query := `
-		INSERT INTO bottles (name, opened, open_date, purchase_date, price, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, datetime('now'), datetime('now'))
-		RETURNING id, created_at, updated_at`
-
-	err := r.DB.QueryRowContext(ctx, query, bottle.Name, bottle.Opened, bottle.OpenDate, bottle.PurchaseDate, bottle.Price).Scan(&bottle.ID, &bottle.CreatedAt, &bottle.UpdatedAt)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create bottle: %v", err)
-	}
-
-	return bottle, nil
-}
-
-func (r *Repository) CreateMixer(ctx context.Context, mixer *models.Mixer) (*models.Mixer, error) {
-	if mixer == nil {
-		return nil, ErrNilMixer
-	}
-	query := `
-		INSERT INTO mixers (name, opened, open_date, purchase_date, price, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, datetime('now'), datetime('now'))
-		RETURNING id, created_at, updated_at`
-	err := r.DB.QueryRowContext(ctx, query, mixer.Name, mixer.Opened, mixer.OpenDate, mixer.PurchaseDate, mixer.Price).Scan(&mixer.ID, &mixer.CreatedAt, &mixer.UpdatedAt)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create mixer: %v", err)
-	}
-	return mixer, nil
+	return r.Bottles().Create(ctx, bottle)
 }
 
-var ErrMixerNotFound = errors.New("mixer not found")
-
-func (r *Repository) GetMixerByID(ctx context.Context, id int) (*models.Mixer, error) {
-	query := `
-		SELECT id, name, opened, open_date, purchase_date, price, created_at, updated_at
-		FROM mixers
-		WHERE id = ?`
-	var mixer models.Mixer
-	err := r.DB.QueryRowContext(ctx, query, id).Scan(&mixer.ID, &mixer.Name, &mixer.Opened, &mixer.OpenDate, &mixer.PurchaseDate, &mixer.Price, &mixer.CreatedAt, &mixer.UpdatedAt)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrMixerNotFound
-		}
-		return nil, fmt.Errorf("failed to get mixer by ID: %v", err)
-	}
-	return &mixer, nil
-}
-
-var ErrBottleNotFound = errors.New("bottle not found")
-
 func (r *Repository) GetBottleByID(ctx context.Context, id int) (*models.Bottle, error) {
-	query := `
-		SELECT id, name, opened, open_date, purchase_date, price, created_at, updated_at
-		FROM bottles
-		WHERE id = ?`
-
-	var bottle models.Bottle
-	err := r.DB.QueryRowContext(ctx, query, id).Scan(&bottle.ID, &bottle.Name, &bottle.Opened, &bottle.OpenDate, &bottle.PurchaseDate, &bottle.Price, &bottle.CreatedAt, &bottle.UpdatedAt)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrBottleNotFound
-		}
-
-		return nil, fmt.Errorf("failed to get bottle by ID: %v", err)
-	}
+	return r.Bottles().Get(ctx, id)
+}
 
-	return &bottle, nil
+// UpdateBottle replaces a bottle's fields. Size, remaining volume and the
+// descriptive fields are only changed when provided, so clients that don't
+// know about them can't wipe them; an empty value clears one.
+func (r *Repository) UpdateBottle(ctx context.Context, id int, updates *models.Bottle) (*models.Bottle, error) {
+	return r.Bottles().Update(ctx, id, updates)
 }
 
 func (r *Repository) DeleteBottleByID(ctx context.Context, id int) error {
-	query := `DELETE FROM bottles WHERE id = ?`
-
-	result, err := r.DB.ExecContext(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete bottle: %v", err)
-	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %v", err)
-	}
-	if rowsAffected == 0 {
-		return ErrBottleNotFound
-	}
-	return nil
+	return r.Bottles().Delete(ctx, id)
 }
 
-func (r *Repository) DeleteMixerByID(ctx context.Context, id int) error {
-	query := `DELETE FROM mixers WHERE id = ?`
-	result, err := r.DB.ExecContext(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete mixer: %v", err)
-	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %v", err)
-	}
-	if rowsAffected == 0 {
-		return ErrMixerNotFound
-	}
-	return nil
+func (r *Repository) GetAllBottles(ctx context.Context) ([]*models.Bottle, error) {
+	return r.Bottles().All(ctx)
 }
 
-func (r *Repository) GetAllBottles(ctx context.Context) ([]*models.Bottle, error) {
-	query := `
-		SELECT id, name, opened, open_date, purchase_date, price, created_at, updated_at
-		FROM bottles
-		ORDER BY created_at DESC`
-	rows, err := r.DB.QueryContext(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get bottles: %v", err)
-	}
-	defer rows.Close()
+// BottleFilter narrows FindBottles by a bottle's details on top of the
+// options shared by every list. Empty text fields and nil bounds match every
+// bottle; text fields match exactly, ignoring case.
+type BottleFilter struct {
+	ListOptions
+	Category    string
+	Subcategory string
+	Brand       string
+	Country     string
+	Region      string
+	MinABV      *float64
+	MaxABV      *float64
+}
 
-	var bottles []*models.Bottle
-	for rows.Next() {
-		var bottle models.Bottle
-		err := rows.Scan(&bottle.ID, &bottle.Name, &bottle.Opened, &bottle.OpenDate, &bottle.PurchaseDate, &bottle.Price, &bottle.CreatedAt, &bottle.UpdatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan bottle: %v", err)
+func (f BottleFilter) conditions() ([]string, []any) {
+	var conditions []string
+	var args []any
+	for _, field := range []struct {
+		column string
+		value  string
+	}{
+		{"category", f.Category},
+		{"subcategory", f.Subcategory},
+		{"brand", f.Brand},
+		{"country", f.Country},
+		{"region", f.Region},
+	} {
+		if field.value != "" {
+			conditions = append(conditions, field.column+" = ? COLLATE NOCASE")
+			args = append(args, field.value)
 		}
-
-		bottles = append(bottles, &bottle)
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over bottles: %v", err)
+	if f.MinABV != nil {
+		conditions = append(conditions, "abv >= ?")
+		args = append(args, *f.MinABV)
+	}
+	if f.MaxABV != nil {
+		conditions = append(conditions, "abv <= ?")
+		args = append(args, *f.MaxABV)
 	}
 
-	return bottles, nil
+	return conditions, args
 }
 
-func (r *Repository) GetAllMixers(ctx context.Context) ([]*models.Mixer, error) {
-	query := `
-		SELECT id, name, opened, open_date, purchase_date, price, created_at, updated_at
-		FROM mixers
-		ORDER BY created_at DESC`
-	rows, err := r.DB.QueryContext(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get mixers: %v", err)
-	}
-	defer rows.Close()
-
-	var mixers []*models.Mixer
-	for rows.Next() {
-		var mixer models.Mixer
-		err := rows.Scan(&mixer.ID, &mixer.Name, &mixer.Opened, &mixer.OpenDate, &mixer.PurchaseDate, &mixer.Price, &mixer.CreatedAt, &mixer.UpdatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan mixer: %v", err)
-		}
-		mixers = append(mixers, &mixer)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over mixers: %v", err)
-	}
-	return mixers, nil
+// FindBottles returns one page of the bottles matching filter, and the cursor
+// of the next page or "" if there are no more.
+func (r *Repository) FindBottles(ctx context.Context, filter BottleFilter) ([]*models.Bottle, string, error) {
+	return r.Bottles().Find(ctx, filter)
 }
 
-func (r *Repository) UpdateBottle(ctx context.Context, id int, updates *models.Bottle) (*models.Bottle, error) {
-	if updates == nil {
-		return nil, ErrNilBottle
-	}
-	query := `
-		UPDATE bottles
-		SET name = ?, opened = ?, open_date = ?, purchase_date = ?, price = ?, updated_at = datetime('now')
-		WHERE id = ?
-		RETURNING id, name, opened, open_date, purchase_date, price, created_at, updated_at`
-
-	var bottle models.Bottle
-	err := r.DB.QueryRowContext(ctx, query, updates.Name, updates.Opened, updates.OpenDate, updates.PurchaseDate, updates.Price, id).Scan(
-		&bottle.ID,
-		&bottle.Name,
-		&bottle.Opened,
-		&bottle.OpenDate,
-		&bottle.PurchaseDate,
-		&bottle.Price,
-		&bottle.CreatedAt,
-		&bottle.UpdatedAt,
-	)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrBottleNotFound
-		}
-		return nil, fmt.Errorf("failed to update bottle: %v", err)
-	}
+func (r *Repository) CreateMixer(ctx context.Context, mixer *models.Mixer) (*models.Mixer, error) {
+	return r.Mixers().Create(ctx, mixer)
+}
 
-	return &bottle, nil
+func (r *Repository) GetMixerByID(ctx context.Context, id int) (*models.Mixer, error) {
+	return r.Mixers().Get(ctx, id)
 }
 
+// UpdateMixer replaces a mixer's fields. Size and remaining volume are only
+// changed when provided, so clients that don't track volume can't wipe it.
 func (r *Repository) UpdateMixer(ctx context.Context, id int, updates *models.Mixer) (*models.Mixer, error) {
-	if updates == nil {
-		return nil, ErrNilMixer
-	}
-	query := `
-		UPDATE mixers
-		SET name = ?, opened = ?, open_date = ?, purchase_date = ?, price = ?, updated_at = datetime('now')
-		WHERE id = ?
-		RETURNING id, name, opened, open_date, purchase_date, price, created_at, updated_at`
-
-	var mixer models.Mixer
-	err := r.DB.QueryRowContext(ctx, query, updates.Name, updates.Opened, updates.OpenDate, updates.PurchaseDate, updates.Price, id).Scan(
-		&mixer.ID,
-		&mixer.Name,
-		&mixer.Opened,
-		&mixer.OpenDate,
-		&mixer.PurchaseDate,
-		&mixer.Price,
-		&mixer.CreatedAt,
-		&mixer.UpdatedAt,
-	)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrMixerNotFound
-		}
-		return nil, fmt.Errorf("failed to update mixer: %v", err)
-	}
-	return &mixer, nil
+	return r.Mixers().Update(ctx, id, updates)
 }
 
-func (r *Repository) CreateFresh(ctx context.Context, fresh *models.Fresh) (*models.Fresh, error) {
-	if fresh == nil {
-		return nil, ErrNilFresh
-	}
+func (r *Repository) DeleteMixerByID(ctx context.Context, id int) error {
+	return r.Mixers().Delete(ctx, id)
+}
 
-	query := `
-		INSERT INTO fresh (name, prepared_date, purchase_date, price, created_at, updated_at)
-		VALUES (?, ?, ?, ?, datetime('now'), datetime('now'))
-		RETURNING id, created_at, updated_at`
+func (r *Repository) GetAllMixers(ctx context.Context) ([]*models.Mixer, error) {
+	return r.Mixers().All(ctx)
+}
 
-	err := r.DB.QueryRowContext(ctx, query, fresh.Name, fresh.PreparedDate, fresh.PurchaseDate, fresh.Price).Scan(&fresh.ID, &fresh.CreatedAt, &fresh.UpdatedAt)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create fresh item: %v", err)
-	}
+// FindMixers returns one page of the mixers matching opts, and the cursor of
+// the next page or "" if there are no more.
+func (r *Repository) FindMixers(ctx context.Context, opts ListOptions) ([]*models.Mixer, string, error) {
+	return r.Mixers().Find(ctx, opts)
+}
 
-	return fresh, nil
+func (r *Repository) CreateFresh(ctx context.Context, fresh *models.Fresh) (*models.Fresh, error) {
+	return r.Fresh().Create(ctx, fresh)
 }
 
 func (r *Repository) GetFreshByID(ctx context.Context, id int) (*models.Fresh, error) {
-	query := `
-		SELECT id, name, prepared_date, purchase_date, price, created_at, updated_at
-		FROM fresh
-		WHERE id = ?`
-
-	var fresh models.Fresh
-	err := r.DB.QueryRowContext(ctx, query, id).Scan(&fresh.ID, &fresh.Name, &fresh.PreparedDate, &fresh.PurchaseDate, &fresh.Price, &fresh.CreatedAt, &fresh.UpdatedAt)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrFreshNotFound
-		}
-
-		return nil, fmt.Errorf("failed to get fresh item by ID: %v", err)
-	}
+	return r.Fresh().Get(ctx, id)
+}
 
-	return &fresh, nil
+// UpdateFresh replaces a fresh item's fields. Size and remaining volume are
+// only changed when provided.
+func (r *Repository) UpdateFresh(ctx context.Context, id int, updates *models.Fresh) (*models.Fresh, error) {
+	return r.Fresh().Update(ctx, id, updates)
 }
 
 func (r *Repository) DeleteFreshByID(ctx context.Context, id int) error {
-	query := `DELETE FROM fresh WHERE id = ?`
-
-	result, err := r.DB.ExecContext(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete fresh item: %v", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %v", err)
-	}
-
-	if rowsAffected == 0 {
-		return ErrFreshNotFound
-	}
-
-	return nil
+	return r.Fresh().Delete(ctx, id)
 }
 
 func (r *Repository) GetAllFresh(ctx context.Context) ([]*models.Fresh, error) {
-	query := `
-		SELECT id, name, prepared_date, purchase_date, price, created_at, updated_at
-		FROM fresh
-		ORDER BY created_at DESC`
-
-	rows, err := r.DB.QueryContext(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get fresh items: %v", err)
-	}
-	defer rows.Close()
-
-	var freshItems []*models.Fresh
-	for rows.Next() {
-		var fresh models.Fresh
-		err := rows.Scan(&fresh.ID, &fresh.Name, &fresh.PreparedDate, &fresh.PurchaseDate, &fresh.Price, &fresh.CreatedAt, &fresh.UpdatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan fresh item: %v", err)
-		}
-
-		freshItems = append(freshItems, &fresh)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over fresh items: %v", err)
-	}
+	return r.Fresh().All(ctx)
+}
 
-	return freshItems, nil
+// FindFresh returns one page of the fresh items matching opts, and the cursor
+// of the next page or "" if there are no more. Fresh items can't be filtered
+// by opened, and sorting by open_date uses their prepared date.
+func (r *Repository) FindFresh(ctx context.Context, opts ListOptions) ([]*models.Fresh, string, error) {
+	return r.Fresh().Find(ctx, opts)
 }
 
-func (r *Repository) UpdateFresh(ctx context.Context, id int, updates *models.Fresh) (*models.Fresh, error) {
-	if updates == nil {
-		return nil, ErrNilFresh
+// BackupTo writes a consistent copy of the database to path with VACUUM INTO,
+// without blocking other connections. path must not exist yet.
+func (r *Repository) BackupTo(ctx context.Context, path string) error {
+	if _, err := r.DB.ExecContext(ctx, `VACUUM INTO ?`, path); err != nil {
+		return fmt.Errorf("failed to back up database: %v", err)
 	}
-
-	query := `
-		UPDATE fresh
-		SET name = ?, prepared_date = ?, purchase_date = ?, price = ?, updated_at = datetime('now')
-		WHERE id = ?
-		RETURNING id, name, prepared_date, purchase_date, price, created_at, updated_at`
-
-	var fresh models.Fresh
-	err := r.DB.QueryRowContext(ctx, query, updates.Name, updates.PreparedDate, updates.PurchaseDate, updates.Price, id).Scan(
-		&fresh.ID,
-		&fresh.Name,
-		&fresh.PreparedDate,
-		&fresh.PurchaseDate,
-		&fresh.Price,
-		&fresh.CreatedAt,
-		&fresh.UpdatedAt,
-	)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrFreshNotFound
-		}
-		return nil, fmt.Errorf("failed to update fresh item: %v", err)
-	}
-
-	return &fresh, nil
+	return nil
 }
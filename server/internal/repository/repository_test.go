@@ -30,6 +30,11 @@ func setupTestRepository(t *testing.T) *Repository {
 func applyMigrations(t *testing.T, db *sql.DB) {
 	t.Helper()
 
+	var fts5 bool
+	if err := db.QueryRow(`SELECT sqlite_compileoption_used('ENABLE_FTS5')`).Scan(&fts5); err != nil || !fts5 {
+		t.Fatalf("Failed to check for FTS5: %v", ErrNoFTS5)
+	}
+
 	migrationsDir := filepath.Join("..", "database", "migrations")
 	entries, err := os.ReadDir(migrationsDir)
 	if err != nil {
@@ -368,3 +373,224 @@ func TestDeleteBottleByID_MultipleDeletes(t *testing.T) {
 		t.Errorf("Second delete error = %v, want %v", err, ErrBottleNotFound)
 	}
 }
+
+func str(v string) *string {
+	return &v
+}
+
+func TestUpdateBottle_Details(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	bottle, err := repo.CreateBottle(ctx, &models.Bottle{
+		Name:         "Lagavulin 16",
+		Category:     str("Whiskey"),
+		Subcategory:  str("Scotch"),
+		Brand:        str("Lagavulin"),
+		ABV:          float(43),
+		Country:      str("Scotland"),
+		Region:       str("Islay"),
+		AgeStatement: str("16 years"),
+	})
+	if err != nil {
+		t.Fatalf("CreateBottle() error = %v, want nil", err)
+	}
+
+	if bottle.Region == nil || *bottle.Region != "Islay" || bottle.ABV == nil || *bottle.ABV != 43 {
+		t.Errorf("CreateBottle() region/abv = %v/%v, want Islay/43", bottle.Region, bottle.ABV)
+	}
+
+	updated, err := repo.UpdateBottle(ctx, int(bottle.ID), &models.Bottle{Name: "Lagavulin 16", AgeStatement: str("")})
+	if err != nil {
+		t.Fatalf("UpdateBottle() error = %v, want nil", err)
+	}
+
+	if updated.Brand == nil || *updated.Brand != "Lagavulin" || updated.ABV == nil || *updated.ABV != 43 {
+		t.Errorf("UpdateBottle() brand/abv = %v/%v, want them kept", updated.Brand, updated.ABV)
+	}
+
+	if updated.AgeStatement != nil {
+		t.Errorf("UpdateBottle() age statement = %q, want cleared", *updated.AgeStatement)
+	}
+}
+
+func TestFindBottles(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	for _, bottle := range []*models.Bottle{
+		{Name: "Buffalo Trace", Category: str("Whiskey"), Subcategory: str("Bourbon"), ABV: float(45), Country: str("USA")},
+		{Name: "Laphroaig 10", Category: str("whiskey"), Subcategory: str("Scotch"), ABV: float(40), Country: str("Scotland"), Region: str("Islay")},
+		{Name: "Plantation 3 Stars", Category: str("Rum"), ABV: float(41.2)},
+		{Name: "Mystery Bottle"},
+	} {
+		if _, err := repo.CreateBottle(ctx, bottle); err != nil {
+			t.Fatalf("CreateBottle() error = %v, want nil", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter BottleFilter
+		want   []string
+	}{
+		{"no filter", BottleFilter{}, []string{"Buffalo Trace", "Laphroaig 10", "Mystery Bottle", "Plantation 3 Stars"}},
+		{"category ignores case", BottleFilter{Category: "WHISKEY"}, []string{"Buffalo Trace", "Laphroaig 10"}},
+		{"region", BottleFilter{Region: "islay"}, []string{"Laphroaig 10"}},
+		{"abv range", BottleFilter{MinABV: float(41), MaxABV: float(44)}, []string{"Plantation 3 Stars"}},
+		{"combined", BottleFilter{Category: "whiskey", MinABV: float(42)}, []string{"Buffalo Trace"}},
+		{"no match", BottleFilter{Country: "Japan"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bottles, _, err := repo.FindBottles(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("FindBottles() error = %v, want nil", err)
+			}
+
+			var names []string
+			for _, bottle := range bottles {
+				names = append(names, bottle.Name)
+			}
+			sort.Strings(names)
+
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("FindBottles() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackupTo(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	if _, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Campari"}); err != nil {
+		t.Fatalf("CreateBottle() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "backup.db")
+	if err := repo.BackupTo(ctx, path); err != nil {
+		t.Fatalf("BackupTo() error = %v, want nil", err)
+	}
+
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("Failed to open backup: %v", err)
+	}
+	defer db.Close()
+
+	var name string
+	if err := db.QueryRow(`SELECT name FROM bottles`).Scan(&name); err != nil || name != "Campari" {
+		t.Errorf("backup bottle = %q, %v, want Campari", name, err)
+	}
+
+	if err := repo.BackupTo(ctx, path); err == nil {
+		t.Error("BackupTo() over an existing file error = nil, want an error")
+	}
+}
+
+func TestNew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "app.db")
+	repo, err := New(Config{Path: path, JournalMode: "WAL", BusyTimeout: DefaultConfig.BusyTimeout})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer repo.CloseDB()
+
+	if err := repo.RunMigrations(); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+	if err := repo.RunMigrations(); err != nil {
+		t.Fatalf("RunMigrations() a second time error = %v", err)
+	}
+	if _, err := repo.CreateBottle(context.Background(), &models.Bottle{Name: "Rye"}); err != nil {
+		t.Fatalf("CreateBottle() after migrating error = %v", err)
+	}
+
+	var mode string
+	var timeout int
+	if err := repo.DB.QueryRow(`PRAGMA journal_mode`).Scan(&mode); err != nil {
+		t.Fatalf("Failed to read journal mode: %v", err)
+	}
+	if err := repo.DB.QueryRow(`PRAGMA busy_timeout`).Scan(&timeout); err != nil {
+		t.Fatalf("Failed to read busy timeout: %v", err)
+	}
+	if mode != "wal" || timeout != 5000 {
+		t.Errorf("journal_mode = %s and busy_timeout = %d, want wal and 5000", mode, timeout)
+	}
+}
+
+func TestDSN(t *testing.T) {
+	cfg := Config{JournalMode: "WAL", BusyTimeout: DefaultConfig.BusyTimeout}
+	tests := []struct {
+		path     string
+		wantDSN  string
+		wantFile string
+	}{
+		{"/data/app.db", "/data/app.db?_busy_timeout=5000&_journal_mode=WAL", "/data/app.db"},
+		{"/data/app.db?_busy_timeout=100&cache=shared", "/data/app.db?_busy_timeout=100&_journal_mode=WAL&cache=shared", "/data/app.db"},
+		{"file:/data/app.db?_journal=DELETE", "file:/data/app.db?_busy_timeout=5000&_journal=DELETE", "/data/app.db"},
+		{"file:///data/app.db", "file:///data/app.db?_busy_timeout=5000&_journal_mode=WAL", "/data/app.db"},
+		{"file:app.db?mode=rwc", "file:app.db?_busy_timeout=5000&_journal_mode=WAL&mode=rwc", "app.db"},
+		{"file::memory:?cache=shared", "file::memory:?_busy_timeout=5000&_journal_mode=WAL&cache=shared", ""},
+		{":memory:", ":memory:?_busy_timeout=5000&_journal_mode=WAL", ""},
+	}
+	for _, tt := range tests {
+		cfg.Path = tt.path
+		got, file, err := dsn(cfg)
+		if err != nil || got != tt.wantDSN || file != tt.wantFile {
+			t.Errorf("dsn(%q) = %q, %q, %v; want %q, %q", tt.path, got, file, err, tt.wantDSN, tt.wantFile)
+		}
+	}
+
+	// A file: URI with options of its own opens, and gets its directory made.
+	path := filepath.Join(t.TempDir(), "nested", "app.db")
+	repo, err := New(Config{Path: "file:" + path + "?_busy_timeout=250", JournalMode: "WAL"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer repo.CloseDB()
+	var timeout int
+	if err := repo.DB.QueryRow(`PRAGMA busy_timeout`).Scan(&timeout); err != nil || timeout != 250 {
+		t.Errorf("busy_timeout = %d, %v; want 250 from the URI", timeout, err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("database file not created: %v", err)
+	}
+}
+
+func TestNew_BadPath(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	if _, err := New(Config{Path: filepath.Join(file, "app.db")}); err == nil {
+		t.Error("New() under a file succeeded, want an error")
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("DB_PATH", "/data/bar.db")
+	t.Setenv("MIGRATIONS_PATH", "")
+	t.Setenv("DB_JOURNAL_MODE", "delete")
+	t.Setenv("DB_BUSY_TIMEOUT", "10s")
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if cfg.Path != "/data/bar.db" || cfg.JournalMode != "DELETE" || cfg.BusyTimeout.Seconds() != 10 {
+		t.Errorf("ConfigFromEnv() = %+v", cfg)
+	}
+
+	t.Setenv("DB_BUSY_TIMEOUT", "soon")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Error("ConfigFromEnv() with a bad DB_BUSY_TIMEOUT succeeded, want an error")
+	}
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+var ErrEmptySearch = errors.New("search query must contain a word")
+
+// searchRank orders search_index rows by FTS5's BM25, with each column
+// weighted in column order: kind, item_id, name, details. A word in an item's
+// name counts for more than one in a recipe's ingredients or a bottle's
+// details. bm25 is lower for better matches.
+const searchRank = `bm25(search_index, 0, 0, 4, 1)`
+
+// Search finds the inventory items and saved cocktails whose names or details
+// contain every word of query, best match first. Words match as prefixes, so
+// "camp" finds Campari. At most limit hits are returned.
+func (r *Repository) Search(ctx context.Context, query string, limit int) ([]models.SearchHit, error) {
+	match := searchMatch(query)
+	if match == "" {
+		return nil, ErrEmptySearch
+	}
+
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT kind, item_id, name, -`+searchRank+` AS score
+		FROM search_index
+		WHERE search_index MATCH ?
+		ORDER BY score DESC, name COLLATE NOCASE
+		LIMIT ?`, match, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %v", err)
+	}
+	defer rows.Close()
+
+	hits := []models.SearchHit{}
+	for rows.Next() {
+		var hit models.SearchHit
+		if err := rows.Scan(&hit.Kind, &hit.ID, &hit.Name, &hit.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan search hit: %v", err)
+		}
+		hits = append(hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over search hits: %v", err)
+	}
+
+	return hits, nil
+}
+
+// searchMatch turns free text into an FTS query that matches rows containing
+// a word starting with each word of the text. Punctuation is dropped and words
+// are lowercased, so user input can't inject FTS operators such as OR.
+func searchMatch(query string) string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for i, word := range words {
+		words[i] = word + "*"
+	}
+	return strings.Join(words, " ")
+}
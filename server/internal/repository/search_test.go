@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+func hitKeys(hits []models.SearchHit) []string {
+	keys := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		keys = append(keys, hit.Kind+":"+hit.Name)
+	}
+	return keys
+}
+
+func TestSearch(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	if _, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Campari", Category: str("Liqueur"), Country: str("Italy")}); err != nil {
+		t.Fatalf("CreateBottle() error = %v, want nil", err)
+	}
+	if _, err := repo.CreateMixer(ctx, &models.Mixer{Name: "Tonic Water"}); err != nil {
+		t.Fatalf("CreateMixer() error = %v, want nil", err)
+	}
+	if _, err := repo.CreateFresh(ctx, &models.Fresh{Name: "Orange Peel"}); err != nil {
+		t.Fatalf("CreateFresh() error = %v, want nil", err)
+	}
+	cocktail := newTestCocktail()
+	cocktail.Ingredients = append(cocktail.Ingredients, models.Ingredient{Name: "Orange Peel"})
+	if _, err := repo.CreateCocktail(ctx, cocktail); err != nil {
+		t.Fatalf("CreateCocktail() error = %v, want nil", err)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"campari", []string{"bottle:Campari", "cocktail:Negroni"}},
+		{"CAMP", []string{"bottle:Campari", "cocktail:Negroni"}},
+		{"orange", []string{"fresh:Orange Peel", "cocktail:Negroni"}},
+		{"italy", []string{"bottle:Campari"}},
+		{"tonic water", []string{"mixer:Tonic Water"}},
+		{`"tonic" OR gin*`, nil},
+		{"mezcal", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			hits, err := repo.Search(ctx, tt.query, 0)
+			if err != nil {
+				t.Fatalf("Search() error = %v, want nil", err)
+			}
+
+			if got := hitKeys(hits); !equalNames(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := repo.Search(ctx, " ?! ", 0); err != ErrEmptySearch {
+		t.Errorf("Search() error = %v, want %v", err, ErrEmptySearch)
+	}
+
+	hits, err := repo.Search(ctx, "campari", 1)
+	if err != nil {
+		t.Fatalf("Search() error = %v, want nil", err)
+	}
+	if len(hits) != 1 {
+		t.Errorf("Search() with limit 1 returned %d hits", len(hits))
+	}
+}
+
+func TestSearch_FollowsChanges(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	mixer, err := repo.CreateMixer(ctx, &models.Mixer{Name: "Ginger Ale"})
+	if err != nil {
+		t.Fatalf("CreateMixer() error = %v, want nil", err)
+	}
+	// The bottle shares the mixer's ID, so changing the mixer must leave the
+	// bottle's row alone.
+	if _, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Dry Curacao"}); err != nil {
+		t.Fatalf("CreateBottle() error = %v, want nil", err)
+	}
+	cocktail, err := repo.CreateCocktail(ctx, newTestCocktail())
+	if err != nil {
+		t.Fatalf("CreateCocktail() error = %v, want nil", err)
+	}
+
+	if _, err := repo.UpdateMixer(ctx, int(mixer.ID), &models.Mixer{Name: "Ginger Beer"}); err != nil {
+		t.Fatalf("UpdateMixer() error = %v, want nil", err)
+	}
+	updated := newTestCocktail()
+	updated.Name = "Boulevardier"
+	updated.Ingredients[0].Name = "Bourbon"
+	if _, err := repo.UpdateCocktail(ctx, cocktail.ID, updated); err != nil {
+		t.Fatalf("UpdateCocktail() error = %v, want nil", err)
+	}
+
+	for query, want := range map[string][]string{
+		"ale":     nil,
+		"beer":    {"mixer:Ginger Beer"},
+		"gin":     {"mixer:Ginger Beer"},
+		"bourbon": {"cocktail:Boulevardier"},
+		"negroni": nil,
+		"curacao": {"bottle:Dry Curacao"},
+	} {
+		hits, err := repo.Search(ctx, query, 0)
+		if err != nil {
+			t.Fatalf("Search() error = %v, want nil", err)
+		}
+		if got := hitKeys(hits); !equalNames(got, want) {
+			t.Errorf("Search(%q) = %v, want %v", query, got, want)
+		}
+	}
+
+	if err := repo.DeleteCocktailByID(ctx, cocktail.ID); err != nil {
+		t.Fatalf("DeleteCocktailByID() error = %v, want nil", err)
+	}
+	hits, err := repo.Search(ctx, "bourbon", 0)
+	if err != nil {
+		t.Fatalf("Search() error = %v, want nil", err)
+	}
+	if len(hits) != 0 {
+		t.Errorf("Search() after delete = %v, want no hits", hitKeys(hits))
+	}
+}
@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+var (
+	ErrNilShoppingList       = errors.New("shopping list cannot be nil")
+	ErrShoppingListNotFound  = errors.New("shopping list not found")
+	ErrShoppingItemNotFound  = errors.New("shopping list item not found")
+	ErrShoppingItemConverted = errors.New("shopping list item has already been converted")
+	ErrInvalidInventoryKind  = errors.New("kind must be bottle, mixer, fresh, bitters, syrup or garnish")
+)
+
+func (r *Repository) CreateShoppingList(ctx context.Context, list *models.ShoppingList) (*models.ShoppingList, error) {
+	if list == nil {
+		return nil, ErrNilShoppingList
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO shopping_lists (name, created_at, updated_at)
+		VALUES (?, datetime('now'), datetime('now'))
+		RETURNING id, created_at, updated_at`
+
+	err = tx.QueryRowContext(ctx, query, list.Name).Scan(&list.ID, &list.CreatedAt, &list.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shopping list: %v", err)
+	}
+
+	for i := range list.Items {
+		item := &list.Items[i]
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO shopping_list_items (list_id, position, name, quantity, needed_for)
+			VALUES (?, ?, ?, ?, ?)
+			RETURNING id`, list.ID, i, item.Name, item.Quantity, item.NeededFor).Scan(&item.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create shopping list item: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit shopping list: %v", err)
+	}
+
+	if list.Items == nil {
+		list.Items = []models.ShoppingListItem{}
+	}
+
+	return list, nil
+}
+
+func (r *Repository) GetShoppingListByID(ctx context.Context, id int) (*models.ShoppingList, error) {
+	query := `
+		SELECT id, name, created_at, updated_at
+		FROM shopping_lists
+		WHERE id = ?`
+
+	var list models.ShoppingList
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(&list.ID, &list.Name, &list.CreatedAt, &list.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrShoppingListNotFound
+		}
+		return nil, fmt.Errorf("failed to get shopping list by ID: %v", err)
+	}
+
+	if err := r.loadShoppingListItems(ctx, map[int64]*models.ShoppingList{list.ID: &list}, `WHERE list_id = ?`, id); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// GetAllShoppingLists returns every shopping list with its items, newest
+// first.
+func (r *Repository) GetAllShoppingLists(ctx context.Context) ([]*models.ShoppingList, error) {
+	query := `
+		SELECT id, name, created_at, updated_at
+		FROM shopping_lists
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shopping lists: %v", err)
+	}
+	defer rows.Close()
+
+	lists := []*models.ShoppingList{}
+	byID := make(map[int64]*models.ShoppingList)
+	for rows.Next() {
+		var list models.ShoppingList
+		if err := rows.Scan(&list.ID, &list.Name, &list.CreatedAt, &list.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan shopping list: %v", err)
+		}
+		lists = append(lists, &list)
+		byID[list.ID] = &list
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over shopping lists: %v", err)
+	}
+	rows.Close()
+
+	if err := r.loadShoppingListItems(ctx, byID, ``); err != nil {
+		return nil, err
+	}
+
+	return lists, nil
+}
+
+// loadShoppingListItems fills in the items of the lists in byID. where
+// optionally narrows the query to the lists being loaded.
+func (r *Repository) loadShoppingListItems(ctx context.Context, byID map[int64]*models.ShoppingList, where string, args ...any) error {
+	for _, list := range byID {
+		list.Items = []models.ShoppingListItem{}
+	}
+
+	query := `
+		SELECT list_id, ` + shoppingItemColumns + `
+		FROM shopping_list_items
+		` + where + `
+		ORDER BY list_id, position`
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to get shopping list items: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var listID int64
+		var item models.ShoppingListItem
+		if err := rows.Scan(append([]any{&listID}, shoppingItemFields(&item)...)...); err != nil {
+			return fmt.Errorf("failed to scan shopping list item: %v", err)
+		}
+		if list, ok := byID[listID]; ok {
+			list.Items = append(list.Items, item)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating over shopping list items: %v", err)
+	}
+
+	return nil
+}
+
+const shoppingItemColumns = `id, name, quantity, needed_for, checked, checked_at, converted_kind, converted_id`
+
+func shoppingItemFields(item *models.ShoppingListItem) []any {
+	return []any{&item.ID, &item.Name, &item.Quantity, &item.NeededFor, &item.Checked, &item.CheckedAt, &item.ConvertedKind, &item.ConvertedID}
+}
+
+func (r *Repository) DeleteShoppingListByID(ctx context.Context, id int) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM shopping_list_items WHERE list_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete shopping list items: %v", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM shopping_lists WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete shopping list: %v", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return ErrShoppingListNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit shopping list deletion: %v", err)
+	}
+
+	return nil
+}
+
+// SetShoppingItemChecked checks an item off a list, or unchecks it.
+func (r *Repository) SetShoppingItemChecked(ctx context.Context, listID, itemID int, checked bool) (*models.ShoppingListItem, error) {
+	query := `
+		UPDATE shopping_list_items
+		SET checked = ?,
+			checked_at = CASE WHEN ? THEN COALESCE(checked_at, datetime('now')) END
+		WHERE id = ? AND list_id = ?
+		RETURNING ` + shoppingItemColumns
+
+	var item models.ShoppingListItem
+	err := r.DB.QueryRowContext(ctx, query, checked, checked, itemID, listID).Scan(shoppingItemFields(&item)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrShoppingItemNotFound
+		}
+		return nil, fmt.Errorf("failed to update shopping list item: %v", err)
+	}
+
+	return &item, nil
+}
+
+// ConvertShoppingItem adds an inventory item of the requested kind named
+// after a shopping list item and checks the item off, in one transaction. An
+// item can only be converted once.
+func (r *Repository) ConvertShoppingItem(ctx context.Context, listID, itemID int, req models.ConvertShoppingItemRequest) (*models.ShoppingListItem, error) {
+	if !slices.Contains(shoppingKinds, req.Kind) {
+		return nil, ErrInvalidInventoryKind
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var name string
+	var convertedKind *string
+	err = tx.QueryRowContext(ctx, `SELECT name, converted_kind FROM shopping_list_items WHERE id = ? AND list_id = ?`, itemID, listID).Scan(&name, &convertedKind)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrShoppingItemNotFound
+		}
+		return nil, fmt.Errorf("failed to get shopping list item: %v", err)
+	}
+	if convertedKind != nil {
+		return nil, ErrShoppingItemConverted
+	}
+
+	inventoryID, err := r.createBought(ctx, tx, req.Kind, name, req.PurchaseDate, req.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE shopping_list_items
+		SET checked = TRUE,
+			checked_at = COALESCE(checked_at, datetime('now')),
+			converted_kind = ?,
+			converted_id = ?
+		WHERE id = ?
+		RETURNING ` + shoppingItemColumns
+
+	var item models.ShoppingListItem
+	if err := tx.QueryRowContext(ctx, query, req.Kind, inventoryID, itemID).Scan(shoppingItemFields(&item)...); err != nil {
+		return nil, fmt.Errorf("failed to update shopping list item: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit shopping list item conversion: %v", err)
+	}
+
+	return &item, nil
+}
+
+// shoppingKinds are the kinds of inventory item a shopping list item can be
+// converted to.
+var shoppingKinds = []string{"bottle", "mixer", "fresh", "bitters", "syrup", "garnish"}
+
+// createBought adds a bought item of one of shoppingKinds to the inventory
+// and returns its ID.
+func (r *Repository) createBought(ctx context.Context, q queryRower, kind, name string, purchaseDate *time.Time, price *float64) (int64, error) {
+	switch kind {
+	case "bottle":
+		bottle, err := r.Bottles().create(ctx, q, &models.Bottle{Name: name, PurchaseDate: purchaseDate, Price: price})
+		if err != nil {
+			return 0, err
+		}
+		return bottle.ID, nil
+	case "mixer":
+		mixer, err := r.Mixers().create(ctx, q, &models.Mixer{Name: name, PurchaseDate: purchaseDate, Price: price})
+		if err != nil {
+			return 0, err
+		}
+		return mixer.ID, nil
+	case "fresh":
+		fresh, err := r.Fresh().create(ctx, q, &models.Fresh{Name: name, PurchaseDate: purchaseDate, Price: price})
+		if err != nil {
+			return 0, err
+		}
+		return fresh.ID, nil
+	case "bitters":
+		bitters, err := r.Bitters().create(ctx, q, &models.Bitters{Name: name, PurchaseDate: purchaseDate, Price: price})
+		if err != nil {
+			return 0, err
+		}
+		return bitters.ID, nil
+	case "syrup":
+		syrup, err := r.Syrups().create(ctx, q, &models.Syrup{Name: name, PurchaseDate: purchaseDate, Price: price})
+		if err != nil {
+			return 0, err
+		}
+		return syrup.ID, nil
+	case "garnish":
+		garnish, err := r.Garnishes().create(ctx, q, &models.Garnish{Name: name, PurchaseDate: purchaseDate, Price: price})
+		if err != nil {
+			return 0, err
+		}
+		return garnish.ID, nil
+	}
+	return 0, ErrInvalidInventoryKind
+}
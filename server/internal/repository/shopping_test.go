@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+func TestShoppingList_CheckAndConvert(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.CloseDB()
+
+	ctx := context.Background()
+	list, err := repo.CreateShoppingList(ctx, &models.ShoppingList{
+		Name: "Friday",
+		Items: []models.ShoppingListItem{
+			{Name: "Campari", Quantity: "1 oz", NeededFor: "Negroni"},
+			{Name: "Limes", Quantity: "2", NeededFor: "Daiquiri"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateShoppingList() error = %v, want nil", err)
+	}
+
+	listID, campariID := int(list.ID), int(list.Items[0].ID)
+
+	checked, err := repo.SetShoppingItemChecked(ctx, listID, campariID, true)
+	if err != nil {
+		t.Fatalf("SetShoppingItemChecked() error = %v, want nil", err)
+	}
+	if !checked.Checked || checked.CheckedAt == nil {
+		t.Errorf("SetShoppingItemChecked() = %+v, want checked with a timestamp", checked)
+	}
+
+	converted, err := repo.ConvertShoppingItem(ctx, listID, campariID, models.ConvertShoppingItemRequest{Kind: "bottle"})
+	if err != nil {
+		t.Fatalf("ConvertShoppingItem() error = %v, want nil", err)
+	}
+	if converted.ConvertedKind == nil || *converted.ConvertedKind != "bottle" || converted.ConvertedID == nil {
+		t.Fatalf("ConvertShoppingItem() = %+v, want a bottle link", converted)
+	}
+
+	bottle, err := repo.GetBottleByID(ctx, int(*converted.ConvertedID))
+	if err != nil {
+		t.Fatalf("GetBottleByID() error = %v, want nil", err)
+	}
+	if bottle.Name != "Campari" {
+		t.Errorf("converted bottle name = %q, want Campari", bottle.Name)
+	}
+
+	if _, err := repo.ConvertShoppingItem(ctx, listID, campariID, models.ConvertShoppingItemRequest{Kind: "bottle"}); err != ErrShoppingItemConverted {
+		t.Errorf("ConvertShoppingItem() twice error = %v, want %v", err, ErrShoppingItemConverted)
+	}
+	if _, err := repo.ConvertShoppingItem(ctx, listID, int(list.Items[1].ID), models.ConvertShoppingItemRequest{Kind: "cocktail"}); err != ErrInvalidInventoryKind {
+		t.Errorf("ConvertShoppingItem() error = %v, want %v", err, ErrInvalidInventoryKind)
+	}
+	if _, err := repo.SetShoppingItemChecked(ctx, listID+1, campariID, true); err != ErrShoppingItemNotFound {
+		t.Errorf("SetShoppingItemChecked() on another list error = %v, want %v", err, ErrShoppingItemNotFound)
+	}
+
+	got, err := repo.GetShoppingListByID(ctx, listID)
+	if err != nil {
+		t.Fatalf("GetShoppingListByID() error = %v, want nil", err)
+	}
+	if len(got.Items) != 2 || !got.Items[0].Checked || got.Items[1].Checked {
+		t.Errorf("GetShoppingListByID() items = %+v, want Campari checked and Limes not", got.Items)
+	}
+
+	price := 1.5
+	converted, err = repo.ConvertShoppingItem(ctx, listID, int(list.Items[1].ID), models.ConvertShoppingItemRequest{Kind: "garnish", Price: &price})
+	if err != nil {
+		t.Fatalf("ConvertShoppingItem(garnish) error = %v, want nil", err)
+	}
+	garnish, err := repo.Garnishes().Get(ctx, int(*converted.ConvertedID))
+	if err != nil {
+		t.Fatalf("Garnishes().Get() error = %v, want nil", err)
+	}
+	if garnish.Name != "Limes" || garnish.Price == nil || *garnish.Price != price {
+		t.Errorf("converted garnish = %+v, want Limes at %v", garnish, price)
+	}
+
+	if err := repo.DeleteShoppingListByID(ctx, listID); err != nil {
+		t.Fatalf("DeleteShoppingListByID() error = %v, want nil", err)
+	}
+	if _, err := repo.GetShoppingListByID(ctx, listID); err != ErrShoppingListNotFound {
+		t.Errorf("GetShoppingListByID() after delete error = %v, want %v", err, ErrShoppingListNotFound)
+	}
+}
@@ -0,0 +1,62 @@
+package secret
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSealOpen(t *testing.T) {
+	box, err := NewBox("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("NewBox: %v", err)
+	}
+
+	sealed, err := box.Seal("sk-test-1234")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if strings.Contains(sealed, "sk-test") {
+		t.Fatalf("sealed value %q contains the plaintext", sealed)
+	}
+
+	again, err := box.Seal("sk-test-1234")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if again == sealed {
+		t.Error("sealing twice gave the same ciphertext; nonces are not random")
+	}
+
+	opened, err := box.Open(sealed)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if opened != "sk-test-1234" {
+		t.Errorf("Open = %q, want %q", opened, "sk-test-1234")
+	}
+}
+
+func TestOpenWrongKey(t *testing.T) {
+	box, _ := NewBox("one key")
+	other, _ := NewBox("another key")
+
+	sealed, err := box.Seal("sk-test-1234")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	if _, err := other.Open(sealed); err != ErrDecrypt {
+		t.Errorf("Open with the wrong key: err = %v, want ErrDecrypt", err)
+	}
+	for _, bad := range []string{"", "not base64!", "c2hvcnQ="} {
+		if _, err := box.Open(bad); err != ErrDecrypt {
+			t.Errorf("Open(%q): err = %v, want ErrDecrypt", bad, err)
+		}
+	}
+}
+
+func TestNewBoxEmpty(t *testing.T) {
+	if _, err := NewBox(""); err != ErrNoKey {
+		t.Errorf("NewBox(\"\"): err = %v, want ErrNoKey", err)
+	}
+}
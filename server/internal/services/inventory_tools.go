@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nguyenjessev/liquor-locker/internal/freshness"
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+)
+
+// expiringWindow is how far ahead list_expiring_items looks.
+const expiringWindow = 3 * 24 * time.Hour
+
+// defaultToolLimit and maxToolLimit bound how many results the search tools
+// return, so a big bar or recipe book doesn't fill the model's context.
+const (
+	defaultToolLimit = 20
+	maxToolLimit     = 50
+)
+
+// inventoryKinds are the kinds of inventory item, as search hits name them.
+var inventoryKinds = []string{"bottle", "mixer", "fresh", "bitters", "syrup", "garnish"}
+
+// recipeSummary is a saved recipe without its steps, as list_saved_recipes
+// returns it.
+type recipeSummary struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Ingredients []string `json:"ingredients"`
+}
+
+// recipeList is list_saved_recipes' result. Total counts every recipe that
+// matched, so the model knows when Recipes holds only the first of them.
+type recipeList struct {
+	Recipes   []recipeSummary `json:"recipes"`
+	Total     int             `json:"total"`
+	Truncated bool            `json:"truncated"`
+}
+
+// InventoryTools returns the tools that let a model look up the bar's
+// inventory and saved recipes in repo.
+func InventoryTools(repo *repository.Repository) *ToolRegistry {
+	tools := NewToolRegistry()
+
+	listAll := func(name, description string, all func(context.Context) (any, error)) {
+		tools.Register(Tool{Name: name, Description: description}, func(ctx context.Context, _ json.RawMessage) (any, error) {
+			return all(ctx)
+		})
+	}
+	listAll("list_bottles", "Get list of bottles in the user's bar inventory. For a large bar, search_inventory finds particular bottles without listing them all",
+		func(ctx context.Context) (any, error) { return repo.GetAllBottles(ctx) })
+	listAll("list_fresh_ingredients", "Get list of fresh ingredients in the user's bar inventory",
+		func(ctx context.Context) (any, error) { return repo.GetAllFresh(ctx) })
+	listAll("list_mixers", "Get list of mixers in the user's bar inventory",
+		func(ctx context.Context) (any, error) { return repo.GetAllMixers(ctx) })
+	listAll("list_bitters", "Get list of bitters in the user's bar inventory, with roughly how many dashes are left in each",
+		func(ctx context.Context) (any, error) { return repo.Bitters().All(ctx) })
+	listAll("list_syrups", "Get list of syrups in the user's bar inventory, with their sugar to water ratio and the date they were made",
+		func(ctx context.Context) (any, error) { return repo.Syrups().All(ctx) })
+	listAll("list_garnishes", "Get list of garnishes in the user's bar inventory, with how many of each are on hand",
+		func(ctx context.Context) (any, error) { return repo.Garnishes().All(ctx) })
+	listAll("list_expiring_items", "Get the fresh ingredients, opened mixers and syrups that expire within the next three days or have expired, soonest first, with their expiry time and status",
+		func(ctx context.Context) (any, error) { return listExpiring(ctx, repo) })
+
+	tools.Register(Tool{
+		Name:        "search_inventory",
+		Description: "Search the user's bar inventory by name or category, such as \"campari\" or \"rye whiskey\". Words match the start of words in an item's name, and in a bottle's category, subcategory, brand, country and region. Returns the kind, ID and name of the best matches; get_item_details gives the rest",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{"type": "string", "description": "Words to search for"},
+				"kind":  map[string]any{"type": "string", "enum": inventoryKinds, "description": "Only return items of this kind"},
+				"limit": map[string]any{"type": "integer", "minimum": 1, "maximum": maxToolLimit, "description": "Most items to return, 20 if not set"},
+			},
+			"required": []string{"query"},
+		},
+	}, func(ctx context.Context, args json.RawMessage) (any, error) {
+		var in struct {
+			Query string `json:"query"`
+			Kind  string `json:"kind"`
+			Limit int    `json:"limit"`
+		}
+		if err := decodeArgs(args, &in); err != nil {
+			return nil, err
+		}
+		if in.Kind != "" && !slices.Contains(inventoryKinds, in.Kind) {
+			return nil, badCall("kind must be one of %s", strings.Join(inventoryKinds, ", "))
+		}
+
+		hits, err := search(ctx, repo, in.Query, func(kind string) bool {
+			return kind == in.Kind || (in.Kind == "" && kind != "cocktail")
+		})
+		if err != nil {
+			return nil, err
+		}
+		return hits[:min(len(hits), toolLimit(in.Limit))], nil
+	})
+
+	tools.Register(Tool{
+		Name:        "get_item_details",
+		Description: "Get everything known about one inventory item, such as whether a bottle is open, how much is left and its ABV",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"kind": map[string]any{"type": "string", "enum": inventoryKinds},
+				"id":   map[string]any{"type": "integer"},
+			},
+			"required": []string{"kind", "id"},
+		},
+	}, func(ctx context.Context, args json.RawMessage) (any, error) {
+		var in struct {
+			Kind string `json:"kind"`
+			ID   int    `json:"id"`
+		}
+		if err := decodeArgs(args, &in); err != nil {
+			return nil, err
+		}
+
+		var item any
+		var err error
+		switch in.Kind {
+		case "bottle":
+			item, err = repo.GetBottleByID(ctx, in.ID)
+		case "mixer":
+			item, err = repo.GetMixerByID(ctx, in.ID)
+		case "fresh":
+			item, err = repo.GetFreshByID(ctx, in.ID)
+		case "bitters":
+			item, err = repo.Bitters().Get(ctx, in.ID)
+		case "syrup":
+			item, err = repo.Syrups().Get(ctx, in.ID)
+		case "garnish":
+			item, err = repo.Garnishes().Get(ctx, in.ID)
+		default:
+			return nil, badCall("kind must be one of %s", strings.Join(inventoryKinds, ", "))
+		}
+		if isNotFound(err) {
+			return nil, badCall("there is no %s with ID %d", in.Kind, in.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return item, nil
+	})
+
+	tools.Register(Tool{
+		Name:        "list_saved_recipes",
+		Description: "List cocktail recipes the user has saved, with their ingredients. Give a query to search them by name, description or ingredient. total is how many recipes matched and truncated is true if only the first of them were returned. Prefer these recipes when they can be made from the inventory; get_recipe gives the steps",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{"type": "string", "description": "Words to search for; leave out to list every recipe"},
+				"limit": map[string]any{"type": "integer", "minimum": 1, "maximum": maxToolLimit, "description": "Most recipes to return, 20 if not set"},
+			},
+		},
+	}, func(ctx context.Context, args json.RawMessage) (any, error) {
+		var in struct {
+			Query string `json:"query"`
+			Limit int    `json:"limit"`
+		}
+		if err := decodeArgs(args, &in); err != nil {
+			return nil, err
+		}
+
+		cocktails, err := repo.GetAllCocktails(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(in.Query) != "" {
+			hits, err := search(ctx, repo, in.Query, func(kind string) bool { return kind == "cocktail" })
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[int]*models.Cocktail, len(cocktails))
+			for _, cocktail := range cocktails {
+				byID[cocktail.ID] = cocktail
+			}
+			cocktails = cocktails[:0]
+			for _, hit := range hits {
+				if cocktail, ok := byID[int(hit.ID)]; ok {
+					cocktails = append(cocktails, cocktail)
+				}
+			}
+		}
+
+		list := recipeList{Recipes: []recipeSummary{}, Total: len(cocktails)}
+		limit := toolLimit(in.Limit)
+		list.Truncated = len(cocktails) > limit
+		for _, cocktail := range cocktails[:min(len(cocktails), limit)] {
+			summary := recipeSummary{ID: cocktail.ID, Name: cocktail.Name, Description: cocktail.Description, Ingredients: []string{}}
+			for _, ingredient := range cocktail.Ingredients {
+				summary.Ingredients = append(summary.Ingredients, ingredient.Name)
+			}
+			list.Recipes = append(list.Recipes, summary)
+		}
+		return list, nil
+	})
+
+	tools.Register(Tool{
+		Name:        "get_recipe",
+		Description: "Get one of the user's saved cocktail recipes with its ingredients, quantities and steps",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"id": map[string]any{"type": "integer"},
+			},
+			"required": []string{"id"},
+		},
+	}, func(ctx context.Context, args json.RawMessage) (any, error) {
+		var in struct {
+			ID int `json:"id"`
+		}
+		if err := decodeArgs(args, &in); err != nil {
+			return nil, err
+		}
+
+		cocktail, err := repo.GetCocktailByID(ctx, in.ID)
+		if errors.Is(err, repository.ErrCocktailNotFound) {
+			return nil, badCall("there is no saved recipe with ID %d", in.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return cocktail, nil
+	})
+
+	return tools
+}
+
+// search returns the search hits whose kind passes keep, best first.
+func search(ctx context.Context, repo *repository.Repository, query string, keep func(kind string) bool) ([]models.SearchHit, error) {
+	hits, err := repo.Search(ctx, query, 0)
+	if errors.Is(err, repository.ErrEmptySearch) {
+		return nil, badCall("query must contain a word")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	kept := []models.SearchHit{}
+	for _, hit := range hits {
+		if keep(hit.Kind) {
+			kept = append(kept, hit)
+		}
+	}
+	return kept, nil
+}
+
+// toolLimit returns the number of results to give for a requested limit.
+func toolLimit(limit int) int {
+	if limit <= 0 {
+		return defaultToolLimit
+	}
+	return min(limit, maxToolLimit)
+}
+
+func isNotFound(err error) bool {
+	for _, notFound := range []error{
+		repository.ErrBottleNotFound, repository.ErrMixerNotFound, repository.ErrFreshNotFound,
+		repository.ErrBittersNotFound, repository.ErrSyrupNotFound, repository.ErrGarnishNotFound,
+	} {
+		if errors.Is(err, notFound) {
+			return true
+		}
+	}
+	return false
+}
+
+func listExpiring(ctx context.Context, repo *repository.Repository) ([]models.ExpiringItem, error) {
+	mixers, err := repo.GetAllMixers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	freshIngredients, err := repo.GetAllFresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+	syrups, err := repo.Syrups().All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return freshness.Expiring(mixers, freshIngredients, syrups, time.Now(), expiringWindow), nil
+}
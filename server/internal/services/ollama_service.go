@@ -0,0 +1,183 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// OllamaService talks to an Ollama server through its native API, for
+// versions and setups whose OpenAI-compatible endpoint falls short on tools
+// or structured output.
+type OllamaService struct {
+	// BaseURL is the server's root, such as http://localhost:11434.
+	BaseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewOllamaService creates a new OllamaService. apiKey is sent as a bearer
+// token if set, for servers behind an authenticating proxy.
+func NewOllamaService(baseURL, apiKey string) *OllamaService {
+	return &OllamaService{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  &http.Client{},
+	}
+}
+
+type ollamaMessage struct {
+	Role      string           `json:"role"`
+	Content   string           `json:"content"`
+	ToolCalls []ollamaToolCall `json:"tool_calls,omitempty"`
+	ToolName  string           `json:"tool_name,omitempty"`
+}
+
+type ollamaToolCall struct {
+	Function struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	} `json:"function"`
+}
+
+type ollamaTool struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name        string         `json:"name"`
+		Description string         `json:"description"`
+		Parameters  map[string]any `json:"parameters"`
+	} `json:"function"`
+}
+
+type ollamaChatRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Tools    []ollamaTool    `json:"tools,omitempty"`
+	// Format is the JSON schema the answer must match.
+	Format any  `json:"format,omitempty"`
+	Stream bool `json:"stream"`
+}
+
+type ollamaChatResponse struct {
+	Message ollamaMessage `json:"message"`
+}
+
+// ListModels returns the names of the models pulled on the server.
+func (s *OllamaService) ListModels(ctx context.Context) ([]string, error) {
+	var resp struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := s.do(ctx, http.MethodGet, "/api/tags", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, model := range resp.Models {
+		names = append(names, model.Name)
+	}
+	return names, nil
+}
+
+// Chat sends a conversation to /api/chat. Ollama doesn't give tool calls
+// IDs, so the reply's calls get generated ones.
+func (s *OllamaService) Chat(ctx context.Context, req ChatRequest) (*Message, error) {
+	body := ollamaChatRequest{Model: req.Model, Stream: false}
+	for _, message := range req.Messages {
+		m := ollamaMessage{Role: string(message.Role), Content: message.Content, ToolName: message.ToolName}
+		for _, call := range message.ToolCalls {
+			var c ollamaToolCall
+			c.Function.Name = call.Name
+			c.Function.Arguments = call.arguments()
+			m.ToolCalls = append(m.ToolCalls, c)
+		}
+		body.Messages = append(body.Messages, m)
+	}
+	for _, tool := range req.Tools {
+		t := ollamaTool{Type: "function"}
+		t.Function.Name = tool.Name
+		t.Function.Description = tool.Description
+		t.Function.Parameters = tool.Parameters
+		if t.Function.Parameters == nil {
+			t.Function.Parameters = map[string]any{"type": "object", "properties": map[string]any{}}
+		}
+		body.Tools = append(body.Tools, t)
+	}
+	if req.Schema != nil {
+		body.Format = req.Schema.Schema
+	}
+
+	var resp ollamaChatResponse
+	if err := s.do(ctx, http.MethodPost, "/api/chat", body, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Message.Role == "" && resp.Message.Content == "" && len(resp.Message.ToolCalls) == 0 {
+		return nil, ErrEmptyReply
+	}
+
+	message := &Message{Role: RoleAssistant, Content: resp.Message.Content}
+	for i, call := range resp.Message.ToolCalls {
+		message.ToolCalls = append(message.ToolCalls, ToolCall{
+			ID:        fmt.Sprintf("call_%d_%d", len(req.Messages), i+1),
+			Name:      call.Function.Name,
+			Arguments: call.Function.Arguments,
+		})
+	}
+	return message, nil
+}
+
+// do sends a request to the server and decodes the JSON answer into out.
+func (s *OllamaService) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode ollama request: %v", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.BaseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create ollama request: %v", err)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var failure struct {
+			Error string `json:"error"`
+		}
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		if json.Unmarshal(data, &failure) != nil || failure.Error == "" {
+			failure.Error = strings.TrimSpace(string(data))
+		}
+		return fmt.Errorf("ollama %s %s: %s (status %d)", method, path, failure.Error, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode ollama response: %v", err)
+	}
+	return nil
+}
+
+// Close cleans up any resources used by the Ollama service
+func (s *OllamaService) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
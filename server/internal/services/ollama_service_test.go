@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeOllama answers /api/chat with replies in turn and records what it was
+// sent.
+type fakeOllama struct {
+	mu       sync.Mutex
+	replies  []string
+	requests []ollamaChatRequest
+}
+
+func startOllama(t *testing.T, replies ...string) (*OllamaService, *fakeOllama) {
+	t.Helper()
+
+	fake := &fakeOllama{replies: replies}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/tags":
+			w.Write([]byte(`{"models": [{"name": "llama3.1:8b"}, {"name": "qwen3:14b"}]}`))
+		case "/api/chat":
+			var req ollamaChatRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": "invalid JSON"}`))
+				return
+			}
+
+			fake.mu.Lock()
+			defer fake.mu.Unlock()
+			fake.requests = append(fake.requests, req)
+			if len(fake.replies) == 0 {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error": "model \"` + req.Model + `\" not found, try pulling it first"}`))
+				return
+			}
+			w.Write([]byte(`{"model": "` + req.Model + `", "message": ` + fake.replies[0] + `, "done": true}`))
+			fake.replies = fake.replies[1:]
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return NewOllamaService(server.URL+"/", ""), fake
+}
+
+func TestOllama_ListModels(t *testing.T) {
+	s, _ := startOllama(t)
+
+	models, err := s.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if want := []string{"llama3.1:8b", "qwen3:14b"}; !slices.Equal(models, want) {
+		t.Errorf("ListModels() = %v, want %v", models, want)
+	}
+}
+
+func TestOllama_RecommendCocktail(t *testing.T) {
+	recommendation, _ := json.Marshal(recommendationJSON)
+	s, fake := startOllama(t,
+		`{"role": "assistant", "content": "", "tool_calls": [
+			{"function": {"name": "list_bottles", "arguments": {}}},
+			{"function": {"name": "list_mixers", "arguments": {}}}
+		]}`,
+		`{"role": "assistant", "content": `+string(recommendation)+`}`,
+	)
+	repo := setupTestRepository(t)
+
+	resp, err := RecommendCocktail(context.Background(), s, repo, "llama3.1:8b", RecommendDefault)
+	if err != nil {
+		t.Fatalf("RecommendCocktail() error = %v", err)
+	}
+	if resp == nil || len(resp.Cocktails) != 1 || resp.Cocktails[0].Name != "Gin Rickey" {
+		t.Fatalf("RecommendCocktail() = %+v, want the Gin Rickey", resp)
+	}
+
+	if len(fake.requests) != 2 {
+		t.Fatalf("RecommendCocktail() made %d requests, want 2", len(fake.requests))
+	}
+	first, second := fake.requests[0], fake.requests[1]
+	if first.Stream || first.Format != nil || len(first.Tools) != len(InventoryTools(repo).Tools()) {
+		t.Errorf("first request = stream %v, format %v, %d tools; want no stream, no format and every tool", first.Stream, first.Format, len(first.Tools))
+	}
+	if format, ok := second.Format.(map[string]any); !ok || format["type"] != "object" {
+		t.Errorf("second request format = %v, want the recommendation schema", second.Format)
+	}
+
+	var results []ollamaMessage
+	for _, message := range second.Messages {
+		if message.Role == "tool" {
+			results = append(results, message)
+		}
+	}
+	if len(results) != 2 || results[0].ToolName != "list_bottles" || results[1].ToolName != "list_mixers" {
+		t.Fatalf("second request tool results = %+v, want list_bottles then list_mixers", results)
+	}
+	if !strings.Contains(results[0].Content, "Hendricks Gin") || !strings.Contains(results[1].Content, "Simple Syrup") {
+		t.Errorf("tool results = %+v, want the bottles and mixers", results)
+	}
+}
+
+func TestOllama_Error(t *testing.T) {
+	s, _ := startOllama(t)
+
+	_, err := SendPrompt(context.Background(), s, "missing", "Hello")
+	if err == nil || !strings.Contains(err.Error(), `model "missing" not found`) || !strings.Contains(err.Error(), "404") {
+		t.Errorf("SendPrompt() error = %v, want the server's error and status", err)
+	}
+}
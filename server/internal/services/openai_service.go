@@ -3,11 +3,7 @@ package services
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
-	"github.com/invopop/jsonschema"
-	"github.com/nguyenjessev/liquor-locker/internal/models"
-	"github.com/nguyenjessev/liquor-locker/internal/repository"
 	"github.com/openai/openai-go/v2"
 	"github.com/openai/openai-go/v2/option"
 )
@@ -41,59 +37,33 @@ func (s *OpenAIService) ListModels(ctx context.Context) ([]string, error) {
 	return ids, nil
 }
 
-// SendPrompt sends a prompt to the LLM and returns the response text.
-func (s *OpenAIService) SendPrompt(ctx context.Context, model, prompt string) (string, error) {
-	req := openai.ChatCompletionNewParams{
-		Model: model,
-		Messages: []openai.ChatCompletionMessageParamUnion{
-			openai.UserMessage("Say this is a test"),
-		},
+// Chat sends a conversation through the chat completions API.
+func (s *OpenAIService) Chat(ctx context.Context, req ChatRequest) (*Message, error) {
+	params := openai.ChatCompletionNewParams{Model: req.Model}
+	for _, message := range req.Messages {
+		params.Messages = append(params.Messages, openAIMessage(message))
 	}
-
-	resp, err := s.Client.Chat.Completions.New(ctx, req)
-	if err != nil {
-		return "", err
-	}
-	if len(resp.Choices) == 0 {
-		return "", nil
-	}
-	return resp.Choices[0].Message.Content, nil
-}
-
-func GenerateSchema[T any]() any {
-	reflector := jsonschema.Reflector{
-		AllowAdditionalProperties: false,
-		DoNotReference:            true,
-	}
-
-	var v T
-
-	schema := reflector.Reflect(v)
-	return schema
-}
-
-var CocktailRecommendationResponseSchema = GenerateSchema[models.CocktailRecommendationResponse]()
-
-func (s *OpenAIService) RecommendCocktail(ctx context.Context, repo *repository.Repository, model string) (*models.CocktailRecommendationResponse, error) {
-	params := openai.ChatCompletionNewParams{
-		Messages: []openai.ChatCompletionMessageParamUnion{
-			openai.UserMessage("Recommend a cocktail based on the user's inventory, including bottles, fresh ingredients, and mixers. Prefer using open or prepared ingredients if possible, but you can use sealed ingredients if necessary. You may also assume that the user has common ingredients on hand, such as water and ice."),
-		},
-		Tools: []openai.ChatCompletionToolUnionParam{
-			openai.ChatCompletionFunctionTool(openai.FunctionDefinitionParam{
-				Name:        "list_bottles",
-				Description: openai.String("Get list of bottles in the user's bar inventory"),
-			}),
-			openai.ChatCompletionFunctionTool(openai.FunctionDefinitionParam{
-				Name:        "list_fresh_ingredients",
-				Description: openai.String("Get list of fresh ingredients in the user's bar inventory"),
-			}),
-			openai.ChatCompletionFunctionTool(openai.FunctionDefinitionParam{
-				Name:        "list_mixers",
-				Description: openai.String("Get list of mixers in the user's bar inventory"),
-			}),
-		},
-		Model: model,
+	for _, tool := range req.Tools {
+		function := openai.FunctionDefinitionParam{
+			Name:        tool.Name,
+			Description: openai.String(tool.Description),
+		}
+		if tool.Parameters != nil {
+			function.Parameters = openai.FunctionParameters(tool.Parameters)
+		}
+		params.Tools = append(params.Tools, openai.ChatCompletionFunctionTool(function))
+	}
+	if req.Schema != nil {
+		params.ResponseFormat = openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONSchema: &openai.ResponseFormatJSONSchemaParam{
+				JSONSchema: openai.ResponseFormatJSONSchemaJSONSchemaParam{
+					Name:        req.Schema.Name,
+					Description: openai.String(req.Schema.Description),
+					Schema:      req.Schema.Schema,
+					Strict:      openai.Bool(true),
+				},
+			},
+		}
 	}
 
 	resp, err := s.Client.Chat.Completions.New(ctx, params)
@@ -101,80 +71,47 @@ func (s *OpenAIService) RecommendCocktail(ctx context.Context, repo *repository.
 		return nil, err
 	}
 	if len(resp.Choices) == 0 {
-		return nil, nil
+		return nil, ErrEmptyReply
 	}
 
-	toolCalls := resp.Choices[0].Message.ToolCalls
-	if len(toolCalls) == 0 {
-		return nil, nil
+	reply := resp.Choices[0].Message
+	message := &Message{Role: RoleAssistant, Content: reply.Content}
+	for _, call := range reply.ToolCalls {
+		message.ToolCalls = append(message.ToolCalls, ToolCall{
+			ID:        call.ID,
+			Name:      call.Function.Name,
+			Arguments: json.RawMessage(call.Function.Arguments),
+		})
 	}
+	return message, nil
+}
 
-	params.Messages = append(params.Messages, resp.Choices[0].Message.ToParam())
-	for _, toolCall := range toolCalls {
-		switch toolCall.Function.Name {
-		case "list_bottles":
-			bottles, err := repo.GetAllBottles(ctx)
-			if err != nil {
-				return nil, err
-			}
-
-			bottlesJSON, err := json.Marshal(bottles)
-			if err != nil {
-				return nil, err
-			}
-			params.Messages = append(params.Messages, openai.ToolMessage(string(bottlesJSON), toolCall.ID))
-		case "list_fresh_ingredients":
-			freshIngredients, err := repo.GetAllFresh(ctx)
-			if err != nil {
-				return nil, err
-			}
-
-			freshIngredientsJSON, err := json.Marshal(freshIngredients)
-			if err != nil {
-				return nil, err
-			}
-			params.Messages = append(params.Messages, openai.ToolMessage(string(freshIngredientsJSON), toolCall.ID))
-		case "list_mixers":
-			mixers, err := repo.GetAllMixers(ctx)
-			if err != nil {
-				return nil, err
-			}
-
-			mixersJSON, err := json.Marshal(mixers)
-			if err != nil {
-				return nil, err
-			}
-			params.Messages = append(params.Messages, openai.ToolMessage(string(mixersJSON), toolCall.ID))
-		default:
-			return nil, fmt.Errorf("unknown function name: %s", toolCall.Function.Name)
+func openAIMessage(m Message) openai.ChatCompletionMessageParamUnion {
+	switch m.Role {
+	case RoleSystem:
+		return openai.SystemMessage(m.Content)
+	case RoleTool:
+		return openai.ToolMessage(m.Content, m.ToolCallID)
+	case RoleAssistant:
+		assistant := openai.ChatCompletionAssistantMessageParam{}
+		if m.Content != "" {
+			assistant.Content.OfString = openai.String(m.Content)
 		}
+		for _, call := range m.ToolCalls {
+			assistant.ToolCalls = append(assistant.ToolCalls, openai.ChatCompletionMessageToolCallUnionParam{
+				OfFunction: &openai.ChatCompletionMessageFunctionToolCallParam{
+					ID: call.ID,
+					Function: openai.ChatCompletionMessageFunctionToolCallFunctionParam{
+						Name:      call.Name,
+						Arguments: string(call.arguments()),
+					},
+				},
+			})
+		}
+		return openai.ChatCompletionMessageParamUnion{OfAssistant: &assistant}
+	default:
+		return openai.UserMessage(m.Content)
 	}
-
-	schemaParam := openai.ResponseFormatJSONSchemaJSONSchemaParam{
-		Name:        "cocktail_recommendations",
-		Description: openai.String("A list of recommended cocktails"),
-		Schema:      CocktailRecommendationResponseSchema,
-		Strict:      openai.Bool(true),
-	}
-
-	params.ResponseFormat = openai.ChatCompletionNewParamsResponseFormatUnion{
-		OfJSONSchema: &openai.ResponseFormatJSONSchemaParam{
-			JSONSchema: schemaParam,
-		},
-	}
-
-	resp, err = s.Client.Chat.Completions.New(ctx, params)
-	if err != nil {
-		return nil, err
-	}
-	if len(resp.Choices) == 0 {
-		return nil, nil
-	}
-
-	var cocktailRecommendations models.CocktailRecommendationResponse
-	_ = json.Unmarshal([]byte(resp.Choices[0].Message.Content), &cocktailRecommendations)
-
-	return &cocktailRecommendations, nil
 }
 
 // Close cleans up any resources used by the OpenAI service
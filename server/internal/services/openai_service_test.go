@@ -2,169 +2,305 @@ package services
 
 import (
 	"context"
-	"database/sql"
-	"fmt"
-	"os"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
-	"github.com/joho/godotenv"
+	"github.com/nguyenjessev/liquor-locker/internal/fakeopenai"
+	"github.com/nguyenjessev/liquor-locker/internal/models"
 	"github.com/nguyenjessev/liquor-locker/internal/repository"
 )
 
+const testAPIKey = "sk-test"
+
 func setupTestRepository(t *testing.T) *repository.Repository {
-	db, err := sql.Open("sqlite3", ":memory:")
+	t.Helper()
+
+	repo, err := repository.New(repository.Config{Path: filepath.Join(t.TempDir(), "app.db")})
 	if err != nil {
 		t.Fatalf("Failed to open test database: %v", err)
 	}
+	t.Cleanup(repo.CloseDB)
 
-	createBottlesTableSQL := `
-		CREATE TABLE bottles (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			name TEXT NOT NULL,
-			opened BOOLEAN NOT NULL DEFAULT FALSE,
-			created_at DATETIME NOT NULL,
-			updated_at DATETIME NOT NULL,
-			open_date DATETIME,
-			purchase_date DATETIME
-		)`
-
-	if _, err := db.Exec(createBottlesTableSQL); err != nil {
-		t.Fatalf("Failed to create bottles table: %v", err)
-	}
-
-	createFreshTableSQL := `
-		CREATE TABLE fresh (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			name TEXT NOT NULL,
-			created_at DATETIME NOT NULL,
-			updated_at DATETIME NOT NULL,
-			prepared_date DATETIME,
-			purchase_date DATETIME
-		)`
-
-	if _, err := db.Exec(createFreshTableSQL); err != nil {
-		t.Fatalf("Failed to create fresh table: %v", err)
-	}
-
-	createMixersTableSQL := `
-		CREATE TABLE mixers (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			name TEXT NOT NULL,
-			opened BOOLEAN NOT NULL DEFAULT FALSE,
-			created_at DATETIME NOT NULL,
-			updated_at DATETIME NOT NULL,
-			open_date DATETIME,
-			purchase_date DATETIME
-		)`
-
-	if _, err := db.Exec(createMixersTableSQL); err != nil {
-		t.Fatalf("Failed to create mixers table: %v", err)
-	}
-
-	_, err = db.Exec(`
-		INSERT INTO bottles (name, opened, created_at, updated_at)
-		VALUES ('Citadelle Jardin d Ete Gin', FALSE, datetime('now'), datetime('now')),
-			   ('Hendricks Gin', FALSE, datetime('now'), datetime('now'))`)
-	if err != nil {
-		t.Fatalf("Failed to insert test bottles data: %v", err)
+	if err := repo.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
 	}
 
-	_, err = db.Exec(`
-		INSERT INTO fresh (name, created_at, updated_at, prepared_date, purchase_date)
-		VALUES ('Lemon Juice', datetime('now'), datetime('now'), datetime('now'), datetime('now')),
-			   ('Simple Syrup', datetime('now'), datetime('now'), datetime('now'), datetime('now')),
-			   ('Amarena Cherries', datetime('now'), datetime('now'), datetime('now'), datetime('now')),
-			   ('Lime Juice', datetime('now'), datetime('now'), datetime('now'), datetime('now')),
-			   ('Oranges', datetime('now'), datetime('now'), datetime('now'), datetime('now'))`)
-	if err != nil {
-		t.Fatalf("Failed to insert test fresh data: %v", err)
+	ctx := context.Background()
+	for _, name := range []string{"Citadelle Jardin d Ete Gin", "Hendricks Gin"} {
+		if _, err := repo.CreateBottle(ctx, &models.Bottle{Name: name}); err != nil {
+			t.Fatalf("Failed to insert test bottle: %v", err)
+		}
 	}
-
-	_, err = db.Exec(`
-		INSERT INTO mixers (name, created_at, updated_at, open_date, purchase_date)
-		VALUES ('Simple Syrup', datetime('now'), datetime('now'), datetime('now'), datetime('now')),
-			   ('Seltzer Water', datetime('now'), datetime('now'), datetime('now'), datetime('now'))`)
-	if err != nil {
-		t.Fatalf("Failed to insert test mixers data: %v", err)
+	for _, name := range []string{"Lemon Juice", "Lime Juice", "Oranges"} {
+		if _, err := repo.Fresh().Create(ctx, &models.Fresh{Name: name}); err != nil {
+			t.Fatalf("Failed to insert test fresh item: %v", err)
+		}
 	}
+	for _, name := range []string{"Simple Syrup", "Seltzer Water"} {
+		if _, err := repo.Mixers().Create(ctx, &models.Mixer{Name: name}); err != nil {
+			t.Fatalf("Failed to insert test mixer: %v", err)
+		}
+	}
+
+	return repo
+}
+
+// startFake serves script and returns a service pointed at it.
+func startFake(t *testing.T, script fakeopenai.Script) (*OpenAIService, *fakeopenai.Server) {
+	t.Helper()
+
+	fake := fakeopenai.New(script)
+	fake.APIKey = testAPIKey
+	server := fake.Start()
+	t.Cleanup(server.Close)
 
-	return &repository.Repository{DB: db}
+	return NewOpenAIService(server.URL+"/v1", testAPIKey), fake
 }
 
+const recommendationJSON = `{"cocktails": [{
+	"name": "Gin Rickey",
+	"description": "Gin, lime and soda.",
+	"ingredients": [{"name": "Hendricks Gin", "quantity": "2 oz"}, {"name": "Lime Juice", "quantity": "0.5 oz"}],
+	"steps": [{"order": 1, "text": "Build over ice and top with seltzer."}]
+}]}`
+
 func TestListModels(t *testing.T) {
-	err := godotenv.Load()
+	s, _ := startFake(t, fakeopenai.Script{Models: []string{"gpt-test", "gpt-test-mini"}})
+
+	models, err := s.ListModels(context.Background())
 	if err != nil {
-		t.Log("No .env file found or error loading .env file")
+		t.Fatalf("ListModels() error = %v", err)
 	}
-	ctx := context.Background()
-	baseURL := os.Getenv("OPENAI_BASE_URL")
-	apiKey := os.Getenv("OPENAI_API_KEY")
-	if baseURL == "" || apiKey == "" {
-		t.Fatal("OPENAI_BASE_URL or OPENAI_API_KEY environment variable not set")
+	if want := []string{"gpt-test", "gpt-test-mini"}; !slices.Equal(models, want) {
+		t.Errorf("ListModels() = %v, want %v", models, want)
+	}
+}
+
+func TestListModels_BadKey(t *testing.T) {
+	fake := fakeopenai.New(fakeopenai.Script{})
+	fake.APIKey = testAPIKey
+	server := fake.Start()
+	defer server.Close()
+
+	s := NewOpenAIService(server.URL+"/v1", "sk-wrong")
+	if _, err := s.ListModels(context.Background()); err == nil {
+		t.Error("ListModels() with the wrong key succeeded, want an error")
 	}
+}
 
-	s := NewOpenAIService(baseURL, apiKey)
+func TestSendPrompt(t *testing.T) {
+	s, fake := startFake(t, fakeopenai.Script{Turns: []fakeopenai.Turn{
+		{Content: json.RawMessage(`"This is a test"`)},
+	}})
 
-	models, err := s.ListModels(ctx)
+	resp, err := SendPrompt(context.Background(), s, "gpt-test", "Say this is a test")
 	if err != nil {
-		t.Errorf("ListModels() error = %v", err)
+		t.Fatalf("SendPrompt() error = %v", err)
 	}
-	if len(models) == 0 {
-		t.Errorf("ListModels() returned no models")
+	if resp != "This is a test" {
+		t.Errorf("SendPrompt() = %q, want %q", resp, "This is a test")
 	}
 
-	for _, model := range models {
-		fmt.Println(model)
+	requests := fake.Requests()
+	if len(requests) != 1 || requests[0].Model != "gpt-test" || requests[0].Messages[0].Text() != "Say this is a test" {
+		t.Errorf("SendPrompt() sent %+v, want the prompt to gpt-test", requests)
 	}
 }
 
-func TestSendPrompt(t *testing.T) {
-	err := godotenv.Load()
+func TestRecommendCocktail(t *testing.T) {
+	s, fake := startFake(t, fakeopenai.Script{Turns: []fakeopenai.Turn{
+		{ToolCalls: []fakeopenai.ToolCall{{Name: "list_bottles"}, {Name: "list_fresh_ingredients"}}},
+		{Content: json.RawMessage(recommendationJSON)},
+	}})
+	repo := setupTestRepository(t)
+
+	resp, err := RecommendCocktail(context.Background(), s, repo, "gpt-test", RecommendDefault)
 	if err != nil {
-		t.Log("No .env file found or error loading .env file")
+		t.Fatalf("RecommendCocktail() error = %v", err)
 	}
-	ctx := context.Background()
-	baseURL := os.Getenv("OPENAI_BASE_URL")
-	apiKey := os.Getenv("OPENAI_API_KEY")
-	if baseURL == "" || apiKey == "" {
-		t.Fatal("OPENAI_BASE_URL or OPENAI_API_KEY environment variable not set")
+	if resp == nil || len(resp.Cocktails) != 1 || resp.Cocktails[0].Name != "Gin Rickey" || len(resp.Cocktails[0].Ingredients) != 2 {
+		t.Fatalf("RecommendCocktail() = %+v, want the Gin Rickey", resp)
 	}
 
-	s := NewOpenAIService(baseURL, apiKey)
+	requests := fake.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("RecommendCocktail() made %d requests, want 2", len(requests))
+	}
 
-	resp, err := s.SendPrompt(ctx, os.Getenv("OPENAI_DEFAULT_MODEL"), "Say this is a test")
-	if err != nil {
-		t.Errorf("SendPrompt() error = %v", err)
+	first := requests[0]
+	if first.Messages[0].Text() != RecommendCocktailPrompt {
+		t.Errorf("first request prompt = %q, want RecommendCocktailPrompt", first.Messages[0].Text())
 	}
-	if resp == "" {
-		t.Errorf("SendPrompt() returned empty response")
+	for _, tool := range []string{"list_bottles", "list_fresh_ingredients", "list_mixers", "list_expiring_items"} {
+		if !slices.Contains(first.ToolNames(), tool) {
+			t.Errorf("first request tools = %v, missing %s", first.ToolNames(), tool)
+		}
+	}
+	if first.ResponseFormat != nil {
+		t.Errorf("first request asked for response format %+v, want none", first.ResponseFormat)
+	}
+
+	second := requests[1]
+	if format := second.ResponseFormat; format == nil || format.Type != "json_schema" || format.JSONSchema == nil || format.JSONSchema.Name != "cocktail_recommendations" {
+		t.Errorf("second request response format = %+v, want the cocktail_recommendations schema", format)
 	}
 
-	fmt.Println(resp)
+	results := map[string]string{}
+	for _, message := range second.Messages {
+		if message.Role == "tool" {
+			results[message.ToolCallID] = message.Text()
+		}
+	}
+	if len(results) != 2 {
+		t.Fatalf("second request has %d tool results, want 2", len(results))
+	}
+	if bottles := results["call_1_1"]; !strings.Contains(bottles, "Hendricks Gin") {
+		t.Errorf("list_bottles result = %s, want the bottles", bottles)
+	}
+	if fresh := results["call_1_2"]; !strings.Contains(fresh, "Lime Juice") || strings.Contains(fresh, "Hendricks") {
+		t.Errorf("list_fresh_ingredients result = %s, want the fresh items", fresh)
+	}
 }
 
-func TestRecommendCocktail(t *testing.T) {
-	err := godotenv.Load()
+func TestRecommendCocktail_UseExpiring(t *testing.T) {
+	s, fake := startFake(t, fakeopenai.Script{Turns: []fakeopenai.Turn{
+		{ToolCalls: []fakeopenai.ToolCall{{Name: "list_expiring_items"}}},
+		{Content: json.RawMessage(recommendationJSON)},
+	}})
+	repo := setupTestRepository(t)
+
+	if _, err := RecommendCocktail(context.Background(), s, repo, "gpt-test", RecommendUseExpiring); err != nil {
+		t.Fatalf("RecommendCocktail() error = %v", err)
+	}
+
+	requests := fake.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("RecommendCocktail() made %d requests, want 2", len(requests))
+	}
+	if prompt := requests[0].Messages[0].Text(); prompt != UseExpiringPrompt {
+		t.Errorf("prompt = %q, want UseExpiringPrompt", prompt)
+	}
+	last := requests[1].Messages[len(requests[1].Messages)-1]
+	if last.Role != "tool" || !json.Valid([]byte(last.Text())) {
+		t.Errorf("list_expiring_items result = %+v, want a JSON tool result", last)
+	}
+}
+
+func TestRecommendCocktail_ProviderError(t *testing.T) {
+	s, _ := startFake(t, fakeopenai.Script{Turns: []fakeopenai.Turn{
+		{Status: 400, Error: "model not found"},
+	}})
+	repo := setupTestRepository(t)
+
+	_, err := RecommendCocktail(context.Background(), s, repo, "gpt-missing", RecommendDefault)
+	var providerErr *ProviderError
+	if !errors.As(err, &providerErr) || providerErr.Round != 1 || !strings.Contains(err.Error(), "model not found") {
+		t.Errorf("RecommendCocktail() error = %v, want the provider's error from the first request", err)
+	}
+}
+
+func TestRecommendCocktail_UnknownTool(t *testing.T) {
+	s, fake := startFake(t, fakeopenai.Script{Turns: []fakeopenai.Turn{
+		{ToolCalls: []fakeopenai.ToolCall{{Name: "drop_tables"}}},
+		{Content: json.RawMessage(recommendationJSON)},
+	}})
+	repo := setupTestRepository(t)
+
+	if _, err := RecommendCocktail(context.Background(), s, repo, "gpt-test", RecommendDefault); err != nil {
+		t.Fatalf("RecommendCocktail() error = %v", err)
+	}
+
+	requests := fake.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("RecommendCocktail() made %d requests, want 2", len(requests))
+	}
+	last := requests[1].Messages[len(requests[1].Messages)-1]
+	if last.Role != "tool" || !strings.Contains(last.Text(), "no tool named") {
+		t.Errorf("drop_tables result = %+v, want an error for the model", last)
+	}
+}
+
+func TestRecommendCocktail_MultipleRounds(t *testing.T) {
+	s, fake := startFake(t, fakeopenai.Script{Turns: []fakeopenai.Turn{
+		{ToolCalls: []fakeopenai.ToolCall{{Name: "list_bottles"}}},
+		{ToolCalls: []fakeopenai.ToolCall{{Name: "list_mixers"}}},
+		{Content: json.RawMessage(recommendationJSON)},
+	}})
+	repo := setupTestRepository(t)
+
+	resp, err := RecommendCocktail(context.Background(), s, repo, "gpt-test", RecommendDefault)
 	if err != nil {
-		t.Log("No .env file found or error loading .env file")
+		t.Fatalf("RecommendCocktail() error = %v", err)
 	}
-	ctx := context.Background()
-	baseURL := os.Getenv("OPENAI_BASE_URL")
-	apiKey := os.Getenv("OPENAI_API_KEY")
-	if baseURL == "" || apiKey == "" {
-		t.Fatal("OPENAI_BASE_URL or OPENAI_API_KEY environment variable not set")
+	if len(resp.Cocktails) != 1 {
+		t.Errorf("RecommendCocktail() = %+v, want the Gin Rickey", resp)
 	}
 
-	s := NewOpenAIService(baseURL, apiKey)
-	r := setupTestRepository(t)
+	requests := fake.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("RecommendCocktail() made %d requests, want 3", len(requests))
+	}
+	var results []string
+	for _, message := range requests[2].Messages {
+		if message.Role == "tool" {
+			results = append(results, message.ToolCallID)
+		}
+	}
+	if want := []string{"call_1_1", "call_2_1"}; !slices.Equal(results, want) {
+		t.Errorf("last request tool results = %v, want %v", results, want)
+	}
+}
+
+func TestRecommendCocktail_NoToolCalls(t *testing.T) {
+	s, fake := startFake(t, fakeopenai.Script{Turns: []fakeopenai.Turn{
+		{Content: json.RawMessage(`"How about a Gin Rickey?"`)},
+		{Content: json.RawMessage(recommendationJSON)},
+	}})
+	repo := setupTestRepository(t)
 
-	resp, err := s.RecommendCocktail(ctx, r, os.Getenv("OPENAI_DEFAULT_MODEL"))
+	resp, err := RecommendCocktail(context.Background(), s, repo, "gpt-test", RecommendDefault)
 	if err != nil {
-		t.Errorf("RecommendCocktail() error = %v", err)
+		t.Fatalf("RecommendCocktail() error = %v", err)
 	}
-	if resp == nil {
-		t.Errorf("RecommendCocktail() returned empty response")
+	if resp == nil || len(resp.Cocktails) != 1 {
+		t.Errorf("RecommendCocktail() = %+v, want the Gin Rickey", resp)
 	}
 
-	fmt.Println(resp)
+	requests := fake.Requests()
+	if len(requests) != 2 || requests[1].ResponseFormat == nil || len(requests[1].Messages) != 1 {
+		t.Errorf("RecommendCocktail() sent %+v, want the prompt again with the schema", requests)
+	}
+}
+
+func TestRecommendCocktail_TooManyRounds(t *testing.T) {
+	s, fake := startFake(t, fakeopenai.Script{Loop: true, Turns: []fakeopenai.Turn{
+		{ToolCalls: []fakeopenai.ToolCall{{Name: "list_bottles"}}},
+	}})
+	repo := setupTestRepository(t)
+
+	_, err := RecommendCocktail(context.Background(), s, repo, "gpt-test", RecommendDefault)
+	if !errors.Is(err, ErrTooManyRounds) {
+		t.Errorf("RecommendCocktail() error = %v, want ErrTooManyRounds", err)
+	}
+	if n := len(fake.Requests()); n != MaxRecommendRounds {
+		t.Errorf("RecommendCocktail() made %d requests, want %d", n, MaxRecommendRounds)
+	}
+}
+
+func TestRecommendCocktail_InvalidAnswer(t *testing.T) {
+	s, _ := startFake(t, fakeopenai.Script{Turns: []fakeopenai.Turn{
+		{ToolCalls: []fakeopenai.ToolCall{{Name: "list_bottles"}}},
+		{Content: json.RawMessage(`"Sorry, I can't help with that."`)},
+	}})
+	repo := setupTestRepository(t)
+
+	resp, err := RecommendCocktail(context.Background(), s, repo, "gpt-test", RecommendDefault)
+	var answerErr *AnswerError
+	if !errors.As(err, &answerErr) || answerErr.Content != "Sorry, I can't help with that." {
+		t.Errorf("RecommendCocktail() = %+v, %v; want an AnswerError", resp, err)
+	}
 }
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// Provider is an LLM backend that can chat with tools and answer in JSON.
+// OpenAIService speaks the OpenAI API and OllamaService speaks Ollama's own.
+type Provider interface {
+	// ListModels returns the IDs of the models the backend offers.
+	ListModels(ctx context.Context) ([]string, error)
+	// Chat sends a conversation and returns the model's reply, which either
+	// calls tools or answers.
+	Chat(ctx context.Context, req ChatRequest) (*Message, error)
+	// Close cleans up any resources used by the provider.
+	Close() error
+}
+
+// ProviderKind names the protocol a provider speaks.
+type ProviderKind string
+
+const (
+	ProviderOpenAI ProviderKind = "openai"
+	ProviderOllama ProviderKind = "ollama"
+)
+
+var (
+	ErrUnknownProvider = errors.New("provider must be openai or ollama")
+	// ErrEmptyReply means the provider answered without a message.
+	ErrEmptyReply = errors.New("model returned no reply")
+)
+
+// ParseProviderKind reads a provider kind, where empty means openai.
+func ParseProviderKind(s string) (ProviderKind, error) {
+	switch kind := ProviderKind(s); kind {
+	case "":
+		return ProviderOpenAI, nil
+	case ProviderOpenAI, ProviderOllama:
+		return kind, nil
+	}
+	return "", ErrUnknownProvider
+}
+
+// NewProvider creates a provider of the given kind.
+func NewProvider(kind ProviderKind, baseURL, apiKey string) (Provider, error) {
+	switch kind {
+	case ProviderOpenAI, "":
+		return NewOpenAIService(baseURL, apiKey), nil
+	case ProviderOllama:
+		return NewOllamaService(baseURL, apiKey), nil
+	}
+	return nil, ErrUnknownProvider
+}
+
+// Role is who wrote a message.
+type Role string
+
+const (
+	RoleSystem    Role = "system"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+	RoleTool      Role = "tool"
+)
+
+// Message is one turn of a chat.
+type Message struct {
+	Role    Role
+	Content string
+	// ToolCalls are the calls an assistant message asks for.
+	ToolCalls []ToolCall
+	// ToolCallID and ToolName say which call a tool message answers.
+	ToolCallID string
+	ToolName   string
+}
+
+// ToolCall is a model's request to run one of the offered tools.
+type ToolCall struct {
+	// ID matches the call to its result. Providers that don't give calls IDs
+	// get generated ones.
+	ID        string
+	Name      string
+	Arguments json.RawMessage
+}
+
+// Tool is a function the model may call.
+type Tool struct {
+	Name        string
+	Description string
+	// Parameters is the JSON schema of the arguments; nil takes none.
+	Parameters map[string]any
+}
+
+// Schema asks for an answer that is JSON matching a schema.
+type Schema struct {
+	Name        string
+	Description string
+	Schema      any
+}
+
+// ChatRequest is a conversation to send to a model.
+type ChatRequest struct {
+	Model    string
+	Messages []Message
+	Tools    []Tool
+	// Schema, if set, asks for the answer as JSON matching it.
+	Schema *Schema
+}
+
+// UserMessage makes a message from the user.
+func UserMessage(content string) Message {
+	return Message{Role: RoleUser, Content: content}
+}
+
+// ToolResult makes the message answering a tool call.
+func ToolResult(call ToolCall, content string) Message {
+	return Message{Role: RoleTool, Content: content, ToolCallID: call.ID, ToolName: call.Name}
+}
+
+// SendPrompt sends a single prompt and returns the answer's text.
+func SendPrompt(ctx context.Context, p Provider, model, prompt string) (string, error) {
+	reply, err := p.Chat(ctx, ChatRequest{Model: model, Messages: []Message{UserMessage(prompt)}})
+	if err != nil {
+		return "", err
+	}
+	return reply.Content, nil
+}
+
+// arguments returns a tool call's arguments, or an empty object if it has
+// none.
+func (c ToolCall) arguments() json.RawMessage {
+	if len(c.Arguments) == 0 {
+		return json.RawMessage("{}")
+	}
+	return c.Arguments
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+	"github.com/nguyenjessev/liquor-locker/internal/repository"
+)
+
+func GenerateSchema[T any]() any {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties: false,
+		DoNotReference:            true,
+	}
+
+	var v T
+
+	schema := reflector.Reflect(v)
+	return schema
+}
+
+var CocktailRecommendationResponseSchema = GenerateSchema[models.CocktailRecommendationResponse]()
+
+// RecommendCocktailPrompt is the user prompt sent by RecommendCocktail.
+const RecommendCocktailPrompt = "Recommend a cocktail based on the user's inventory, including bottles, fresh ingredients, mixers, bitters, syrups, and garnishes. Prefer using open or prepared ingredients if possible, but you can use sealed ingredients if necessary. You may also assume that the user has common ingredients on hand, such as water and ice."
+
+// UseExpiringPrompt is the user prompt sent by RecommendCocktail in
+// RecommendUseExpiring mode.
+const UseExpiringPrompt = "Recommend a cocktail based on the user's inventory, including bottles, fresh ingredients, mixers, bitters, syrups, and garnishes. First call list_expiring_items: the user wants to use up fresh ingredients, opened mixers and syrups before they go bad, so build the cocktail around as many of those items as possible, starting with the ones that expire soonest. Avoid items whose status is expired. You may also assume that the user has common ingredients on hand, such as water and ice."
+
+// RecommendMode selects the prompt RecommendCocktail sends.
+type RecommendMode string
+
+const (
+	RecommendDefault RecommendMode = ""
+	// RecommendUseExpiring asks for a cocktail that uses up items nearing
+	// expiry first.
+	RecommendUseExpiring RecommendMode = "use_expiring"
+)
+
+// Prompt returns the user prompt for the mode.
+func (m RecommendMode) Prompt() string {
+	if m == RecommendUseExpiring {
+		return UseExpiringPrompt
+	}
+	return RecommendCocktailPrompt
+}
+
+// MaxRecommendRounds caps the requests RecommendCocktail makes to the model,
+// so a model that keeps calling tools can't run up the bill forever.
+const MaxRecommendRounds = 6
+
+// ErrTooManyRounds means the model was still calling tools after
+// MaxRecommendRounds requests.
+var ErrTooManyRounds = errors.New("model didn't answer within the round limit")
+
+// ProviderError is a failed request to the model.
+type ProviderError struct {
+	// Round is the number of the request, starting at 1.
+	Round int
+	Err   error
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("request %d to the model failed: %v", e.Round, e.Err)
+}
+
+func (e *ProviderError) Unwrap() error { return e.Err }
+
+// ToolError is a tool call that couldn't be answered.
+type ToolError struct {
+	Tool string
+	Err  error
+}
+
+func (e *ToolError) Error() string {
+	return fmt.Sprintf("tool %s: %v", e.Tool, e.Err)
+}
+
+func (e *ToolError) Unwrap() error { return e.Err }
+
+// AnswerError is a final answer that isn't a recommendation matching the
+// schema.
+type AnswerError struct {
+	// Content is what the model answered.
+	Content string
+	Err     error
+}
+
+func (e *AnswerError) Error() string {
+	return fmt.Sprintf("model's answer isn't a valid recommendation: %v", e.Err)
+}
+
+func (e *AnswerError) Unwrap() error { return e.Err }
+
+var recommendationSchema = &Schema{
+	Name:        "cocktail_recommendations",
+	Description: "A list of recommended cocktails",
+	Schema:      CocktailRecommendationResponseSchema,
+}
+
+// RecommendCocktail asks the model for cocktails it can make from the
+// inventory. The model looks the inventory and saved recipes up with
+// InventoryTools for as many rounds as it likes, up to MaxRecommendRounds
+// requests in all, and then answers in the recommendation schema.
+//
+// The first request leaves the schema out so the model looks before it
+// answers. Once it has called a tool, or if it answers without calling any,
+// the schema is asked for and the next answer without tool calls is final.
+func RecommendCocktail(ctx context.Context, p Provider, repo *repository.Repository, model string, mode RecommendMode) (*models.CocktailRecommendationResponse, error) {
+	tools := InventoryTools(repo)
+	req := ChatRequest{
+		Model:    model,
+		Messages: []Message{UserMessage(mode.Prompt())},
+		Tools:    tools.Tools(),
+	}
+
+	for round := 1; round <= MaxRecommendRounds; round++ {
+		reply, err := p.Chat(ctx, req)
+		if err != nil {
+			return nil, &ProviderError{Round: round, Err: err}
+		}
+
+		if len(reply.ToolCalls) == 0 {
+			if req.Schema != nil {
+				return parseRecommendation(reply.Content)
+			}
+			// The model answered without the schema. Ask again with it,
+			// leaving out the free-form answer.
+			req.Schema = recommendationSchema
+			continue
+		}
+
+		req.Messages = append(req.Messages, *reply)
+		for _, toolCall := range reply.ToolCalls {
+			result, err := tools.Call(ctx, toolCall)
+			if err != nil {
+				return nil, &ToolError{Tool: toolCall.Name, Err: err}
+			}
+			req.Messages = append(req.Messages, ToolResult(toolCall, result))
+		}
+		req.Schema = recommendationSchema
+	}
+
+	return nil, ErrTooManyRounds
+}
+
+// parseRecommendation reads the model's final answer.
+func parseRecommendation(content string) (*models.CocktailRecommendationResponse, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, &AnswerError{Content: content, Err: ErrEmptyReply}
+	}
+
+	var cocktailRecommendations models.CocktailRecommendationResponse
+	if err := json.Unmarshal([]byte(content), &cocktailRecommendations); err != nil {
+		return nil, &AnswerError{Content: content, Err: err}
+	}
+	return &cocktailRecommendations, nil
+}
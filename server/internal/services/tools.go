@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrBadToolCall marks a tool call the model can fix, such as one with bad
+// arguments or for an item that doesn't exist. ToolRegistry.Call sends the
+// error back to the model as the tool's result instead of failing.
+var ErrBadToolCall = errors.New("bad tool call")
+
+// badCall makes an ErrBadToolCall with a message for the model.
+func badCall(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrBadToolCall, fmt.Sprintf(format, args...))
+}
+
+// ToolFunc answers a tool call from its JSON arguments. The result is sent to
+// the model as JSON.
+type ToolFunc func(ctx context.Context, args json.RawMessage) (any, error)
+
+// ToolRegistry is a set of tools to offer a model, and the functions that
+// answer them.
+type ToolRegistry struct {
+	tools []Tool
+	funcs map[string]ToolFunc
+}
+
+func NewToolRegistry() *ToolRegistry {
+	return &ToolRegistry{funcs: make(map[string]ToolFunc)}
+}
+
+// Register adds a tool. It panics if a tool with the same name is already
+// registered.
+func (r *ToolRegistry) Register(tool Tool, fn ToolFunc) {
+	if _, ok := r.funcs[tool.Name]; ok {
+		panic("services: tool registered twice: " + tool.Name)
+	}
+	r.tools = append(r.tools, tool)
+	r.funcs[tool.Name] = fn
+}
+
+// Tools returns the registered tools in the order they were registered.
+func (r *ToolRegistry) Tools() []Tool {
+	return r.tools
+}
+
+// Call answers a tool call with its result as JSON. A call that fails with
+// ErrBadToolCall, or a call to a tool that isn't registered, is answered with
+// {"error": "..."} so the model can try again.
+func (r *ToolRegistry) Call(ctx context.Context, call ToolCall) (string, error) {
+	var result any
+	var err error
+	if fn, ok := r.funcs[call.Name]; ok {
+		result, err = fn(ctx, call.arguments())
+	} else {
+		err = badCall("there is no tool named %q", call.Name)
+	}
+	if errors.Is(err, ErrBadToolCall) {
+		result, err = map[string]string{"error": err.Error()}, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(resultJSON), nil
+}
+
+// decodeArgs reads a tool call's arguments into v.
+func decodeArgs(args json.RawMessage, v any) error {
+	if err := json.Unmarshal(args, v); err != nil {
+		return badCall("invalid arguments: %v", err)
+	}
+	return nil
+}
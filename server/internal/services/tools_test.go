@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+// callTool runs a tool and decodes its result into out.
+func callTool(t *testing.T, tools *ToolRegistry, name, args string, out any) {
+	t.Helper()
+
+	result, err := tools.Call(context.Background(), ToolCall{ID: "call_1", Name: name, Arguments: json.RawMessage(args)})
+	if err != nil {
+		t.Fatalf("Call(%s, %s) error = %v", name, args, err)
+	}
+	if err := json.Unmarshal([]byte(result), out); err != nil {
+		t.Fatalf("Call(%s, %s) = %s, not JSON: %v", name, args, result, err)
+	}
+}
+
+func TestToolRegistry(t *testing.T) {
+	tools := NewToolRegistry()
+	tools.Register(Tool{Name: "echo"}, func(ctx context.Context, args json.RawMessage) (any, error) {
+		var in struct {
+			Text string `json:"text"`
+		}
+		if err := decodeArgs(args, &in); err != nil {
+			return nil, err
+		}
+		if in.Text == "" {
+			return nil, badCall("text is required")
+		}
+		return in, nil
+	})
+	tools.Register(Tool{Name: "broken"}, func(ctx context.Context, args json.RawMessage) (any, error) {
+		return nil, errors.New("database is locked")
+	})
+
+	tests := []struct {
+		name string
+		call ToolCall
+		want string
+	}{
+		{"result", ToolCall{Name: "echo", Arguments: json.RawMessage(`{"text": "hi"}`)}, `{"text":"hi"}`},
+		{"bad call", ToolCall{Name: "echo"}, `{"error":"bad tool call: text is required"}`},
+		{"bad arguments", ToolCall{Name: "echo", Arguments: json.RawMessage(`{"text": 1}`)}, `{"error":"bad tool call: invalid arguments`},
+		{"failure", ToolCall{Name: "broken"}, ""},
+		{"unknown tool", ToolCall{Name: "drop_tables"}, `{"error":"bad tool call: there is no tool named \"drop_tables\""}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tools.Call(context.Background(), tt.call)
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("Call() = %s, nil; want an error", got)
+				}
+				return
+			}
+			if err != nil || !strings.HasPrefix(got, tt.want) {
+				t.Errorf("Call() = %s, %v; want %s", got, err, tt.want)
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Register() of a duplicate tool didn't panic")
+		}
+	}()
+	tools.Register(Tool{Name: "echo"}, nil)
+}
+
+func TestInventoryTools_SearchAndDetails(t *testing.T) {
+	repo := setupTestRepository(t)
+	ctx := context.Background()
+	category := "Rye Whiskey"
+	rye, err := repo.CreateBottle(ctx, &models.Bottle{Name: "Rittenhouse", Category: &category})
+	if err != nil {
+		t.Fatalf("Failed to insert test bottle: %v", err)
+	}
+	tools := InventoryTools(repo)
+
+	var hits []models.SearchHit
+	callTool(t, tools, "search_inventory", `{"query": "rye"}`, &hits)
+	if len(hits) != 1 || hits[0].ID != rye.ID || hits[0].Kind != "bottle" {
+		t.Errorf("search_inventory(rye) = %+v, want the Rittenhouse by its category", hits)
+	}
+
+	callTool(t, tools, "search_inventory", `{"query": "l", "kind": "fresh", "limit": 1}`, &hits)
+	if len(hits) != 1 || hits[0].Kind != "fresh" {
+		t.Errorf("search_inventory(l, fresh, 1) = %+v, want one fresh item", hits)
+	}
+
+	var bottle models.Bottle
+	callTool(t, tools, "get_item_details", fmt.Sprintf(`{"kind": "bottle", "id": %d}`, rye.ID), &bottle)
+	if bottle.Name != "Rittenhouse" || bottle.Category == nil || *bottle.Category != category {
+		t.Errorf("get_item_details(bottle) = %+v, want the Rittenhouse", bottle)
+	}
+
+	var failure map[string]string
+	callTool(t, tools, "get_item_details", `{"kind": "mixer", "id": 999}`, &failure)
+	if !strings.Contains(failure["error"], "no mixer with ID 999") {
+		t.Errorf("get_item_details(missing mixer) = %v, want an error for the model", failure)
+	}
+
+	failure = nil
+	callTool(t, tools, "search_inventory", `{"query": "!!"}`, &failure)
+	if failure["error"] == "" {
+		t.Errorf("search_inventory(!!) = %v, want an error for the model", failure)
+	}
+}
+
+func TestInventoryTools_Recipes(t *testing.T) {
+	repo := setupTestRepository(t)
+	ctx := context.Background()
+	for _, cocktail := range []*models.Cocktail{
+		{Name: "Negroni", Ingredients: []models.Ingredient{{Name: "Gin", Quantity: "1 oz"}, {Name: "Campari", Quantity: "1 oz"}}},
+		{Name: "Daiquiri", Ingredients: []models.Ingredient{{Name: "Rum", Quantity: "2 oz"}, {Name: "Lime Juice", Quantity: "1 oz"}},
+			Steps: []models.Step{{Order: 1, Text: "Shake with ice and strain."}}},
+	} {
+		if _, err := repo.CreateCocktail(ctx, cocktail); err != nil {
+			t.Fatalf("Failed to insert test cocktail: %v", err)
+		}
+	}
+	tools := InventoryTools(repo)
+
+	var recipes recipeList
+	callTool(t, tools, "list_saved_recipes", `{}`, &recipes)
+	if len(recipes.Recipes) != 2 || recipes.Recipes[0].Name != "Daiquiri" || len(recipes.Recipes[0].Ingredients) != 2 {
+		t.Fatalf("list_saved_recipes() = %+v, want both recipes by name", recipes)
+	}
+	if recipes.Total != 2 || recipes.Truncated {
+		t.Errorf("list_saved_recipes() total = %d, truncated = %v; want 2 and false", recipes.Total, recipes.Truncated)
+	}
+
+	callTool(t, tools, "list_saved_recipes", `{"query": "campari"}`, &recipes)
+	if len(recipes.Recipes) != 1 || recipes.Recipes[0].Name != "Negroni" || recipes.Total != 1 {
+		t.Errorf("list_saved_recipes(campari) = %+v, want the Negroni", recipes)
+	}
+
+	callTool(t, tools, "list_saved_recipes", `{"limit": 1}`, &recipes)
+	if len(recipes.Recipes) != 1 || recipes.Total != 2 || !recipes.Truncated {
+		t.Errorf("list_saved_recipes(limit 1) = %+v, want one of two recipes, truncated", recipes)
+	}
+
+	var recipe models.Cocktail
+	daiquiri := recipes.Recipes[0]
+	callTool(t, tools, "get_recipe", fmt.Sprintf(`{"id": %d}`, daiquiri.ID), &recipe)
+	if recipe.Name != "Daiquiri" || len(recipe.Steps) != 1 {
+		t.Errorf("get_recipe() = %+v, want the Daiquiri with its steps", recipe)
+	}
+
+	var failure map[string]string
+	callTool(t, tools, "get_recipe", `{"id": 999}`, &failure)
+	if !strings.Contains(failure["error"], "no saved recipe") {
+		t.Errorf("get_recipe(999) = %v, want an error for the model", failure)
+	}
+}
@@ -0,0 +1,166 @@
+// Package shopping builds consolidated shopping lists from the ingredients
+// saved recipes need but the inventory lacks, and renders them as text or CSV.
+package shopping
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/nguyenjessev/liquor-locker/internal/matcher"
+	"github.com/nguyenjessev/liquor-locker/internal/measure"
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+// need collects every recipe line that asks for the same missing ingredient.
+type need struct {
+	name       string
+	quantities []string
+	cocktails  []string
+}
+
+// Build lists the ingredients of cocktails that m cannot find in the
+// inventory. Lines that m says ask for the same ingredient, such as "Lime
+// Juice" and "Fresh Lime Juice", are merged into one item under the shorter
+// name, with their quantities added up where the units allow.
+func Build(cocktails []*models.Cocktail, m *matcher.Matcher) []models.ShoppingListItem {
+	var needs []*need
+	for _, cocktail := range cocktails {
+		for _, ingredient := range cocktail.Ingredients {
+			if m.Has(ingredient.Name) {
+				continue
+			}
+
+			n := findNeed(needs, m, ingredient.Name)
+			if n == nil {
+				n = &need{name: ingredient.Name}
+				needs = append(needs, n)
+			} else if len(ingredient.Name) < len(n.name) {
+				n.name = ingredient.Name
+			}
+
+			n.quantities = append(n.quantities, ingredient.Quantity)
+			if !contains(n.cocktails, cocktail.Name) {
+				n.cocktails = append(n.cocktails, cocktail.Name)
+			}
+		}
+	}
+
+	items := make([]models.ShoppingListItem, 0, len(needs))
+	for _, n := range needs {
+		items = append(items, models.ShoppingListItem{
+			Name:      n.name,
+			Quantity:  Total(n.quantities),
+			NeededFor: strings.Join(n.cocktails, ", "),
+		})
+	}
+	return items
+}
+
+func findNeed(needs []*need, m *matcher.Matcher, name string) *need {
+	for _, n := range needs {
+		if m.SameIngredient(n.name, name) {
+			return n
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Total adds up recipe quantities. Volumes are summed in the unit of the
+// first one and counts of the same thing are summed; anything else is listed
+// as written, joined with " + ".
+func Total(quantities []string) string {
+	var parsed []measure.Quantity
+	var written []string
+	for _, quantity := range quantities {
+		quantity = strings.TrimSpace(quantity)
+		if quantity == "" {
+			continue
+		}
+		if !contains(written, quantity) {
+			written = append(written, quantity)
+		}
+		if q, err := measure.Parse(quantity); err == nil {
+			parsed = append(parsed, q)
+		}
+	}
+
+	if len(parsed) == 0 || len(parsed) != countNonEmpty(quantities) {
+		return strings.Join(written, " + ")
+	}
+
+	total := parsed[0]
+	for _, q := range parsed[1:] {
+		if total.IsVolume() && q.IsVolume() {
+			converted, _ := measure.Convert(q, total.Unit)
+			total.Amount += converted.Amount
+			continue
+		}
+		if q.Unit == total.Unit && q.Item == total.Item {
+			total.Amount += q.Amount
+			continue
+		}
+		return strings.Join(written, " + ")
+	}
+
+	return total.String()
+}
+
+func countNonEmpty(values []string) int {
+	count := 0
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// Text renders a list as a plain-text checklist.
+func Text(list *models.ShoppingList) string {
+	var b strings.Builder
+	b.WriteString(list.Name)
+	b.WriteString("\n\n")
+	for _, item := range list.Items {
+		box := "[ ]"
+		if item.Checked {
+			box = "[x]"
+		}
+		fmt.Fprintf(&b, "%s %s", box, item.Name)
+		if item.Quantity != "" {
+			fmt.Fprintf(&b, ", %s", item.Quantity)
+		}
+		if item.NeededFor != "" {
+			fmt.Fprintf(&b, " (for %s)", item.NeededFor)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// WriteCSV renders a list as CSV with a header row.
+func WriteCSV(w io.Writer, list *models.ShoppingList) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"name", "quantity", "needed_for", "checked"}); err != nil {
+		return err
+	}
+	for _, item := range list.Items {
+		record := []string{item.Name, item.Quantity, item.NeededFor, strconv.FormatBool(item.Checked)}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
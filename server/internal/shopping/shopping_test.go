@@ -0,0 +1,130 @@
+package shopping
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/nguyenjessev/liquor-locker/internal/matcher"
+	"github.com/nguyenjessev/liquor-locker/internal/models"
+)
+
+func TestBuild(t *testing.T) {
+	cocktails := []*models.Cocktail{
+		{
+			Name: "Gimlet",
+			Ingredients: []models.Ingredient{
+				{Name: "Gin", Quantity: "2 oz"},
+				{Name: "Lime Juice", Quantity: "3/4 oz"},
+				{Name: "Simple Syrup", Quantity: "3/4 oz"},
+				{Name: "Ice", Quantity: "1 cup"},
+			},
+		},
+		{
+			Name: "Daiquiri",
+			Ingredients: []models.Ingredient{
+				{Name: "White Rum", Quantity: "2 oz"},
+				{Name: "Fresh Lime Juice", Quantity: "30 ml"},
+				{Name: "Simple Syrup", Quantity: "to taste"},
+			},
+		},
+	}
+	m := matcher.New([]matcher.Item{{Name: "Beefeater Gin"}})
+
+	items := Build(cocktails, m)
+
+	want := []models.ShoppingListItem{
+		{Name: "Lime Juice", Quantity: "1.76 oz", NeededFor: "Gimlet, Daiquiri"},
+		{Name: "Simple Syrup", Quantity: "3/4 oz + to taste", NeededFor: "Gimlet, Daiquiri"},
+		{Name: "White Rum", Quantity: "2 oz", NeededFor: "Daiquiri"},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("Build() = %+v, want %d items", items, len(want))
+	}
+	for i := range want {
+		if items[i] != want[i] {
+			t.Errorf("Build()[%d] = %+v, want %+v", i, items[i], want[i])
+		}
+	}
+}
+
+func TestBuild_KeepsDifferentIngredientsApart(t *testing.T) {
+	cocktails := []*models.Cocktail{
+		{
+			Name: "Sloe Gin Fizz",
+			Ingredients: []models.Ingredient{
+				{Name: "Gin", Quantity: "1 oz"},
+				{Name: "Sloe Gin", Quantity: "1 oz"},
+				{Name: "Lime Juice", Quantity: "1/2 oz"},
+			},
+		},
+		{
+			Name: "Margarita",
+			Ingredients: []models.Ingredient{
+				{Name: "Orange Liqueur", Quantity: "1 oz"},
+				{Name: "Orange", Quantity: "1 peel"},
+				{Name: "Lime", Quantity: "1 wedge"},
+			},
+		},
+	}
+
+	items := Build(cocktails, matcher.New(nil))
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	want := []string{"Gin", "Sloe Gin", "Lime Juice", "Orange Liqueur", "Orange", "Lime"}
+	if !slices.Equal(names, want) {
+		t.Errorf("Build() = %v, want %v", names, want)
+	}
+	if items[0].Quantity != "1 oz" {
+		t.Errorf("Build() gin = %q, want 1 oz", items[0].Quantity)
+	}
+}
+
+func TestTotal(t *testing.T) {
+	tests := []struct {
+		quantities []string
+		want       string
+	}{
+		{[]string{"1 oz", "1/2 oz"}, "1 1/2 oz"},
+		{[]string{"30 ml", "1 cl"}, "40 ml"},
+		{[]string{"2 dashes", "1 dash"}, "3 dashes"},
+		{[]string{"1 egg white", "1 egg white"}, "2 egg white"},
+		{[]string{"1 lime wheel", "1 oz"}, "1 lime wheel + 1 oz"},
+		{[]string{"to taste", "to taste"}, "to taste"},
+		{[]string{"", "2 oz"}, "2 oz"},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := Total(tt.quantities); got != tt.want {
+			t.Errorf("Total(%q) = %q, want %q", tt.quantities, got, tt.want)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	list := &models.ShoppingList{
+		Name: "Party",
+		Items: []models.ShoppingListItem{
+			{Name: "Campari", Quantity: "1 oz", NeededFor: "Negroni"},
+			{Name: "Limes, fresh", Checked: true},
+		},
+	}
+
+	wantText := "Party\n\n[ ] Campari, 1 oz (for Negroni)\n[x] Limes, fresh\n"
+	if got := Text(list); got != wantText {
+		t.Errorf("Text() = %q, want %q", got, wantText)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, list); err != nil {
+		t.Fatalf("WriteCSV() error = %v, want nil", err)
+	}
+	wantCSV := "name,quantity,needed_for,checked\nCampari,1 oz,Negroni,false\n\"Limes, fresh\",,,true\n"
+	if got := buf.String(); got != wantCSV {
+		t.Errorf("WriteCSV() = %q, want %q", got, wantCSV)
+	}
+}
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 
 	_ "github.com/nguyenjessev/liquor-locker/docs"
+	"github.com/nguyenjessev/liquor-locker/internal/backup"
 	"github.com/nguyenjessev/liquor-locker/internal/handlers"
 	"github.com/nguyenjessev/liquor-locker/internal/repository"
 )
@@ -20,7 +22,16 @@ func loggingMiddleware(next http.Handler) http.Handler {
 }
 
 func main() {
-	repo := repository.New()
+	dbConfig, err := repository.ConfigFromEnv()
+	if err != nil {
+		fmt.Println("Error reading database settings:", err)
+		return
+	}
+	repo, err := repository.New(dbConfig)
+	if err != nil {
+		fmt.Println("Error opening database:", err)
+		return
+	}
 	defer repo.CloseDB()
 
 	if err := repo.RunMigrations(); err != nil {
@@ -28,7 +39,15 @@ func main() {
 		return
 	}
 
-	server := handlers.NewServer(repo)
+	backupConfig, err := backup.ConfigFromEnv()
+	if err != nil {
+		fmt.Println("Error reading backup settings:", err)
+		return
+	}
+	backups := backup.NewManager(repo, backupConfig)
+	go backups.Schedule(context.Background())
+
+	server := handlers.NewServer(repo, backups)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -37,21 +56,78 @@ func main() {
 
 	fmt.Printf("Starting server on port %s\n", port)
 	fmt.Println("Available endpoints:")
-	fmt.Println("  GET /api/bottles - Get all bottles")
+	fmt.Println("  GET /api/bottles - Get bottles (filter by details, name, price, opened; sort; page with limit/cursor)")
 	fmt.Println("  POST /api/bottles - Create a new bottle")
 	fmt.Println("  GET /api/bottles/{id} - Get bottle by ID")
 	fmt.Println("  DELETE /api/bottles/{id} - Delete bottle by ID")
 	fmt.Println("  PUT /api/bottles/{id} - Update bottle by ID")
-	fmt.Println("  GET /api/fresh - Get all fresh items")
+	fmt.Println("  POST /api/bottles/{id}/pour - Pour from a bottle")
+	fmt.Println("  GET /api/bottles/{id}/pours - Get a bottle's pour history")
+	fmt.Println("  GET /api/fresh - Get fresh items (filter by name, price; sort; page with limit/cursor)")
 	fmt.Println("  POST /api/fresh - Create a new fresh item")
 	fmt.Println("  GET /api/fresh/{id} - Get fresh item by ID")
 	fmt.Println("  DELETE /api/fresh/{id} - Delete fresh item by ID")
 	fmt.Println("  PUT /api/fresh/{id} - Update fresh item by ID")
-	fmt.Println("  GET /api/mixers - Get all mixers")
+	fmt.Println("  GET /api/mixers - Get mixers (filter by name, price, opened; sort; page with limit/cursor)")
 	fmt.Println("  POST /api/mixers - Create a new mixer")
 	fmt.Println("  GET /api/mixers/{id} - Get mixer by ID")
 	fmt.Println("  DELETE /api/mixers/{id} - Delete mixer by ID")
 	fmt.Println("  PUT /api/mixers/{id} - Update mixer by ID")
+	fmt.Println("  POST /api/mixers/{id}/pour - Pour from a mixer")
+	fmt.Println("  GET /api/mixers/{id}/pours - Get a mixer's pour history")
+	fmt.Println("  GET /api/bitters - Get bitters (filter by name, price, opened; sort; page with limit/cursor)")
+	fmt.Println("  POST /api/bitters - Create bitters")
+	fmt.Println("  GET /api/bitters/{id} - Get bitters by ID")
+	fmt.Println("  DELETE /api/bitters/{id} - Delete bitters by ID")
+	fmt.Println("  PUT /api/bitters/{id} - Update bitters by ID")
+	fmt.Println("  GET /api/syrups - Get syrups (filter by name, price; sort; page with limit/cursor)")
+	fmt.Println("  POST /api/syrups - Create a new syrup")
+	fmt.Println("  GET /api/syrups/{id} - Get syrup by ID")
+	fmt.Println("  DELETE /api/syrups/{id} - Delete syrup by ID")
+	fmt.Println("  PUT /api/syrups/{id} - Update syrup by ID")
+	fmt.Println("  POST /api/syrups/{id}/pour - Pour from a syrup")
+	fmt.Println("  GET /api/syrups/{id}/pours - Get a syrup's pour history")
+	fmt.Println("  GET /api/garnishes - Get garnishes (filter by name, price; sort; page with limit/cursor)")
+	fmt.Println("  POST /api/garnishes - Create a new garnish")
+	fmt.Println("  GET /api/garnishes/{id} - Get garnish by ID")
+	fmt.Println("  DELETE /api/garnishes/{id} - Delete garnish by ID")
+	fmt.Println("  PUT /api/garnishes/{id} - Update garnish by ID")
+	fmt.Println("  GET /api/expiring - List fresh items and opened mixers about to go bad")
+	fmt.Println("  GET /api/search?q= - Search the inventory and recipes")
+	fmt.Println("  POST /api/import?kind= - Import inventory items in bulk from CSV or JSON")
+	fmt.Println("  GET /api/export - Download the whole database as a JSON archive")
+	fmt.Println("  POST /api/restore?mode= - Restore an archive, replacing or merging with the current data")
+	fmt.Println("  GET /api/admin/backups - List database backups")
+	fmt.Println("  POST /api/admin/backups - Back up the database now")
+	fmt.Println("  GET /api/cocktails - Get all cocktail recipes")
+	fmt.Println("  POST /api/cocktails - Create a new cocktail recipe")
+	fmt.Println("  GET /api/cocktails/{id} - Get cocktail recipe by ID")
+	fmt.Println("  DELETE /api/cocktails/{id} - Delete cocktail recipe by ID")
+	fmt.Println("  PUT /api/cocktails/{id} - Update cocktail recipe by ID")
+	fmt.Println("  GET /api/cocktails/makeable - List saved cocktails makeable from the inventory")
+	fmt.Println("  POST /api/cocktails/{id}/make - Record a made cocktail and deduct its ingredients")
+	fmt.Println("  GET /api/cocktails/{id}/history - List times a cocktail was made")
+	fmt.Println("  GET /api/cocktails/history - List every made cocktail")
+	fmt.Println("  GET /api/cocktails/{id}/batch?servings=N - Scale a cocktail recipe for a batch")
+	fmt.Println("  POST /api/cocktails/recommendation/save - Save a recommended cocktail as a recipe")
+	fmt.Println("  GET /api/ingredients - Get the ingredient catalog")
+	fmt.Println("  POST /api/ingredients - Create a catalog ingredient")
+	fmt.Println("  GET /api/ingredients/{id} - Get catalog ingredient by ID")
+	fmt.Println("  DELETE /api/ingredients/{id} - Delete catalog ingredient by ID")
+	fmt.Println("  POST /api/ingredients/{id}/aliases - Add an alias to a catalog ingredient")
+	fmt.Println("  GET /api/ingredients/resolve?name= - Resolve a name to a catalog ingredient")
+	fmt.Println("  PUT /api/ingredients/links - Link an inventory item to a catalog ingredient")
+	fmt.Println("  GET /api/shopping-lists - Get all shopping lists")
+	fmt.Println("  POST /api/shopping-lists - Create a shopping list from recipes")
+	fmt.Println("  GET /api/shopping-lists/{id}?format=json|text|csv - Get a shopping list")
+	fmt.Println("  DELETE /api/shopping-lists/{id} - Delete a shopping list")
+	fmt.Println("  PUT /api/shopping-lists/{id}/items/{itemId} - Check off a shopping list item")
+	fmt.Println("  POST /api/shopping-lists/{id}/items/{itemId}/convert - Add a bought item to the inventory")
+	fmt.Println("  GET /api/ai/profiles - Get AI provider profiles, the default first")
+	fmt.Println("  POST /api/ai/profiles - Create an AI provider profile")
+	fmt.Println("  GET /api/ai/profiles/{id} - Get AI provider profile by ID")
+	fmt.Println("  DELETE /api/ai/profiles/{id} - Delete AI provider profile by ID")
+	fmt.Println("  PUT /api/ai/profiles/{id} - Update AI provider profile by ID")
 	fmt.Println("  GET /health - Health check")
 
 	handlerWithLogging := loggingMiddleware(server)